@@ -2,6 +2,8 @@ package config
 
 import (
 	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -42,6 +44,11 @@ func (server *Server) SetupDatabaseConnection() *gorm.DB {
 		log.Fatal("Only PostgreSQL is supported")
 	}
 
+	// semua query model ber-workspace dibatasi ke workspace aktif di context
+	if err := server.DB.Use(repository.WorkspaceScopePlugin{}); err != nil {
+		log.Fatal("Failed to register workspace scope:", err)
+	}
+
 	// Run migrations
 	server.InitMigrate()
 	return server.DB
@@ -51,12 +58,123 @@ func (server *Server) InitMigrate() {
 
 	server.DB.Exec("CREATE EXTENSION IF NOT EXISTS \"uuid-ossp\"")
 
+	// priority sengaja tidak memakai tag default gorm: gorm mengganti zero value
+	// dengan default saat insert sehingga P0 akan tersimpan sebagai P2.
+	// Default hanya dibutuhkan untuk mengisi baris lama.
+	server.DB.Exec(`ALTER TABLE IF EXISTS "Tasks" ADD COLUMN IF NOT EXISTS priority bigint NOT NULL DEFAULT 2`)
+
+	server.DB.AutoMigrate(
+		&models.Workspace{},
+		&models.WorkspaceMember{},
+	)
+	server.migrateDefaultWorkspace()
+
 	// Auto migrate models
 	server.DB.AutoMigrate(
 		// &models.Account{},
+		&models.Label{},
+		&models.Project{},
+		&models.ProjectMember{},
+		&models.TaskRecurrence{},
+		&models.TaskRecurrenceSkip{},
 		&models.Task{},
+		&models.ChecklistItem{},
+		&models.TaskTemplate{},
+		&models.TaskTemplateSubtask{},
+		&models.WorkLog{},
+		&models.ImportJob{},
+		&models.CalendarToken{},
+		&models.AppPassword{},
+		&models.CalDAVObject{},
+		&models.TaskDependency{},
+		&models.TaskReminder{},
+		&models.SavedView{},
+		&models.TaskStatusChange{},
 	)
 
+	server.migrateTaskRanks()
+	server.migrateTaskSearch()
+
+	// task done lama belum punya completed_at, perkiraan terbaiknya updated_at
+	server.DB.Exec(`UPDATE "Tasks" SET completed_at = updated_at
+		WHERE status = 'done' AND completed_at IS NULL`)
+
+	// task lama belum punya riwayat status: anggap dibuat sebagai todo dan
+	// pindah ke status sekarang saat selesai atau terakhir diubah
+	server.DB.Exec(`WITH missing AS (
+			SELECT * FROM "Tasks" t
+			WHERE NOT EXISTS (SELECT 1 FROM task_status_changes c WHERE c.task_id = t.id)
+		)
+		INSERT INTO task_status_changes (workspace_id, task_id, status, changed_at)
+		SELECT workspace_id, id, 'todo', created_at FROM missing
+		UNION ALL
+		SELECT workspace_id, id, status, GREATEST(COALESCE(completed_at, updated_at), created_at)
+		FROM missing WHERE status <> 'todo'`)
+
+	// assignee lama (accounts_id) ikut menjadi anggota task_assignees
+	server.DB.Exec(`INSERT INTO task_assignees (task_id, account_id)
+		SELECT id, accounts_id FROM "Tasks"
+		ON CONFLICT DO NOTHING`)
+
+}
+
+// migrateDefaultWorkspace memindahkan data lama (sebelum ada workspace) ke
+// workspace "default" dan mendaftarkan semua akun sebagai member-nya.
+// Aman dijalankan berulang kali.
+func (server *Server) migrateDefaultWorkspace() {
+	server.DB.Exec(`INSERT INTO workspaces (name, slug, owner_accounts_id, created_at, updated_at)
+		SELECT 'Default', 'default', MIN(id), NOW(), NOW() FROM accounts HAVING COUNT(*) > 0
+		ON CONFLICT (slug) DO NOTHING`)
+
+	for _, table := range []string{`"Tasks"`, "projects", "labels"} {
+		server.DB.Exec(fmt.Sprintf("ALTER TABLE IF EXISTS %s ADD COLUMN IF NOT EXISTS workspace_id bigint", table))
+		server.DB.Exec(fmt.Sprintf(`UPDATE %s SET workspace_id = (SELECT id FROM workspaces WHERE slug = 'default')
+			WHERE workspace_id IS NULL`, table))
+	}
+
+	server.DB.Exec(`INSERT INTO workspace_members (workspace_id, accounts_id, role, created_at)
+		SELECT w.id, a.id, CASE WHEN a.id = w.owner_accounts_id THEN 'owner' ELSE 'member' END, NOW()
+		FROM accounts a CROSS JOIN workspaces w
+		WHERE w.slug = 'default'
+		AND NOT EXISTS (SELECT 1 FROM workspace_members m WHERE m.accounts_id = a.id)`)
+
+	// unique index lama bersifat global, sekarang unik per workspace
+	server.DB.Exec("DROP INDEX IF EXISTS idx_labels_name")
+	server.DB.Exec("DROP INDEX IF EXISTS idx_projects_key")
+}
+
+// migrateTaskRanks memakai collation "C" untuk rank (urutan byte, sesuai
+// alfabet fractional index) dan memberi rank ke task lama yang belum punya
+func (server *Server) migrateTaskRanks() {
+	server.DB.Exec(`ALTER TABLE "Tasks" ALTER COLUMN rank TYPE varchar(255) COLLATE "C"`)
+
+	// rank diurutkan per kolom status di setiap workspace
+	var columns []struct {
+		WorkspaceID uint
+		Status      string
+	}
+	server.DB.Model(&models.Task{}).
+		Where("rank IS NULL OR rank = ''").
+		Distinct("workspace_id", "status").
+		Scan(&columns)
+
+	taskRepo := repository.NewTaskRepository(server.DB)
+	for _, column := range columns {
+		if err := taskRepo.RebalanceRanks(context.Background(), column.WorkspaceID, column.Status); err != nil {
+			log.Printf("Failed to assign ranks for workspace %d status %q: %v", column.WorkspaceID, column.Status, err)
+		}
+	}
+}
+
+// migrateTaskSearch menambahkan kolom dan index full-text search task.
+// Butuh extension pg_trgm (contrib) tersedia di server database.
+func (server *Server) migrateTaskSearch() {
+	for _, statement := range repository.TaskSearchMigrations {
+		if err := server.DB.Exec(statement).Error; err != nil {
+			log.Printf("Failed to migrate task search: %v", err)
+			return
+		}
+	}
 }
 
 func CloseDatabaseConnection(db *gorm.DB) {
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"backend/internal/dto"
+	"backend/internal/helper"
+	"backend/internal/service"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AppPasswordController struct {
+	appPasswordService service.AppPasswordService
+}
+
+func NewAppPasswordController(appPasswordService service.AppPasswordService) *AppPasswordController {
+	return &AppPasswordController{
+		appPasswordService: appPasswordService,
+	}
+}
+
+func (c *AppPasswordController) All(ctx *gin.Context) {
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	passwords, err := c.appPasswordService.GetAppPasswords(ctx.Request.Context(), userID)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusInternalServerError, "Failed to get app passwords", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": passwords})
+}
+
+// Insert membuat app password baru; password hanya ditampilkan sekali di response ini
+func (c *AppPasswordController) Insert(ctx *gin.Context) {
+	var req dto.CreateAppPasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	password, err := c.appPasswordService.CreateAppPassword(ctx.Request.Context(), req, userID)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusInternalServerError, "Failed to create app password", err.Error())
+		return
+	}
+
+	helper.CreatedResponse(ctx, "App password created", password)
+}
+
+func (c *AppPasswordController) Delete(ctx *gin.Context) {
+	id, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	err = c.appPasswordService.DeleteAppPassword(ctx.Request.Context(), id, userID)
+	if errors.Is(err, service.ErrAppPasswordNotFound) {
+		helper.JSONError(ctx, http.StatusNotFound, "App password not found", err.Error())
+		return
+	}
+	if err != nil {
+		helper.JSONError(ctx, http.StatusInternalServerError, "Failed to delete app password", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "App password deleted"})
+}
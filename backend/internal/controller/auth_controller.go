@@ -4,6 +4,7 @@ import (
 	"backend/internal/dto"
 	"backend/internal/helper"
 	"backend/internal/service"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -95,9 +96,36 @@ func (c *AuthController) GetProfile(ctx *gin.Context) {
 	}
 
 	profile := map[string]interface{}{
-		"account_id": uid,
-		"email":      ctx.GetString("email"),
+		"account_id":   uid,
+		"email":        ctx.GetString("email"),
+		"workspace_id": ctx.GetUint("workspace_id"),
 	}
 
 	helper.SuccessResponse(ctx, "Profile retrieved successfully", profile)
 }
+
+func (c *AuthController) SwitchWorkspace(ctx *gin.Context) {
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	var req dto.SwitchWorkspaceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	authResponse, err := c.authService.SwitchWorkspace(ctx.Request.Context(), userID, req)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, service.ErrWorkspaceNotFound) {
+			status = http.StatusNotFound
+		}
+		helper.JSONError(ctx, status, "Failed to switch workspace", err.Error())
+		return
+	}
+
+	helper.SuccessResponse(ctx, "Workspace switched successfully", authResponse)
+}
@@ -0,0 +1,617 @@
+package controller
+
+import (
+	"backend/internal/dto"
+	"backend/internal/helper"
+	"backend/internal/service"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	caldavBasePath     = "/caldav"
+	caldavCalendarPath = caldavBasePath + "/calendars/"
+	caldavPrincipal    = caldavBasePath + "/principal/"
+	// maxCalDAVBody membatasi ukuran body PUT, PROPFIND dan REPORT
+	maxCalDAVBody = 1 << 20
+
+	caldavContentType = "text/calendar; charset=utf-8"
+	davXMLContentType = "application/xml; charset=utf-8"
+)
+
+var (
+	davResourceType        = xml.Name{Space: dto.DAVNamespace, Local: "resourcetype"}
+	davDisplayName         = xml.Name{Space: dto.DAVNamespace, Local: "displayname"}
+	davCurrentUser         = xml.Name{Space: dto.DAVNamespace, Local: "current-user-principal"}
+	davPrincipalURL        = xml.Name{Space: dto.DAVNamespace, Local: "principal-URL"}
+	davOwner               = xml.Name{Space: dto.DAVNamespace, Local: "owner"}
+	davPrivilegeSet        = xml.Name{Space: dto.DAVNamespace, Local: "current-user-privilege-set"}
+	davSupportedReportSet  = xml.Name{Space: dto.DAVNamespace, Local: "supported-report-set"}
+	davGetETag             = xml.Name{Space: dto.DAVNamespace, Local: "getetag"}
+	davGetContentType      = xml.Name{Space: dto.DAVNamespace, Local: "getcontenttype"}
+	davGetLastModified     = xml.Name{Space: dto.DAVNamespace, Local: "getlastmodified"}
+	caldavHomeSet          = xml.Name{Space: dto.CalDAVNamespace, Local: "calendar-home-set"}
+	caldavUserAddressSet   = xml.Name{Space: dto.CalDAVNamespace, Local: "calendar-user-address-set"}
+	caldavComponentSet     = xml.Name{Space: dto.CalDAVNamespace, Local: "supported-calendar-component-set"}
+	caldavCalendarData     = xml.Name{Space: dto.CalDAVNamespace, Local: "calendar-data"}
+	calendarServerCTag     = xml.Name{Space: dto.CalendarServerNamespace, Local: "getctag"}
+	caldavCalendarQuery    = xml.Name{Space: dto.CalDAVNamespace, Local: "calendar-query"}
+	caldavCalendarMultiget = xml.Name{Space: dto.CalDAVNamespace, Local: "calendar-multiget"}
+)
+
+var davPrefixes = map[string]string{
+	dto.DAVNamespace:            "D",
+	dto.CalDAVNamespace:         "C",
+	dto.CalendarServerNamespace: "CS",
+}
+
+// davProp adalah satu property resource yang sudah di-render sebagai XML
+type davProp struct {
+	Name xml.Name
+	XML  string
+}
+
+// davPropRequest adalah property yang diminta PROPFIND atau REPORT; Names
+// nil berarti allprop
+type davPropRequest struct {
+	Names    []xml.Name
+	NameOnly bool
+}
+
+func (r davPropRequest) wants(name xml.Name) bool {
+	for _, requested := range r.Names {
+		if requested == name {
+			return true
+		}
+	}
+	return false
+}
+
+// CalDAVController melayani task sebagai kalender VTODO (RFC 4791):
+//
+//	/caldav/                          root, berisi property principal
+//	/caldav/principal/                principal user yang login
+//	/caldav/calendars/                calendar home
+//	/caldav/calendars/<collection>/   "tasks" atau "project-<id>"
+//	/caldav/calendars/<collection>/<name>.ics
+type CalDAVController struct {
+	caldavService service.CalDAVService
+}
+
+func NewCalDAVController(caldavService service.CalDAVService) *CalDAVController {
+	return &CalDAVController{
+		caldavService: caldavService,
+	}
+}
+
+// caldavPath adalah path request yang sudah dipecah
+type caldavPath struct {
+	Principal  bool
+	Home       bool
+	Collection string
+	Object     string
+}
+
+func (p caldavPath) IsRoot() bool {
+	return !p.Principal && !p.Home && p.Collection == ""
+}
+
+func parseCalDAVPath(path string) (caldavPath, bool) {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return caldavPath{}, true
+	}
+
+	segments := strings.Split(path, "/")
+	switch {
+	case len(segments) == 1 && segments[0] == "principal":
+		return caldavPath{Principal: true}, true
+	case segments[0] != "calendars" || len(segments) > 3:
+		return caldavPath{}, false
+	case len(segments) == 1:
+		return caldavPath{Home: true}, true
+	case len(segments) == 2:
+		return caldavPath{Collection: segments[1]}, true
+	default:
+		return caldavPath{Collection: segments[1], Object: segments[2]}, true
+	}
+}
+
+// Options tidak memerlukan autentikasi supaya client bisa mendeteksi
+// dukungan CalDAV sebelum login
+func (c *CalDAVController) Options(ctx *gin.Context) {
+	ctx.Header("DAV", "1, 3, calendar-access")
+	ctx.Header("Allow", "OPTIONS, GET, HEAD, PUT, DELETE, PROPFIND, REPORT")
+	ctx.Status(http.StatusOK)
+}
+
+// WellKnown mengarahkan discovery RFC 6764 ke root CalDAV
+func (c *CalDAVController) WellKnown(ctx *gin.Context) {
+	ctx.Redirect(http.StatusMovedPermanently, caldavBasePath+"/")
+}
+
+func (c *CalDAVController) Propfind(ctx *gin.Context) {
+	path, ok := parseCalDAVPath(ctx.Param("path"))
+	if !ok {
+		helper.JSONError(ctx, http.StatusNotFound, "Not found", "unknown CalDAV path")
+		return
+	}
+
+	propRequest, err := readPropfind(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	// Depth infinity diperlakukan sama dengan 1
+	depth := ctx.GetHeader("Depth") != "0"
+	requestCtx := ctx.Request.Context()
+	var responses []dto.DAVResponse
+
+	switch {
+	case path.IsRoot(), path.Principal:
+		href := caldavBasePath + "/"
+		if path.Principal {
+			href = caldavPrincipal
+		}
+		responses = append(responses, davPropResponse(href, c.principalProps(ctx, path.Principal), propRequest))
+		if depth && path.IsRoot() {
+			responses = append(responses,
+				davPropResponse(caldavPrincipal, c.principalProps(ctx, true), propRequest),
+				davPropResponse(caldavCalendarPath, c.homeProps(), propRequest))
+		}
+
+	case path.Home:
+		responses = append(responses, davPropResponse(caldavCalendarPath, c.homeProps(), propRequest))
+		if depth {
+			collections, err := c.caldavService.GetCollections(requestCtx, userID)
+			if err != nil {
+				helper.JSONError(ctx, caldavErrorStatus(err), "Failed to get calendars", err.Error())
+				return
+			}
+			for i := range collections {
+				responses = append(responses, davPropResponse(collectionHref(collections[i].Name), c.collectionProps(&collections[i]), propRequest))
+			}
+		}
+
+	case path.Object == "":
+		collection, err := c.caldavService.GetCollection(requestCtx, path.Collection, userID)
+		if err != nil {
+			helper.JSONError(ctx, caldavErrorStatus(err), "Failed to get calendar", err.Error())
+			return
+		}
+		responses = append(responses, davPropResponse(collectionHref(collection.Name), c.collectionProps(collection), propRequest))
+		if depth {
+			objects, err := c.caldavService.GetObjects(requestCtx, collection)
+			if err != nil {
+				helper.JSONError(ctx, caldavErrorStatus(err), "Failed to get tasks", err.Error())
+				return
+			}
+			responses = append(responses, c.objectResponses(collection, objects, propRequest)...)
+		}
+
+	default:
+		collection, object, err := c.getObject(ctx, path, userID)
+		if err != nil {
+			helper.JSONError(ctx, caldavErrorStatus(err), "Failed to get task", err.Error())
+			return
+		}
+		responses = append(responses, c.objectResponses(collection, []dto.CalDAVObject{*object}, propRequest)...)
+	}
+
+	writeMultistatus(ctx, responses)
+}
+
+// Report mendukung calendar-query dan calendar-multiget pada collection.
+// Filter time-range tidak dievaluasi: query selalu mengembalikan semua
+// VTODO di collection, yang tetap benar untuk client yang menyinkronkan
+// seluruh kalender.
+func (c *CalDAVController) Report(ctx *gin.Context) {
+	path, ok := parseCalDAVPath(ctx.Param("path"))
+	if !ok || path.Collection == "" || path.Object != "" {
+		helper.JSONError(ctx, http.StatusNotFound, "Not found", "REPORT is only supported on calendar collections")
+		return
+	}
+
+	var report dto.CalDAVReport
+	if err := xml.NewDecoder(io.LimitReader(ctx.Request.Body, maxCalDAVBody)).Decode(&report); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	collection, err := c.caldavService.GetCollection(ctx.Request.Context(), path.Collection, userID)
+	if err != nil {
+		helper.JSONError(ctx, caldavErrorStatus(err), "Failed to get calendar", err.Error())
+		return
+	}
+	propRequest := davPropRequest{}
+	if report.Prop != nil {
+		propRequest.Names = davPropNames(report.Prop)
+	}
+
+	switch report.XMLName {
+	case caldavCalendarQuery:
+		if report.Filter != nil && !filterMatchesTodo(report.Filter.CompFilter) {
+			writeMultistatus(ctx, nil)
+			return
+		}
+		objects, err := c.caldavService.GetObjects(ctx.Request.Context(), collection)
+		if err != nil {
+			helper.JSONError(ctx, caldavErrorStatus(err), "Failed to get tasks", err.Error())
+			return
+		}
+		writeMultistatus(ctx, c.objectResponses(collection, objects, propRequest))
+
+	case caldavCalendarMultiget:
+		var responses []dto.DAVResponse
+		prefix := collectionHref(collection.Name)
+		for _, href := range report.Hrefs {
+			href = strings.TrimSpace(href)
+			name, err := url.PathUnescape(strings.TrimPrefix(hrefPath(href), prefix))
+			var object *dto.CalDAVObject
+			if err == nil && strings.HasPrefix(hrefPath(href), prefix) {
+				object, err = c.caldavService.GetObject(ctx.Request.Context(), collection, name)
+			}
+			if err != nil && !errors.Is(err, service.ErrCalDAVNotFound) {
+				helper.JSONError(ctx, caldavErrorStatus(err), "Failed to get task", err.Error())
+				return
+			}
+			if object == nil {
+				responses = append(responses, dto.DAVResponse{Href: href, Status: davStatus(http.StatusNotFound)})
+				continue
+			}
+			responses = append(responses, c.objectResponses(collection, []dto.CalDAVObject{*object}, propRequest)...)
+		}
+		writeMultistatus(ctx, responses)
+
+	default:
+		helper.JSONError(ctx, http.StatusForbidden, "Unsupported report", report.XMLName.Local)
+	}
+}
+
+func (c *CalDAVController) Get(ctx *gin.Context) {
+	path, ok := parseCalDAVPath(ctx.Param("path"))
+	if !ok || path.Object == "" {
+		helper.JSONError(ctx, http.StatusMethodNotAllowed, "Method not allowed", "GET is only supported on calendar objects")
+		return
+	}
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	_, object, err := c.getObject(ctx, path, userID)
+	if err != nil {
+		helper.JSONError(ctx, caldavErrorStatus(err), "Failed to get task", err.Error())
+		return
+	}
+
+	var body bytes.Buffer
+	if err := c.caldavService.WriteObject(&body, object); err != nil {
+		helper.JSONError(ctx, http.StatusInternalServerError, "Failed to build calendar object", err.Error())
+		return
+	}
+
+	ctx.Header("ETag", helper.ETag(object.Task.Version))
+	ctx.Header("Last-Modified", object.Task.UpdatedAt.UTC().Format(http.TimeFormat))
+	ctx.Data(http.StatusOK, caldavContentType, body.Bytes())
+}
+
+// Put membuat task baru atau mengubah title, description, status, deadline
+// dan priority task dari VTODO yang dikirim client
+func (c *CalDAVController) Put(ctx *gin.Context) {
+	path, ok := parseCalDAVPath(ctx.Param("path"))
+	if !ok || path.Object == "" {
+		helper.JSONError(ctx, http.StatusMethodNotAllowed, "Method not allowed", "PUT is only supported on calendar objects")
+		return
+	}
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	collection, err := c.caldavService.GetCollection(ctx.Request.Context(), path.Collection, userID)
+	if err != nil {
+		helper.JSONError(ctx, caldavErrorStatus(err), "Failed to get calendar", err.Error())
+		return
+	}
+
+	body := http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxCalDAVBody)
+	object, created, err := c.caldavService.PutObject(ctx.Request.Context(), collection, path.Object, body, caldavPrecondition(ctx), userID)
+	if err != nil {
+		helper.JSONError(ctx, caldavErrorStatus(err), "Failed to save task", err.Error())
+		return
+	}
+
+	ctx.Header("ETag", helper.ETag(object.Task.Version))
+	if created {
+		ctx.Header("Location", objectHref(collection.Name, object.Name))
+		ctx.Status(http.StatusCreated)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *CalDAVController) Delete(ctx *gin.Context) {
+	path, ok := parseCalDAVPath(ctx.Param("path"))
+	if !ok || path.Object == "" {
+		helper.JSONError(ctx, http.StatusMethodNotAllowed, "Method not allowed", "DELETE is only supported on calendar objects")
+		return
+	}
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	collection, err := c.caldavService.GetCollection(ctx.Request.Context(), path.Collection, userID)
+	if err != nil {
+		helper.JSONError(ctx, caldavErrorStatus(err), "Failed to get calendar", err.Error())
+		return
+	}
+	if err := c.caldavService.DeleteObject(ctx.Request.Context(), collection, path.Object, caldavPrecondition(ctx)); err != nil {
+		helper.JSONError(ctx, caldavErrorStatus(err), "Failed to delete task", err.Error())
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *CalDAVController) getObject(ctx *gin.Context, path caldavPath, userID uint) (*dto.CalDAVCollection, *dto.CalDAVObject, error) {
+	collection, err := c.caldavService.GetCollection(ctx.Request.Context(), path.Collection, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	object, err := c.caldavService.GetObject(ctx.Request.Context(), collection, path.Object)
+	if err != nil {
+		return nil, nil, err
+	}
+	return collection, object, nil
+}
+
+// principalProps dipakai untuk root dan principal, supaya client yang
+// memulai discovery dari root menemukan calendar-home-set
+func (c *CalDAVController) principalProps(ctx *gin.Context, principal bool) []davProp {
+	resourceType := "<D:collection/>"
+	if principal {
+		resourceType = "<D:principal/>"
+	}
+	email := ctx.GetString("email")
+	return []davProp{
+		{davResourceType, davElement(davResourceType, resourceType)},
+		{davDisplayName, davElement(davDisplayName, davText(email))},
+		{davCurrentUser, davElement(davCurrentUser, davHref(caldavPrincipal))},
+		{davPrincipalURL, davElement(davPrincipalURL, davHref(caldavPrincipal))},
+		{caldavHomeSet, davElement(caldavHomeSet, davHref(caldavCalendarPath))},
+		{caldavUserAddressSet, davElement(caldavUserAddressSet, davHref("mailto:"+email))},
+	}
+}
+
+func (c *CalDAVController) homeProps() []davProp {
+	return []davProp{
+		{davResourceType, davElement(davResourceType, "<D:collection/>")},
+		{davDisplayName, davElement(davDisplayName, "Calendars")},
+		{davCurrentUser, davElement(davCurrentUser, davHref(caldavPrincipal))},
+		{davOwner, davElement(davOwner, davHref(caldavPrincipal))},
+	}
+}
+
+func (c *CalDAVController) collectionProps(collection *dto.CalDAVCollection) []davProp {
+	return []davProp{
+		{davResourceType, davElement(davResourceType, "<D:collection/><C:calendar/>")},
+		{davDisplayName, davElement(davDisplayName, davText(collection.DisplayName))},
+		{davCurrentUser, davElement(davCurrentUser, davHref(caldavPrincipal))},
+		{davOwner, davElement(davOwner, davHref(caldavPrincipal))},
+		{davPrivilegeSet, davElement(davPrivilegeSet,
+			"<D:privilege><D:read/></D:privilege><D:privilege><D:write/></D:privilege>"+
+				"<D:privilege><D:write-content/></D:privilege><D:privilege><D:bind/></D:privilege>"+
+				"<D:privilege><D:unbind/></D:privilege>")},
+		{caldavComponentSet, davElement(caldavComponentSet, `<C:comp name="VTODO"/>`)},
+		{davSupportedReportSet, davElement(davSupportedReportSet,
+			"<D:supported-report><D:report><C:calendar-query/></D:report></D:supported-report>"+
+				"<D:supported-report><D:report><C:calendar-multiget/></D:report></D:supported-report>")},
+		{calendarServerCTag, davElement(calendarServerCTag, davText(collection.CTag))},
+	}
+}
+
+// objectResponses me-render object; calendar-data hanya dibuat jika diminta
+func (c *CalDAVController) objectResponses(collection *dto.CalDAVCollection, objects []dto.CalDAVObject, propRequest davPropRequest) []dto.DAVResponse {
+	responses := make([]dto.DAVResponse, 0, len(objects))
+	for i := range objects {
+		object := &objects[i]
+		props := []davProp{
+			{davResourceType, davElement(davResourceType, "")},
+			{davGetETag, davElement(davGetETag, davText(helper.ETag(object.Task.Version)))},
+			{davGetContentType, davElement(davGetContentType, "text/calendar; charset=utf-8; component=VTODO")},
+			{davGetLastModified, davElement(davGetLastModified, object.Task.UpdatedAt.UTC().Format(http.TimeFormat))},
+		}
+		if propRequest.wants(caldavCalendarData) {
+			var data bytes.Buffer
+			if err := c.caldavService.WriteObject(&data, object); err == nil {
+				props = append(props, davProp{caldavCalendarData, davElement(caldavCalendarData, davText(data.String()))})
+			}
+		}
+		responses = append(responses, davPropResponse(objectHref(collection.Name, object.Name), props, propRequest))
+	}
+	return responses
+}
+
+// davPropResponse membagi property yang diminta menjadi propstat 200 dan 404
+func davPropResponse(href string, props []davProp, propRequest davPropRequest) dto.DAVResponse {
+	var found, missing strings.Builder
+
+	switch {
+	case propRequest.NameOnly:
+		for _, prop := range props {
+			found.WriteString(davElement(prop.Name, ""))
+		}
+	case propRequest.Names == nil:
+		for _, prop := range props {
+			found.WriteString(prop.XML)
+		}
+	default:
+		for _, name := range propRequest.Names {
+			rendered := ""
+			for _, prop := range props {
+				if prop.Name == name {
+					rendered = prop.XML
+					break
+				}
+			}
+			if rendered != "" {
+				found.WriteString(rendered)
+				continue
+			}
+			fmt.Fprintf(&missing, `<%s xmlns="%s"/>`, name.Local, davText(name.Space))
+		}
+	}
+
+	response := dto.DAVResponse{Href: href}
+	if found.Len() > 0 || missing.Len() == 0 {
+		response.Propstats = append(response.Propstats, dto.DAVPropstat{
+			Prop:   dto.DAVProp{InnerXML: found.String()},
+			Status: davStatus(http.StatusOK),
+		})
+	}
+	if missing.Len() > 0 {
+		response.Propstats = append(response.Propstats, dto.DAVPropstat{
+			Prop:   dto.DAVProp{InnerXML: missing.String()},
+			Status: davStatus(http.StatusNotFound),
+		})
+	}
+	return response
+}
+
+// readPropfind membaca body PROPFIND; body kosong berarti allprop
+func readPropfind(ctx *gin.Context) (davPropRequest, error) {
+	body, err := io.ReadAll(io.LimitReader(ctx.Request.Body, maxCalDAVBody))
+	if err != nil || len(bytes.TrimSpace(body)) == 0 {
+		return davPropRequest{}, err
+	}
+
+	var propfind dto.DAVPropfind
+	if err := xml.Unmarshal(body, &propfind); err != nil {
+		return davPropRequest{}, err
+	}
+	switch {
+	case propfind.PropName != nil:
+		return davPropRequest{NameOnly: true}, nil
+	case propfind.Prop != nil:
+		return davPropRequest{Names: davPropNames(propfind.Prop)}, nil
+	default:
+		return davPropRequest{}, nil
+	}
+}
+
+func davPropNames(prop *dto.DAVPropNames) []xml.Name {
+	names := make([]xml.Name, len(prop.Names))
+	for i, element := range prop.Names {
+		names[i] = element.XMLName
+	}
+	return names
+}
+
+// filterMatchesTodo false jika comp-filter hanya meminta komponen selain
+// VTODO (misalnya VEVENT), sehingga hasil query pasti kosong
+func filterMatchesTodo(filter dto.CalDAVCompFilter) bool {
+	if len(filter.Children) == 0 {
+		return true
+	}
+	for _, child := range filter.Children {
+		if child.Name == "VTODO" {
+			return true
+		}
+	}
+	return false
+}
+
+// caldavPrecondition membaca If-Match dan If-None-Match: *
+func caldavPrecondition(ctx *gin.Context) dto.CalDAVPrecondition {
+	return dto.CalDAVPrecondition{
+		IfMatch:     helper.ParseIfMatch(ctx.GetHeader("If-Match")),
+		IfNoneMatch: strings.TrimSpace(ctx.GetHeader("If-None-Match")) == "*",
+	}
+}
+
+func writeMultistatus(ctx *gin.Context, responses []dto.DAVResponse) {
+	body, err := xml.Marshal(dto.NewDAVMultistatus(responses))
+	if err != nil {
+		helper.JSONError(ctx, http.StatusInternalServerError, "Failed to build response", err.Error())
+		return
+	}
+	ctx.Data(http.StatusMultiStatus, davXMLContentType, append([]byte(xml.Header), body...))
+}
+
+func collectionHref(name string) string {
+	return caldavCalendarPath + url.PathEscape(name) + "/"
+}
+
+func objectHref(collection, name string) string {
+	return collectionHref(collection) + url.PathEscape(name)
+}
+
+// hrefPath mengambil path dari href, yang boleh berupa URL absolut
+func hrefPath(href string) string {
+	if parsed, err := url.Parse(href); err == nil {
+		return parsed.EscapedPath()
+	}
+	return href
+}
+
+func davElement(name xml.Name, inner string) string {
+	prefix := davPrefixes[name.Space]
+	if inner == "" {
+		return "<" + prefix + ":" + name.Local + "/>"
+	}
+	return "<" + prefix + ":" + name.Local + ">" + inner + "</" + prefix + ":" + name.Local + ">"
+}
+
+func davHref(href string) string {
+	return "<D:href>" + davText(href) + "</D:href>"
+}
+
+func davText(value string) string {
+	var escaped strings.Builder
+	xml.EscapeText(&escaped, []byte(value))
+	return escaped.String()
+}
+
+func davStatus(code int) string {
+	return fmt.Sprintf("HTTP/1.1 %d %s", code, http.StatusText(code))
+}
+
+// caldavErrorStatus memetakan error dari CalDAVService ke HTTP status code
+func caldavErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrCalDAVNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrCalDAVInvalidObject):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrCalDAVUnsupportedComponent):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrCalDAVUIDConflict):
+		return http.StatusConflict
+	default:
+		return taskErrorStatus(err)
+	}
+}
@@ -0,0 +1,128 @@
+package controller
+
+import (
+	"backend/internal/dto"
+	"backend/internal/helper"
+	"backend/internal/service"
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const calendarFeedPath = "/api/calendar/feed/"
+
+type CalendarController struct {
+	calendarService service.CalendarService
+}
+
+func NewCalendarController(calendarService service.CalendarService) *CalendarController {
+	return &CalendarController{
+		calendarService: calendarService,
+	}
+}
+
+// CreateToken membuat URL langganan baru; URL lama langsung tidak berlaku
+func (c *CalendarController) CreateToken(ctx *gin.Context) {
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	token, calendarToken, err := c.calendarService.CreateToken(ctx.Request.Context(), userID)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusInternalServerError, "Failed to create calendar token", err.Error())
+		return
+	}
+
+	helper.CreatedResponse(ctx, "Calendar token created", dto.CalendarTokenResponse{
+		Token:      token,
+		URL:        requestBaseURL(ctx) + calendarFeedPath + token + ".ics",
+		LastUsedAt: calendarToken.LastUsedAt,
+		CreatedAt:  calendarToken.CreatedAt,
+	})
+}
+
+func (c *CalendarController) GetToken(ctx *gin.Context) {
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	calendarToken, err := c.calendarService.GetToken(ctx.Request.Context(), userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		helper.JSONError(ctx, http.StatusNotFound, "Calendar token not found", err.Error())
+		return
+	}
+	if err != nil {
+		helper.JSONError(ctx, http.StatusInternalServerError, "Failed to get calendar token", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": dto.CalendarTokenResponse{
+		LastUsedAt: calendarToken.LastUsedAt,
+		CreatedAt:  calendarToken.CreatedAt,
+	}})
+}
+
+func (c *CalendarController) RevokeToken(ctx *gin.Context) {
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	if err := c.calendarService.RevokeToken(ctx.Request.Context(), userID); err != nil {
+		helper.JSONError(ctx, http.StatusInternalServerError, "Failed to revoke calendar token", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Calendar token revoked"})
+}
+
+// Feed melayani URL langganan kalender. Tidak memakai JWT: aksesnya
+// ditentukan token di path, yang boleh diakhiri ".ics".
+func (c *CalendarController) Feed(ctx *gin.Context) {
+	var req dto.CalendarFeedRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	token := strings.TrimSuffix(ctx.Param("token"), ".ics")
+
+	// ditulis ke buffer dulu supaya token tidak valid tetap mendapat 404
+	var body bytes.Buffer
+	err := c.calendarService.WriteFeed(ctx.Request.Context(), token, req, &body)
+	if errors.Is(err, service.ErrCalendarTokenInvalid) {
+		helper.JSONError(ctx, http.StatusNotFound, "Calendar not found", err.Error())
+		return
+	}
+	if err != nil {
+		helper.JSONError(ctx, http.StatusInternalServerError, "Failed to build calendar", err.Error())
+		return
+	}
+
+	ctx.Header("Content-Disposition", `inline; filename="tasks.ics"`)
+	ctx.Header("Cache-Control", "private, max-age=300")
+	ctx.Data(http.StatusOK, "text/calendar; charset=utf-8", body.Bytes())
+}
+
+// requestBaseURL membangun scheme dan host dari request, dengan
+// memperhatikan reverse proxy
+func requestBaseURL(ctx *gin.Context) string {
+	scheme := "http"
+	if ctx.Request.TLS != nil || ctx.GetHeader("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	host := ctx.Request.Host
+	if forwarded := ctx.GetHeader("X-Forwarded-Host"); forwarded != "" {
+		host = forwarded
+	}
+	return scheme + "://" + host
+}
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"backend/internal/dto"
+	"backend/internal/helper"
+	"backend/internal/service"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ChecklistController struct {
+	checklistService service.ChecklistService
+}
+
+func NewChecklistController(checklistService service.ChecklistService) *ChecklistController {
+	return &ChecklistController{
+		checklistService: checklistService,
+	}
+}
+
+func (c *ChecklistController) All(ctx *gin.Context) {
+	taskID, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	items, err := c.checklistService.GetItems(ctx.Request.Context(), taskID)
+	if err != nil {
+		c.handleError(ctx, "Failed to get checklist", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": items})
+}
+
+func (c *ChecklistController) Insert(ctx *gin.Context) {
+	taskID, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var req dto.CreateChecklistItemRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	item, err := c.checklistService.AddItem(ctx.Request.Context(), taskID, req)
+	if err != nil {
+		c.handleError(ctx, "Failed to create checklist item", err)
+		return
+	}
+
+	helper.CreatedResponse(ctx, "Checklist item created successfully", item)
+}
+
+func (c *ChecklistController) Update(ctx *gin.Context) {
+	taskID, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	itemID, err := helper.GetParamID(ctx, "itemId")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid checklist item ID", err.Error())
+		return
+	}
+
+	var req dto.UpdateChecklistItemRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	item, err := c.checklistService.UpdateItem(ctx.Request.Context(), taskID, itemID, req)
+	if err != nil {
+		c.handleError(ctx, "Failed to update checklist item", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Checklist item updated successfully", "data": item})
+}
+
+func (c *ChecklistController) Delete(ctx *gin.Context) {
+	taskID, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	itemID, err := helper.GetParamID(ctx, "itemId")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid checklist item ID", err.Error())
+		return
+	}
+
+	if err := c.checklistService.DeleteItem(ctx.Request.Context(), taskID, itemID); err != nil {
+		c.handleError(ctx, "Failed to delete checklist item", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Checklist item deleted successfully"})
+}
+
+func (c *ChecklistController) handleError(ctx *gin.Context, message string, err error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		helper.JSONError(ctx, http.StatusNotFound, message, err.Error())
+		return
+	}
+	helper.JSONError(ctx, http.StatusBadRequest, message, err.Error())
+}
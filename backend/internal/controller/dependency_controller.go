@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"backend/internal/dto"
+	"backend/internal/helper"
+	"backend/internal/service"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type DependencyController struct {
+	dependencyService service.DependencyService
+}
+
+func NewDependencyController(dependencyService service.DependencyService) *DependencyController {
+	return &DependencyController{
+		dependencyService: dependencyService,
+	}
+}
+
+func (c *DependencyController) Insert(ctx *gin.Context) {
+	taskID, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var req dto.AddDependencyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	dependency, err := c.dependencyService.AddDependency(ctx.Request.Context(), taskID, req.BlockedByID, userID)
+	if err != nil {
+		helper.JSONError(ctx, dependencyErrorStatus(err), "Failed to add dependency", err.Error())
+		return
+	}
+
+	helper.CreatedResponse(ctx, "Dependency added successfully", dependency)
+}
+
+func (c *DependencyController) Delete(ctx *gin.Context) {
+	taskID, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	blockedByID, err := helper.GetParamID(ctx, "blockedById")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid blocker ID", err.Error())
+		return
+	}
+
+	if err := c.dependencyService.RemoveDependency(ctx.Request.Context(), taskID, blockedByID); err != nil {
+		helper.JSONError(ctx, dependencyErrorStatus(err), "Failed to remove dependency", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Dependency removed successfully"})
+}
+
+func dependencyErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrDependencyCycle), errors.Is(err, service.ErrDependencyExists):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrSelfDependency):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,179 @@
+package controller
+
+import (
+	"backend/internal/dto"
+	"backend/internal/helper"
+	"backend/internal/service"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportFileSize membatasi ukuran file yang diupload untuk import
+const maxImportFileSize = 10 << 20
+
+type ImportController struct {
+	importService service.ImportService
+}
+
+func NewImportController(importService service.ImportService) *ImportController {
+	return &ImportController{
+		importService: importService,
+	}
+}
+
+// Preview memvalidasi file import tanpa membuat task (dry run)
+func (c *ImportController) Preview(ctx *gin.Context) {
+	req, opts, ok := bindImportRequest(ctx)
+	if !ok {
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	file, err := req.File.Open()
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid file", err.Error())
+		return
+	}
+	defer file.Close()
+
+	preview, err := c.importService.Preview(ctx.Request.Context(), file, opts, userID)
+	if err != nil {
+		helper.JSONError(ctx, importErrorStatus(err), "Failed to preview import", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Import preview", "data": preview})
+}
+
+// Insert membuat job import yang dijalankan di background, progress-nya
+// dipantau lewat GET /import/:id
+func (c *ImportController) Insert(ctx *gin.Context) {
+	req, opts, ok := bindImportRequest(ctx)
+	if !ok {
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	file, err := req.File.Open()
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid file", err.Error())
+		return
+	}
+	defer file.Close()
+
+	job, preview, err := c.importService.StartImport(ctx.Request.Context(), file, opts, userID)
+	if errors.Is(err, service.ErrImportInvalidRows) {
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{"message": err.Error(), "data": preview})
+		return
+	}
+	if err != nil {
+		helper.JSONError(ctx, importErrorStatus(err), "Failed to start import", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, gin.H{"message": "Import started", "data": job})
+}
+
+func (c *ImportController) All(ctx *gin.Context) {
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	jobs, err := c.importService.GetJobs(ctx.Request.Context(), userID)
+	if err != nil {
+		helper.JSONError(ctx, importErrorStatus(err), "Failed to get import jobs", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": jobs})
+}
+
+func (c *ImportController) FindByID(ctx *gin.Context) {
+	id, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	job, err := c.importService.GetJob(ctx.Request.Context(), id, userID)
+	if err != nil {
+		helper.JSONError(ctx, importErrorStatus(err), "Failed to get import job", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": job})
+}
+
+// bindImportRequest membaca form multipart import dan objek JSON di dalamnya.
+// Response error sudah ditulis jika ok false.
+func bindImportRequest(ctx *gin.Context) (*dto.ImportRequest, dto.ImportOptions, bool) {
+	var req dto.ImportRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return nil, dto.ImportOptions{}, false
+	}
+	if req.File.Size > maxImportFileSize {
+		helper.JSONError(ctx, http.StatusRequestEntityTooLarge, "Invalid request",
+			fmt.Sprintf("file is larger than %d MB", maxImportFileSize>>20))
+		return nil, dto.ImportOptions{}, false
+	}
+
+	opts := dto.ImportOptions{
+		Format:      req.Format,
+		ProjectID:   req.ProjectID,
+		SkipInvalid: req.SkipInvalid,
+	}
+	fields := map[string]struct {
+		raw    string
+		target *map[string]string
+	}{
+		"columns":     {req.Columns, &opts.Columns},
+		"status_map":  {req.StatusMap, &opts.StatusMap},
+		"account_map": {req.AccountMap, &opts.AccountMap},
+	}
+	for name, field := range fields {
+		if field.raw == "" {
+			continue
+		}
+		if err := json.Unmarshal([]byte(field.raw), field.target); err != nil {
+			helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", fmt.Sprintf("%s must be a JSON object of strings", name))
+			return nil, dto.ImportOptions{}, false
+		}
+	}
+
+	return &req, opts, true
+}
+
+func importErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrImportInvalidFile), errors.Is(err, service.ErrInvalidImportMapping),
+		errors.Is(err, service.ErrImportEmpty), errors.Is(err, service.ErrImportTooLarge):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrImportJobNotFound):
+		return http.StatusNotFound
+	default:
+		return taskErrorStatus(err)
+	}
+}
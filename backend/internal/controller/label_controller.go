@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"backend/internal/dto"
+	"backend/internal/helper"
+	"backend/internal/service"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type LabelController struct {
+	labelService service.LabelService
+}
+
+func NewLabelController(labelService service.LabelService) *LabelController {
+	return &LabelController{
+		labelService: labelService,
+	}
+}
+
+func (c *LabelController) All(ctx *gin.Context) {
+	labels, err := c.labelService.GetAllLabels(ctx.Request.Context(), helper.GetQueryString(ctx, "search"))
+	if err != nil {
+		helper.JSONError(ctx, http.StatusInternalServerError, "Failed to get labels", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": labels})
+}
+
+func (c *LabelController) Insert(ctx *gin.Context) {
+	var req dto.CreateLabelRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	label, err := c.labelService.CreateLabel(ctx.Request.Context(), req, userID)
+	if err != nil {
+		helper.JSONError(ctx, labelErrorStatus(err), "Failed to create label", err.Error())
+		return
+	}
+
+	helper.CreatedResponse(ctx, "Label created successfully", label)
+}
+
+func (c *LabelController) FindByID(ctx *gin.Context) {
+	id, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	label, err := c.labelService.GetLabelByID(ctx.Request.Context(), id)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusNotFound, "Label not found", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, label)
+}
+
+func (c *LabelController) Update(ctx *gin.Context) {
+	id, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var req dto.UpdateLabelRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	label, err := c.labelService.UpdateLabel(ctx.Request.Context(), id, req)
+	if err != nil {
+		helper.JSONError(ctx, labelErrorStatus(err), "Failed to update label", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Label updated successfully", "data": label})
+}
+
+func (c *LabelController) Delete(ctx *gin.Context) {
+	id, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	if err := c.labelService.DeleteLabel(ctx.Request.Context(), id); err != nil {
+		helper.JSONError(ctx, labelErrorStatus(err), "Failed to delete label", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Label deleted successfully"})
+}
+
+func labelErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrLabelExists):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"backend/internal/dto"
+	"backend/internal/helper"
+	"backend/internal/service"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MetricsController struct {
+	metricsService service.MetricsService
+}
+
+func NewMetricsController(metricsService service.MetricsService) *MetricsController {
+	return &MetricsController{
+		metricsService: metricsService,
+	}
+}
+
+func (c *MetricsController) LeadTime(ctx *gin.Context) {
+	var req dto.MetricsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	metric, err := c.metricsService.GetLeadTime(ctx.Request.Context(), req)
+	if err != nil {
+		helper.JSONError(ctx, metricsErrorStatus(err), "Failed to get lead time", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, metric)
+}
+
+func (c *MetricsController) CycleTime(ctx *gin.Context) {
+	var req dto.MetricsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	metric, err := c.metricsService.GetCycleTime(ctx.Request.Context(), req)
+	if err != nil {
+		helper.JSONError(ctx, metricsErrorStatus(err), "Failed to get cycle time", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, metric)
+}
+
+func (c *MetricsController) TimeInStatus(ctx *gin.Context) {
+	var req dto.MetricsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	metric, err := c.metricsService.GetTimeInStatus(ctx.Request.Context(), req)
+	if err != nil {
+		helper.JSONError(ctx, metricsErrorStatus(err), "Failed to get time in status", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, metric)
+}
+
+func (c *MetricsController) CumulativeFlow(ctx *gin.Context) {
+	var req dto.MetricsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	metric, err := c.metricsService.GetCumulativeFlow(ctx.Request.Context(), req)
+	if err != nil {
+		helper.JSONError(ctx, metricsErrorStatus(err), "Failed to get cumulative flow", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, metric)
+}
+
+func (c *MetricsController) Throughput(ctx *gin.Context) {
+	var req dto.MetricsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	metric, err := c.metricsService.GetThroughput(ctx.Request.Context(), req)
+	if err != nil {
+		helper.JSONError(ctx, metricsErrorStatus(err), "Failed to get throughput", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, metric)
+}
+
+func metricsErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidTimezone), errors.Is(err, service.ErrInvalidMetricsRange):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
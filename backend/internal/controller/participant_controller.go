@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"backend/internal/dto"
+	"backend/internal/helper"
+	"backend/internal/service"
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ParticipantController struct {
+	participantService service.ParticipantService
+}
+
+func NewParticipantController(participantService service.ParticipantService) *ParticipantController {
+	return &ParticipantController{
+		participantService: participantService,
+	}
+}
+
+func (c *ParticipantController) AddAssignee(ctx *gin.Context) {
+	c.add(ctx, c.participantService.AddAssignee, "Assignee added successfully")
+}
+
+func (c *ParticipantController) RemoveAssignee(ctx *gin.Context) {
+	c.remove(ctx, c.participantService.RemoveAssignee, "Assignee removed successfully")
+}
+
+func (c *ParticipantController) AddWatcher(ctx *gin.Context) {
+	c.add(ctx, c.participantService.AddWatcher, "Watcher added successfully")
+}
+
+func (c *ParticipantController) RemoveWatcher(ctx *gin.Context) {
+	c.remove(ctx, c.participantService.RemoveWatcher, "Watcher removed successfully")
+}
+
+func (c *ParticipantController) add(ctx *gin.Context, add func(ctx context.Context, taskID, accountID uint) error, message string) {
+	taskID, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var req dto.AddParticipantRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	if err := add(ctx.Request.Context(), taskID, req.AccountID); err != nil {
+		helper.JSONError(ctx, participantErrorStatus(err), "Failed to add participant", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": message})
+}
+
+func (c *ParticipantController) remove(ctx *gin.Context, remove func(ctx context.Context, taskID, accountID uint) error, message string) {
+	taskID, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	accountID, err := helper.GetParamID(ctx, "accountId")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid account ID", err.Error())
+		return
+	}
+
+	if err := remove(ctx.Request.Context(), taskID, accountID); err != nil {
+		helper.JSONError(ctx, participantErrorStatus(err), "Failed to remove participant", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": message})
+}
+
+func participantErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrNotWorkspaceMember), errors.Is(err, service.ErrNotProjectMember):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrLastAssignee):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
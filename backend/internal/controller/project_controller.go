@@ -0,0 +1,228 @@
+package controller
+
+import (
+	"backend/internal/dto"
+	"backend/internal/helper"
+	"backend/internal/service"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProjectController struct {
+	projectService service.ProjectService
+}
+
+func NewProjectController(projectService service.ProjectService) *ProjectController {
+	return &ProjectController{
+		projectService: projectService,
+	}
+}
+
+func (c *ProjectController) All(ctx *gin.Context) {
+	var req dto.ProjectListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	projects, err := c.projectService.GetProjects(ctx.Request.Context(), req, userID)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusInternalServerError, "Failed to get projects", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": projects})
+}
+
+func (c *ProjectController) Insert(ctx *gin.Context) {
+	var req dto.CreateProjectRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	project, err := c.projectService.CreateProject(ctx.Request.Context(), req, userID)
+	if err != nil {
+		helper.JSONError(ctx, projectErrorStatus(err), "Failed to create project", err.Error())
+		return
+	}
+
+	helper.CreatedResponse(ctx, "Project created successfully", project)
+}
+
+func (c *ProjectController) FindByID(ctx *gin.Context) {
+	id, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	project, err := c.projectService.GetProjectByID(ctx.Request.Context(), id, userID)
+	if err != nil {
+		helper.JSONError(ctx, projectErrorStatus(err), "Project not found", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, project)
+}
+
+func (c *ProjectController) Update(ctx *gin.Context) {
+	id, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var req dto.UpdateProjectRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	project, err := c.projectService.UpdateProject(ctx.Request.Context(), id, req, userID)
+	if err != nil {
+		helper.JSONError(ctx, projectErrorStatus(err), "Failed to update project", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Project updated successfully", "data": project})
+}
+
+func (c *ProjectController) Delete(ctx *gin.Context) {
+	id, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	if err := c.projectService.DeleteProject(ctx.Request.Context(), id, userID); err != nil {
+		helper.JSONError(ctx, projectErrorStatus(err), "Failed to delete project", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Project deleted successfully"})
+}
+
+func (c *ProjectController) Members(ctx *gin.Context) {
+	id, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	members, err := c.projectService.GetMembers(ctx.Request.Context(), id, userID)
+	if err != nil {
+		helper.JSONError(ctx, projectErrorStatus(err), "Failed to get members", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": members})
+}
+
+func (c *ProjectController) AddMember(ctx *gin.Context) {
+	id, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var req dto.AddProjectMemberRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	member, err := c.projectService.AddMember(ctx.Request.Context(), id, req, userID)
+	if err != nil {
+		helper.JSONError(ctx, projectErrorStatus(err), "Failed to add member", err.Error())
+		return
+	}
+
+	helper.CreatedResponse(ctx, "Member added successfully", member)
+}
+
+func (c *ProjectController) RemoveMember(ctx *gin.Context) {
+	id, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	accountID, err := helper.GetParamID(ctx, "accountId")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid account ID", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	if err := c.projectService.RemoveMember(ctx.Request.Context(), id, accountID, userID); err != nil {
+		helper.JSONError(ctx, projectErrorStatus(err), "Failed to remove member", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Member removed successfully"})
+}
+
+func projectErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrProjectNotFound), errors.Is(err, service.ErrNotProjectMember):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrProjectForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrProjectKeyExists), errors.Is(err, service.ErrProjectHasTasks):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrProjectOwnerMember), errors.Is(err, service.ErrNotWorkspaceMember):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"backend/internal/dto"
+	"backend/internal/helper"
+	"backend/internal/service"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type RecurrenceController struct {
+	recurrenceService service.RecurrenceService
+}
+
+func NewRecurrenceController(recurrenceService service.RecurrenceService) *RecurrenceController {
+	return &RecurrenceController{
+		recurrenceService: recurrenceService,
+	}
+}
+
+func (c *RecurrenceController) FindByTaskID(ctx *gin.Context) {
+	taskID, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	recurrence, err := c.recurrenceService.GetRecurrence(ctx.Request.Context(), taskID)
+	if err != nil {
+		helper.JSONError(ctx, recurrenceErrorStatus(err), "Recurrence not found", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, recurrence)
+}
+
+func (c *RecurrenceController) Update(ctx *gin.Context) {
+	taskID, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var req dto.SetRecurrenceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	recurrence, err := c.recurrenceService.SetRecurrence(ctx.Request.Context(), taskID, req, userID)
+	if err != nil {
+		helper.JSONError(ctx, recurrenceErrorStatus(err), "Failed to set recurrence", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Recurrence saved successfully", "data": recurrence})
+}
+
+func (c *RecurrenceController) Delete(ctx *gin.Context) {
+	taskID, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	if err := c.recurrenceService.DeleteRecurrence(ctx.Request.Context(), taskID); err != nil {
+		helper.JSONError(ctx, recurrenceErrorStatus(err), "Failed to stop recurrence", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Recurrence stopped successfully"})
+}
+
+func (c *RecurrenceController) Skip(ctx *gin.Context) {
+	taskID, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	// body boleh kosong: skip occurrence task ini
+	var req dto.SkipOccurrenceRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+			return
+		}
+	}
+
+	recurrence, err := c.recurrenceService.SkipOccurrence(ctx.Request.Context(), taskID, req)
+	if err != nil {
+		helper.JSONError(ctx, recurrenceErrorStatus(err), "Failed to skip occurrence", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Occurrence skipped successfully", "data": recurrence})
+}
+
+func recurrenceErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, service.ErrRecurrenceNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidRRule), errors.Is(err, service.ErrInvalidTimezone),
+		errors.Is(err, service.ErrRecurrenceNeedsDeadline), errors.Is(err, service.ErrInvalidOccurrence):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -3,11 +3,17 @@ package controller
 import (
 	"backend/internal/dto"
 	"backend/internal/helper"
+	"backend/internal/query"
 	"backend/internal/service"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type TaskController struct {
@@ -34,13 +40,37 @@ func (c *TaskController) All(ctx *gin.Context) {
 	if req.Page == "" {
 		req.Page = "1"
 	}
-	if req.Order == "" {
-		req.Order = "id desc"
+	if len(req.Order) == 0 && req.Sort == "" {
+		if req.Search != nil && *req.Search != "" {
+			req.Sort = dto.TaskSortRelevance
+		} else {
+			req.Sort = dto.TaskSortNewest
+		}
+	}
+
+	if req.UsesCursor() {
+		tasks, page, err := c.taskService.GetTaskPage(ctx.Request.Context(), req)
+		if err != nil {
+			jsonTaskError(ctx, "Failed to get tasks", err)
+			return
+		}
+
+		response := gin.H{
+			"data":        tasks,
+			"next_cursor": page.NextCursor,
+			"prev_cursor": page.PrevCursor,
+			"limit":       req.Limit,
+		}
+		if page.Total != nil {
+			response["total"] = *page.Total
+		}
+		ctx.JSON(http.StatusOK, response)
+		return
 	}
 
 	tasks, count, err := c.taskService.GetAllTasks(ctx.Request.Context(), req)
 	if err != nil {
-		helper.JSONError(ctx, http.StatusInternalServerError, "Failed to get tasks", err.Error())
+		jsonTaskError(ctx, "Failed to get tasks", err)
 		return
 	}
 
@@ -73,7 +103,7 @@ func (c *TaskController) Insert(ctx *gin.Context) {
 
 	task, err := c.taskService.CreateTask(ctx.Request.Context(), req, uint(userID))
 	if err != nil {
-		helper.JSONError(ctx, http.StatusInternalServerError, "Failed to create task", err.Error())
+		helper.JSONError(ctx, taskErrorStatus(err), "Failed to create task", err.Error())
 		return
 	}
 
@@ -94,9 +124,28 @@ func (c *TaskController) FindByID(ctx *gin.Context) {
 		return
 	}
 
+	ctx.Header("ETag", helper.ETag(task.Version))
 	ctx.JSON(http.StatusOK, task)
 }
 
+func (c *TaskController) Subtasks(ctx *gin.Context) {
+	id, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	tasks, err := c.taskService.GetSubtasks(ctx.Request.Context(), id)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusNotFound, "Task not found", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": tasks,
+	})
+}
+
 func (c *TaskController) FindByFilter(ctx *gin.Context) {
 	var req dto.TaskFilterRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -106,7 +155,7 @@ func (c *TaskController) FindByFilter(ctx *gin.Context) {
 
 	tasks, err := c.taskService.GetTasksByFilter(ctx.Request.Context(), req)
 	if err != nil {
-		helper.JSONError(ctx, http.StatusInternalServerError, "Failed to get tasks", err.Error())
+		jsonTaskError(ctx, "Failed to get tasks", err)
 		return
 	}
 
@@ -141,12 +190,15 @@ func (c *TaskController) Update(ctx *gin.Context) {
 		return
 	}
 
+	req.IfMatch = helper.ParseIfMatch(ctx.GetHeader("If-Match"))
+
 	task, err := c.taskService.UpdateTask(ctx.Request.Context(), uint(id), req, uint(userID))
 	if err != nil {
-		helper.JSONError(ctx, http.StatusInternalServerError, "Failed to update task", err.Error())
+		helper.JSONError(ctx, taskErrorStatus(err), "Failed to update task", err.Error())
 		return
 	}
 
+	ctx.Header("ETag", helper.ETag(task.Version))
 	ctx.JSON(http.StatusOK, gin.H{"message": "Task updated successfully", "data": task})
 }
 
@@ -160,9 +212,178 @@ func (c *TaskController) Delete(ctx *gin.Context) {
 
 	err = c.taskService.DeleteTask(ctx.Request.Context(), uint(id))
 	if err != nil {
-		helper.JSONError(ctx, http.StatusInternalServerError, "Failed to delete task", err.Error())
+		helper.JSONError(ctx, taskErrorStatus(err), "Failed to delete task", err.Error())
 		return
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "Task deleted successfully"})
 }
+
+func (c *TaskController) Move(ctx *gin.Context) {
+	id, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var req dto.MoveTaskRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	task, err := c.taskService.MoveTask(ctx.Request.Context(), id, req, userID)
+	if err != nil {
+		helper.JSONError(ctx, taskErrorStatus(err), "Failed to move task", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Task moved successfully", "data": task})
+}
+
+func (c *TaskController) Board(ctx *gin.Context) {
+	var req dto.BoardRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	board, err := c.taskService.GetBoard(ctx.Request.Context(), req)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusInternalServerError, "Failed to get board", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, board)
+}
+
+// Bulk menjalankan operasi ke banyak task sekaligus. Jika ada task yang
+// gagal, tidak ada perubahan yang tersimpan dan response berstatus 422
+// dengan hasil per task.
+func (c *TaskController) Bulk(ctx *gin.Context) {
+	var req dto.BulkTaskRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	result, err := c.taskService.BulkTasks(ctx.Request.Context(), req, userID)
+	if err != nil {
+		jsonTaskError(ctx, "Failed to run bulk operation", err)
+		return
+	}
+
+	if result.Failed > 0 {
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{"message": "Bulk operation rolled back", "data": result})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Bulk operation completed", "data": result})
+}
+
+// exportContentTypes adalah Content-Type untuk setiap format export
+var exportContentTypes = map[string]string{
+	dto.ExportFormatCSV:    "text/csv; charset=utf-8",
+	dto.ExportFormatNDJSON: "application/x-ndjson",
+	dto.ExportFormatJSON:   "application/json; charset=utf-8",
+}
+
+// Export men-stream semua task yang cocok dengan filter list (dari query
+// string) sebagai file download
+func (c *TaskController) Export(ctx *gin.Context) {
+	var req dto.TaskExportRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+	if req.Format == "" {
+		req.Format = dto.ExportFormatCSV
+	}
+	if len(req.Order) == 0 && req.Sort == "" {
+		req.Sort = dto.TaskSortOldest
+	}
+
+	filename := fmt.Sprintf("tasks-%s.%s", time.Now().Format("20060102-150405"), req.Format)
+	ctx.Header("Content-Type", exportContentTypes[req.Format])
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	ctx.Status(http.StatusOK)
+
+	if err := c.taskService.ExportTasks(ctx.Request.Context(), req, ctx.Writer); err != nil {
+		// setelah baris pertama terkirim status tidak bisa diubah lagi,
+		// response hanya terputus
+		if ctx.Writer.Written() {
+			log.Printf("Task export interrupted: %v", err)
+			ctx.Abort()
+			return
+		}
+		ctx.Writer.Header().Del("Content-Type")
+		ctx.Writer.Header().Del("Content-Disposition")
+		jsonTaskError(ctx, "Failed to export tasks", err)
+	}
+}
+
+// Stats mengembalikan ringkasan task untuk dashboard, memakai filter list
+// dari query string ditambah bucket, from, to dan timezone untuk time series
+func (c *TaskController) Stats(ctx *gin.Context) {
+	var req dto.TaskStatsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	stats, err := c.taskService.GetStats(ctx.Request.Context(), req)
+	if err != nil {
+		jsonTaskError(ctx, "Failed to get task stats", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, stats)
+}
+
+// jsonTaskError menulis error dari TaskService. Error query task dikirim
+// beserta posisinya di data supaya frontend bisa menandai bagian yang salah.
+func jsonTaskError(ctx *gin.Context, message string, err error) {
+	var queryErr *query.Error
+	if errors.As(err, &queryErr) {
+		ctx.JSON(http.StatusBadRequest, helper.BuildErrorResponse(message, queryErr.Error(), queryErr))
+		return
+	}
+	helper.JSONError(ctx, taskErrorStatus(err), message, err.Error())
+}
+
+// taskErrorStatus memetakan error dari TaskService ke HTTP status code
+func taskErrorStatus(err error) int {
+	var queryErr *query.Error
+	switch {
+	case errors.As(err, &queryErr), errors.Is(err, service.ErrInvalidOrder),
+		errors.Is(err, service.ErrInvalidCursor):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrTaskHasOpenChildren), errors.Is(err, service.ErrTaskBlocked),
+		errors.Is(err, service.ErrProjectArchived):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrInvalidParent), errors.Is(err, service.ErrLabelNotFound),
+		errors.Is(err, service.ErrNotProjectMember), errors.Is(err, service.ErrNotWorkspaceMember),
+		errors.Is(err, service.ErrInvalidRRule), errors.Is(err, service.ErrInvalidTimezone),
+		errors.Is(err, service.ErrRecurrenceNeedsDeadline), errors.Is(err, service.ErrRecurrenceNotFound),
+		errors.Is(err, service.ErrInvalidNeighbor), errors.Is(err, service.ErrBulkTooLarge),
+		errors.Is(err, service.ErrBulkMissingParam), errors.Is(err, service.ErrInvalidStatsRange):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrVersionMismatch):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, service.ErrProjectNotFound), errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
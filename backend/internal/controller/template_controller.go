@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"backend/internal/dto"
+	"backend/internal/helper"
+	"backend/internal/service"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type TemplateController struct {
+	templateService service.TemplateService
+}
+
+func NewTemplateController(templateService service.TemplateService) *TemplateController {
+	return &TemplateController{
+		templateService: templateService,
+	}
+}
+
+func (c *TemplateController) All(ctx *gin.Context) {
+	templates, err := c.templateService.GetAllTemplates(ctx.Request.Context(), helper.GetQueryString(ctx, "search"))
+	if err != nil {
+		helper.JSONError(ctx, http.StatusInternalServerError, "Failed to get templates", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": templates})
+}
+
+func (c *TemplateController) Insert(ctx *gin.Context) {
+	var req dto.CreateTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	template, err := c.templateService.CreateTemplate(ctx.Request.Context(), req, userID)
+	if err != nil {
+		helper.JSONError(ctx, templateErrorStatus(err), "Failed to create template", err.Error())
+		return
+	}
+
+	helper.CreatedResponse(ctx, "Template created successfully", template)
+}
+
+func (c *TemplateController) FindByID(ctx *gin.Context) {
+	id, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	template, err := c.templateService.GetTemplateByID(ctx.Request.Context(), id)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusNotFound, "Template not found", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, template)
+}
+
+func (c *TemplateController) Update(ctx *gin.Context) {
+	id, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var req dto.UpdateTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	template, err := c.templateService.UpdateTemplate(ctx.Request.Context(), id, req)
+	if err != nil {
+		helper.JSONError(ctx, templateErrorStatus(err), "Failed to update template", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Template updated successfully", "data": template})
+}
+
+func (c *TemplateController) Delete(ctx *gin.Context) {
+	id, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	if err := c.templateService.DeleteTemplate(ctx.Request.Context(), id); err != nil {
+		helper.JSONError(ctx, templateErrorStatus(err), "Failed to delete template", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Template deleted successfully"})
+}
+
+// CreateTask membuat task (dan subtask-nya) dari template
+func (c *TemplateController) CreateTask(ctx *gin.Context) {
+	id, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var req dto.CreateFromTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	task, err := c.templateService.CreateTaskFromTemplate(ctx.Request.Context(), id, req, userID)
+	if err != nil {
+		helper.JSONError(ctx, templateErrorStatus(err), "Failed to create task from template", err.Error())
+		return
+	}
+
+	helper.CreatedResponse(ctx, "Task created successfully", task)
+}
+
+// templateErrorStatus memetakan error template; error pembuatan task
+// mengikuti pemetaan TaskController
+func templateErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrTemplateExists):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrTemplateVariableMissing), errors.Is(err, service.ErrLabelNotFound):
+		return http.StatusBadRequest
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	default:
+		return taskErrorStatus(err)
+	}
+}
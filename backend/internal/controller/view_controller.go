@@ -0,0 +1,180 @@
+package controller
+
+import (
+	"backend/internal/dto"
+	"backend/internal/helper"
+	"backend/internal/service"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ViewController struct {
+	viewService service.ViewService
+}
+
+func NewViewController(viewService service.ViewService) *ViewController {
+	return &ViewController{
+		viewService: viewService,
+	}
+}
+
+func (c *ViewController) All(ctx *gin.Context) {
+	var req dto.ViewListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	views, err := c.viewService.GetViews(ctx.Request.Context(), userID, req.ProjectID)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusInternalServerError, "Failed to get views", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": views})
+}
+
+func (c *ViewController) Insert(ctx *gin.Context) {
+	var req dto.CreateViewRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	view, err := c.viewService.CreateView(ctx.Request.Context(), req, userID)
+	if err != nil {
+		jsonViewError(ctx, "Failed to create view", err)
+		return
+	}
+
+	helper.CreatedResponse(ctx, "View created successfully", view)
+}
+
+func (c *ViewController) FindByID(ctx *gin.Context) {
+	id, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	view, err := c.viewService.GetViewByID(ctx.Request.Context(), id, userID)
+	if err != nil {
+		jsonViewError(ctx, "View not found", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, view)
+}
+
+func (c *ViewController) Update(ctx *gin.Context) {
+	id, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var req dto.UpdateViewRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	view, err := c.viewService.UpdateView(ctx.Request.Context(), id, req, userID)
+	if err != nil {
+		jsonViewError(ctx, "Failed to update view", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "View updated successfully", "data": view})
+}
+
+func (c *ViewController) Delete(ctx *gin.Context) {
+	id, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	if err := c.viewService.DeleteView(ctx.Request.Context(), id, userID); err != nil {
+		jsonViewError(ctx, "Failed to delete view", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "View deleted successfully"})
+}
+
+// Tasks menjalankan filter view dengan pagination ?page= dan ?limit=,
+// responsnya sama dengan /task/list
+func (c *ViewController) Tasks(ctx *gin.Context) {
+	id, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	page := helper.GetQueryStringDefault(ctx, "page", "1")
+	limit := helper.GetQueryStringDefault(ctx, "limit", "10")
+	tasks, count, err := c.viewService.GetViewTasks(ctx.Request.Context(), id, userID, page, limit)
+	if err != nil {
+		jsonViewError(ctx, "Failed to get view tasks", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data":  tasks,
+		"total": count,
+		"page":  page,
+		"limit": limit,
+	})
+}
+
+// jsonViewError memetakan error view; filter yang tidak valid (termasuk
+// posisi error query task) dan error list task mengikuti jsonTaskError
+func jsonViewError(ctx *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, service.ErrViewNotOwner):
+		helper.JSONError(ctx, http.StatusForbidden, message, err.Error())
+	case errors.Is(err, service.ErrViewNeedsProject), errors.Is(err, service.ErrInvalidViewFilter):
+		helper.JSONError(ctx, http.StatusBadRequest, message, err.Error())
+	default:
+		jsonTaskError(ctx, message, err)
+	}
+}
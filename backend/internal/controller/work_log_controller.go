@@ -0,0 +1,200 @@
+package controller
+
+import (
+	"backend/internal/dto"
+	"backend/internal/helper"
+	"backend/internal/service"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type WorkLogController struct {
+	workLogService service.WorkLogService
+}
+
+func NewWorkLogController(workLogService service.WorkLogService) *WorkLogController {
+	return &WorkLogController{
+		workLogService: workLogService,
+	}
+}
+
+func (c *WorkLogController) StartTimer(ctx *gin.Context) {
+	taskID, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	log, err := c.workLogService.StartTimer(ctx.Request.Context(), taskID, userID)
+	if err != nil {
+		helper.JSONError(ctx, workLogErrorStatus(err), "Failed to start timer", err.Error())
+		return
+	}
+
+	helper.CreatedResponse(ctx, "Timer started", log)
+}
+
+func (c *WorkLogController) StopTimer(ctx *gin.Context) {
+	taskID, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	// body boleh kosong
+	var req dto.StopTimerRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+			return
+		}
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	log, err := c.workLogService.StopTimer(ctx.Request.Context(), taskID, req, userID)
+	if err != nil {
+		helper.JSONError(ctx, workLogErrorStatus(err), "Failed to stop timer", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Timer stopped", "data": log})
+}
+
+// RunningTimer mengembalikan timer user yang sedang berjalan, data null jika tidak ada
+func (c *WorkLogController) RunningTimer(ctx *gin.Context) {
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	log, err := c.workLogService.GetRunningTimer(ctx.Request.Context(), userID)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusInternalServerError, "Failed to get timer", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": log})
+}
+
+func (c *WorkLogController) All(ctx *gin.Context) {
+	taskID, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	logs, err := c.workLogService.GetWorkLogs(ctx.Request.Context(), taskID)
+	if err != nil {
+		helper.JSONError(ctx, workLogErrorStatus(err), "Failed to get work logs", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": logs})
+}
+
+func (c *WorkLogController) Insert(ctx *gin.Context) {
+	taskID, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var req dto.CreateWorkLogRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	log, err := c.workLogService.AddWorkLog(ctx.Request.Context(), taskID, req, userID)
+	if err != nil {
+		helper.JSONError(ctx, workLogErrorStatus(err), "Failed to add work log", err.Error())
+		return
+	}
+
+	helper.CreatedResponse(ctx, "Work log added successfully", log)
+}
+
+func (c *WorkLogController) Delete(ctx *gin.Context) {
+	taskID, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	logID, err := helper.GetParamID(ctx, "logId")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid work log ID", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	if err := c.workLogService.DeleteWorkLog(ctx.Request.Context(), taskID, logID, userID); err != nil {
+		helper.JSONError(ctx, workLogErrorStatus(err), "Failed to delete work log", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Work log deleted successfully"})
+}
+
+func (c *WorkLogController) Timesheet(ctx *gin.Context) {
+	var req dto.TimesheetRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	timesheet, err := c.workLogService.GetTimesheet(ctx.Request.Context(), req, userID)
+	if err != nil {
+		helper.JSONError(ctx, workLogErrorStatus(err), "Failed to get timesheet", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, timesheet)
+}
+
+func workLogErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrTimerRunning):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrWorkLogForbidden), errors.Is(err, service.ErrWorkspaceForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrTimerNotRunning), errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrTimesheetTooLarge):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
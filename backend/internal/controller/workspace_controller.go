@@ -0,0 +1,199 @@
+package controller
+
+import (
+	"backend/internal/dto"
+	"backend/internal/helper"
+	"backend/internal/service"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WorkspaceController struct {
+	workspaceService service.WorkspaceService
+}
+
+func NewWorkspaceController(workspaceService service.WorkspaceService) *WorkspaceController {
+	return &WorkspaceController{
+		workspaceService: workspaceService,
+	}
+}
+
+func (c *WorkspaceController) All(ctx *gin.Context) {
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	workspaces, err := c.workspaceService.GetWorkspaces(ctx.Request.Context(), userID)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusInternalServerError, "Failed to get workspaces", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": workspaces})
+}
+
+func (c *WorkspaceController) Insert(ctx *gin.Context) {
+	var req dto.CreateWorkspaceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	workspace, err := c.workspaceService.CreateWorkspace(ctx.Request.Context(), req, userID)
+	if err != nil {
+		helper.JSONError(ctx, workspaceErrorStatus(err), "Failed to create workspace", err.Error())
+		return
+	}
+
+	helper.CreatedResponse(ctx, "Workspace created successfully", workspace)
+}
+
+func (c *WorkspaceController) FindByID(ctx *gin.Context) {
+	id, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	workspace, err := c.workspaceService.GetWorkspaceByID(ctx.Request.Context(), id, userID)
+	if err != nil {
+		helper.JSONError(ctx, workspaceErrorStatus(err), "Workspace not found", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, workspace)
+}
+
+func (c *WorkspaceController) Update(ctx *gin.Context) {
+	id, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var req dto.UpdateWorkspaceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	workspace, err := c.workspaceService.UpdateWorkspace(ctx.Request.Context(), id, req, userID)
+	if err != nil {
+		helper.JSONError(ctx, workspaceErrorStatus(err), "Failed to update workspace", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Workspace updated successfully", "data": workspace})
+}
+
+func (c *WorkspaceController) Members(ctx *gin.Context) {
+	id, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	members, err := c.workspaceService.GetMembers(ctx.Request.Context(), id, userID)
+	if err != nil {
+		helper.JSONError(ctx, workspaceErrorStatus(err), "Failed to get members", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": members})
+}
+
+func (c *WorkspaceController) AddMember(ctx *gin.Context) {
+	id, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var req dto.AddWorkspaceMemberRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	member, err := c.workspaceService.AddMember(ctx.Request.Context(), id, req, userID)
+	if err != nil {
+		helper.JSONError(ctx, workspaceErrorStatus(err), "Failed to add member", err.Error())
+		return
+	}
+
+	helper.CreatedResponse(ctx, "Member added successfully", member)
+}
+
+func (c *WorkspaceController) RemoveMember(ctx *gin.Context) {
+	id, err := helper.GetParamID(ctx, "id")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	accountID, err := helper.GetParamID(ctx, "accountId")
+	if err != nil {
+		helper.JSONError(ctx, http.StatusBadRequest, "Invalid account ID", err.Error())
+		return
+	}
+
+	userID, err := helper.GetUserID(ctx)
+	if err != nil {
+		helper.JSONError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	if err := c.workspaceService.RemoveMember(ctx.Request.Context(), id, accountID, userID); err != nil {
+		helper.JSONError(ctx, workspaceErrorStatus(err), "Failed to remove member", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Member removed successfully"})
+}
+
+func workspaceErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrWorkspaceNotFound), errors.Is(err, service.ErrNotWorkspaceMember):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrWorkspaceForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrWorkspaceSlugExists):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
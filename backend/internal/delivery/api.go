@@ -1,12 +1,18 @@
 package api
 
 import (
+	"context"
+	"log"
 	"os"
+	"strings"
+	"time"
 
 	"backend/config"
 	"backend/internal/delivery/api"
+	"backend/internal/notification"
 	"backend/internal/repository"
 	"backend/internal/service"
+	"backend/internal/worker"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -15,10 +21,20 @@ import (
 var server = config.Server{}
 
 var (
-	db                *gorm.DB                     = server.SetupDatabaseConnection()
-	jwtService        service.JWTService           = service.NewJWTService()
-	accountRepository repository.AccountRepository = repository.NewAccountRepository(db)
-	authService       service.AuthService          = service.NewAuthService(accountRepository, jwtService)
+	db                  *gorm.DB                       = server.SetupDatabaseConnection()
+	jwtService          service.JWTService             = service.NewJWTService()
+	accountRepository   repository.AccountRepository   = repository.NewAccountRepository(db)
+	workspaceRepository repository.WorkspaceRepository = repository.NewWorkspaceRepository(db)
+	authService         service.AuthService            = service.NewAuthService(accountRepository, workspaceRepository, jwtService)
+	workspaceService    service.WorkspaceService       = service.NewWorkspaceService(workspaceRepository, accountRepository)
+	recurrenceService   service.RecurrenceService      = service.NewRecurrenceService(repository.NewTaskRepository(db), repository.NewRecurrenceRepository(db))
+)
+
+// jeda antar putaran worker background
+const (
+	recurrenceInterval = time.Minute
+	reminderInterval   = time.Minute
+	importInterval     = 5 * time.Second
 )
 
 func CORSMiddleware() gin.HandlerFunc {
@@ -26,11 +42,12 @@ func CORSMiddleware() gin.HandlerFunc {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Max-Age", "86400")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS, PUT, DELETE, UPDATE")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Access-Control-Allow-Origin, Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
-		c.Writer.Header().Set("Access-Control-Expose-Headers", "Content-Length")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Access-Control-Allow-Origin, Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, If-Match")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "Content-Length, ETag")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
 
-		if c.Request.Method == "OPTIONS" {
+		// OPTIONS CalDAV dijawab controller dengan header DAV
+		if c.Request.Method == "OPTIONS" && !strings.HasPrefix(c.Request.URL.Path, "/caldav/") {
 			c.AbortWithStatus(200)
 		} else {
 			c.Next()
@@ -41,12 +58,35 @@ func CORSMiddleware() gin.HandlerFunc {
 func InitializeRoutes() {
 	defer config.CloseDatabaseConnection(db)
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	worker.NewRecurrenceWorker(recurrenceService, recurrenceInterval).Start(ctx)
+
+	leadTimes, err := service.ParseLeadTimes(os.Getenv("REMINDER_LEAD_TIMES"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	reminderService := service.NewReminderService(repository.NewReminderRepository(db), notification.NewChannel(), leadTimes)
+	worker.NewReminderWorker(reminderService, reminderInterval).Start(ctx)
+	worker.NewImportWorker(api.NewImportService(db), importInterval).Start(ctx)
+
 	r := gin.Default()
 	r.MaxMultipartMemory = 8 << 20
 	r.Use(CORSMiddleware())
 
-	api.AuthRoutes(r.Group("/api"), db, jwtService)
-	api.TaskRoutes(r.Group("/api"), db, jwtService)
+	api.AuthRoutes(r.Group("/api"), db, jwtService, workspaceService)
+	api.TaskRoutes(r.Group("/api"), db, jwtService, workspaceService)
+	api.LabelRoutes(r.Group("/api"), db, jwtService, workspaceService)
+	api.ProjectRoutes(r.Group("/api"), db, jwtService, workspaceService)
+	api.WorkspaceRoutes(r.Group("/api"), db, jwtService, workspaceService)
+	api.TemplateRoutes(r.Group("/api"), db, jwtService, workspaceService)
+	api.WorkLogRoutes(r.Group("/api"), db, jwtService, workspaceService)
+	api.ImportRoutes(r.Group("/api"), db, jwtService, workspaceService)
+	api.CalendarRoutes(r.Group("/api"), db, jwtService, workspaceService)
+	api.AppPasswordRoutes(r.Group("/api"), db, jwtService, workspaceService)
+	api.ViewRoutes(r.Group("/api"), db, jwtService, workspaceService)
+	api.MetricsRoutes(r.Group("/api"), db, jwtService, workspaceService)
+	api.CalDAVRoutes(r, db, workspaceService)
 
 	port := os.Getenv("APP_PORT")
 	if port == "" {
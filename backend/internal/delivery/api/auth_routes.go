@@ -10,11 +10,12 @@ import (
 	"gorm.io/gorm"
 )
 
-func AuthRoutes(r *gin.RouterGroup, db *gorm.DB, jwtService service.JWTService) {
+func AuthRoutes(r *gin.RouterGroup, db *gorm.DB, jwtService service.JWTService, workspaceService service.WorkspaceService) {
 	var (
-		accountRepo    repository.AccountRepository = repository.NewAccountRepository(db)
-		authService    service.AuthService          = service.NewAuthService(accountRepo, jwtService)
-		authController *controller.AuthController   = controller.NewAuthController(authService)
+		accountRepo    repository.AccountRepository   = repository.NewAccountRepository(db)
+		workspaceRepo  repository.WorkspaceRepository = repository.NewWorkspaceRepository(db)
+		authService    service.AuthService            = service.NewAuthService(accountRepo, workspaceRepo, jwtService)
+		authController *controller.AuthController     = controller.NewAuthController(authService)
 	)
 
 	// Public routes
@@ -26,9 +27,10 @@ func AuthRoutes(r *gin.RouterGroup, db *gorm.DB, jwtService service.JWTService)
 
 	// Protected routes
 	protected := auth.Group("")
-	protected.Use(middleware.AuthorizeJWT(jwtService))
+	protected.Use(middleware.AuthorizeJWT(jwtService, workspaceService))
 	{
 		protected.GET("/profile", authController.GetProfile)
 		protected.POST("/change-password", authController.ChangePassword)
+		protected.POST("/switch-workspace", authController.SwitchWorkspace)
 	}
 }
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"backend/internal/controller"
+	"backend/internal/middleware"
+	"backend/internal/repository"
+	"backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// AppPasswordRoutes mengelola app password untuk client non-browser seperti CalDAV
+func AppPasswordRoutes(r *gin.RouterGroup, db *gorm.DB, jwtService service.JWTService, workspaceService service.WorkspaceService) {
+	var (
+		appPasswordService service.AppPasswordService        = service.NewAppPasswordService(repository.NewAppPasswordRepository(db))
+		controller         *controller.AppPasswordController = controller.NewAppPasswordController(appPasswordService)
+	)
+
+	appPasswordGroup := r.Group("/app-passwords", middleware.AuthorizeJWT(jwtService, workspaceService))
+
+	{
+		appPasswordGroup.GET("", controller.All)
+		appPasswordGroup.POST("", controller.Insert)
+		appPasswordGroup.DELETE("/:id", controller.Delete)
+	}
+}
+
+// CalDAVRoutes didaftarkan langsung di engine (bukan di /api) karena
+// client CalDAV memakai method WebDAV dan discovery lewat /.well-known
+func CalDAVRoutes(r *gin.Engine, db *gorm.DB, workspaceService service.WorkspaceService) {
+	var (
+		taskRepo           repository.TaskRepository      = repository.NewTaskRepository(db)
+		projectRepo        repository.ProjectRepository   = repository.NewProjectRepository(db)
+		labelRepo          repository.LabelRepository     = repository.NewLabelRepository(db)
+		workspaceRepo      repository.WorkspaceRepository = repository.NewWorkspaceRepository(db)
+		recurrenceService  service.RecurrenceService      = service.NewRecurrenceService(taskRepo, repository.NewRecurrenceRepository(db))
+		taskService        service.TaskService            = service.NewTaskService(taskRepo, repository.NewDependencyRepository(db), labelRepo, projectRepo, workspaceRepo, recurrenceService)
+		appPasswordService service.AppPasswordService     = service.NewAppPasswordService(repository.NewAppPasswordRepository(db))
+		caldavService      service.CalDAVService          = service.NewCalDAVService(repository.NewCalDAVRepository(db), taskRepo, projectRepo, taskService)
+		controller         *controller.CalDAVController   = controller.NewCalDAVController(caldavService)
+	)
+
+	r.GET("/.well-known/caldav", controller.WellKnown)
+	r.Handle("PROPFIND", "/.well-known/caldav", controller.WellKnown)
+
+	path := "/caldav/*path"
+	r.OPTIONS(path, controller.Options)
+
+	caldavGroup := r.Group("", middleware.AuthorizeAppPassword(appPasswordService, workspaceService, "Tasks CalDAV"))
+	{
+		caldavGroup.Handle("PROPFIND", path, controller.Propfind)
+		caldavGroup.Handle("REPORT", path, controller.Report)
+		caldavGroup.GET(path, controller.Get)
+		caldavGroup.HEAD(path, controller.Get)
+		caldavGroup.PUT(path, controller.Put)
+		caldavGroup.DELETE(path, controller.Delete)
+	}
+}
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"backend/internal/controller"
+	"backend/internal/middleware"
+	"backend/internal/repository"
+	"backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func CalendarRoutes(r *gin.RouterGroup, db *gorm.DB, jwtService service.JWTService, workspaceService service.WorkspaceService) {
+	var (
+		calendarService service.CalendarService        = service.NewCalendarService(repository.NewCalendarRepository(db), repository.NewTaskRepository(db), repository.NewWorkspaceRepository(db))
+		controller      *controller.CalendarController = controller.NewCalendarController(calendarService)
+	)
+
+	calendarGroup := r.Group("/calendar")
+
+	// URL langganan dibuka oleh aplikasi kalender, jadi tanpa JWT
+	calendarGroup.GET("/feed/:token", controller.Feed)
+
+	tokenGroup := calendarGroup.Group("/token", middleware.AuthorizeJWT(jwtService, workspaceService))
+	{
+		tokenGroup.GET("", controller.GetToken)
+		tokenGroup.POST("", controller.CreateToken)
+		tokenGroup.DELETE("", controller.RevokeToken)
+	}
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"backend/internal/controller"
+	"backend/internal/middleware"
+	"backend/internal/repository"
+	"backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func ImportRoutes(r *gin.RouterGroup, db *gorm.DB, jwtService service.JWTService, workspaceService service.WorkspaceService) {
+	var (
+		importService service.ImportService        = NewImportService(db)
+		controller    *controller.ImportController = controller.NewImportController(importService)
+	)
+
+	importGroup := r.Group("/import", middleware.AuthorizeJWT(jwtService, workspaceService))
+
+	{
+		importGroup.GET("/", controller.All)
+		importGroup.POST("/", controller.Insert)
+		importGroup.POST("/preview", controller.Preview)
+		importGroup.GET("/:id", controller.FindByID)
+	}
+}
+
+// NewImportService merangkai ImportService beserta TaskService yang dipakai
+// untuk membuat task, juga dipakai worker import
+func NewImportService(db *gorm.DB) service.ImportService {
+	var (
+		taskRepo          repository.TaskRepository      = repository.NewTaskRepository(db)
+		labelRepo         repository.LabelRepository     = repository.NewLabelRepository(db)
+		projectRepo       repository.ProjectRepository   = repository.NewProjectRepository(db)
+		workspaceRepo     repository.WorkspaceRepository = repository.NewWorkspaceRepository(db)
+		recurrenceService service.RecurrenceService      = service.NewRecurrenceService(taskRepo, repository.NewRecurrenceRepository(db))
+		taskService       service.TaskService            = service.NewTaskService(taskRepo, repository.NewDependencyRepository(db), labelRepo, projectRepo, workspaceRepo, recurrenceService)
+	)
+	return service.NewImportService(repository.NewImportJobRepository(db), labelRepo, projectRepo, workspaceRepo, taskService)
+}
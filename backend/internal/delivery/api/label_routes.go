@@ -0,0 +1,29 @@
+package api
+
+import (
+	"backend/internal/controller"
+	"backend/internal/middleware"
+	"backend/internal/repository"
+	"backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func LabelRoutes(r *gin.RouterGroup, db *gorm.DB, jwtService service.JWTService, workspaceService service.WorkspaceService) {
+	var (
+		repo         repository.LabelRepository  = repository.NewLabelRepository(db)
+		labelService service.LabelService        = service.NewLabelService(repo)
+		controller   *controller.LabelController = controller.NewLabelController(labelService)
+	)
+
+	labelGroup := r.Group("/label", middleware.AuthorizeJWT(jwtService, workspaceService))
+
+	{
+		labelGroup.GET("/", controller.All)
+		labelGroup.POST("/", controller.Insert)
+		labelGroup.GET("/:id", controller.FindByID)
+		labelGroup.PUT("/:id", controller.Update)
+		labelGroup.DELETE("/:id", controller.Delete)
+	}
+}
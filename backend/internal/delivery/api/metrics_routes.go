@@ -0,0 +1,29 @@
+package api
+
+import (
+	"backend/internal/controller"
+	"backend/internal/middleware"
+	"backend/internal/repository"
+	"backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func MetricsRoutes(r *gin.RouterGroup, db *gorm.DB, jwtService service.JWTService, workspaceService service.WorkspaceService) {
+	var (
+		repo           repository.MetricsRepository  = repository.NewMetricsRepository(db)
+		metricsService service.MetricsService        = service.NewMetricsService(repo)
+		controller     *controller.MetricsController = controller.NewMetricsController(metricsService)
+	)
+
+	metricsGroup := r.Group("/metrics", middleware.AuthorizeJWT(jwtService, workspaceService))
+
+	{
+		metricsGroup.GET("/lead-time", controller.LeadTime)
+		metricsGroup.GET("/cycle-time", controller.CycleTime)
+		metricsGroup.GET("/time-in-status", controller.TimeInStatus)
+		metricsGroup.GET("/cumulative-flow", controller.CumulativeFlow)
+		metricsGroup.GET("/throughput", controller.Throughput)
+	}
+}
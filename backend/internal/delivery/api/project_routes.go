@@ -0,0 +1,35 @@
+package api
+
+import (
+	"backend/internal/controller"
+	"backend/internal/middleware"
+	"backend/internal/repository"
+	"backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func ProjectRoutes(r *gin.RouterGroup, db *gorm.DB, jwtService service.JWTService, workspaceService service.WorkspaceService) {
+	var (
+		repo           repository.ProjectRepository   = repository.NewProjectRepository(db)
+		accountRepo    repository.AccountRepository   = repository.NewAccountRepository(db)
+		workspaceRepo  repository.WorkspaceRepository = repository.NewWorkspaceRepository(db)
+		projectService service.ProjectService         = service.NewProjectService(repo, accountRepo, workspaceRepo)
+		controller     *controller.ProjectController  = controller.NewProjectController(projectService)
+	)
+
+	projectGroup := r.Group("/project", middleware.AuthorizeJWT(jwtService, workspaceService))
+
+	{
+		projectGroup.GET("/", controller.All)
+		projectGroup.POST("/", controller.Insert)
+		projectGroup.GET("/:id", controller.FindByID)
+		projectGroup.PUT("/:id", controller.Update)
+		projectGroup.DELETE("/:id", controller.Delete)
+
+		projectGroup.GET("/:id/members", controller.Members)
+		projectGroup.POST("/:id/members", controller.AddMember)
+		projectGroup.DELETE("/:id/members/:accountId", controller.RemoveMember)
+	}
+}
@@ -10,21 +10,67 @@ import (
 	"gorm.io/gorm"
 )
 
-func TaskRoutes(r *gin.RouterGroup, db *gorm.DB, jwtService service.JWTService) {
+func TaskRoutes(r *gin.RouterGroup, db *gorm.DB, jwtService service.JWTService, workspaceService service.WorkspaceService) {
 	var (
-		repo        repository.TaskRepository  = repository.NewTaskRepository(db)
-		taskService service.TaskService        = service.NewTaskService(repo)
-		controller  *controller.TaskController = controller.NewTaskController(taskService)
+		repo                  repository.TaskRepository         = repository.NewTaskRepository(db)
+		checklistRepo         repository.ChecklistRepository    = repository.NewChecklistRepository(db)
+		dependencyRepo        repository.DependencyRepository   = repository.NewDependencyRepository(db)
+		labelRepo             repository.LabelRepository        = repository.NewLabelRepository(db)
+		projectRepo           repository.ProjectRepository      = repository.NewProjectRepository(db)
+		workspaceRepo         repository.WorkspaceRepository    = repository.NewWorkspaceRepository(db)
+		recurrenceRepo        repository.RecurrenceRepository   = repository.NewRecurrenceRepository(db)
+		recurrenceService     service.RecurrenceService         = service.NewRecurrenceService(repo, recurrenceRepo)
+		taskService           service.TaskService               = service.NewTaskService(repo, dependencyRepo, labelRepo, projectRepo, workspaceRepo, recurrenceService)
+		checklistService      service.ChecklistService          = service.NewChecklistService(repo, checklistRepo)
+		dependencyService     service.DependencyService         = service.NewDependencyService(repo, dependencyRepo)
+		participantService    service.ParticipantService        = service.NewParticipantService(repo, projectRepo, workspaceRepo)
+		workLogService        service.WorkLogService            = service.NewWorkLogService(repository.NewWorkLogRepository(db), repo, workspaceRepo)
+		checklistController   *controller.ChecklistController   = controller.NewChecklistController(checklistService)
+		dependencyController  *controller.DependencyController  = controller.NewDependencyController(dependencyService)
+		recurrenceController  *controller.RecurrenceController  = controller.NewRecurrenceController(recurrenceService)
+		participantController *controller.ParticipantController = controller.NewParticipantController(participantService)
+		workLogController     *controller.WorkLogController     = controller.NewWorkLogController(workLogService)
+		controller            *controller.TaskController        = controller.NewTaskController(taskService)
 	)
 
-	taskGroup := r.Group("/task", middleware.AuthorizeJWT(jwtService))
+	taskGroup := r.Group("/task", middleware.AuthorizeJWT(jwtService, workspaceService))
 
 	{
 		taskGroup.POST("/list", controller.All)
+		taskGroup.GET("/export", controller.Export)
+		taskGroup.GET("/stats", controller.Stats)
+		taskGroup.GET("/board", controller.Board)
 		taskGroup.POST("/", controller.Insert)
 		taskGroup.GET("/:id", controller.FindByID)
 		taskGroup.PUT("/:id", controller.Update)
 		taskGroup.DELETE("/:id", controller.Delete)
 		taskGroup.POST("/byfilter", controller.FindByFilter)
+		taskGroup.POST("/bulk", controller.Bulk)
+		taskGroup.GET("/:id/subtasks", controller.Subtasks)
+		taskGroup.POST("/:id/move", controller.Move)
+
+		taskGroup.GET("/:id/checklist", checklistController.All)
+		taskGroup.POST("/:id/checklist", checklistController.Insert)
+		taskGroup.PUT("/:id/checklist/:itemId", checklistController.Update)
+		taskGroup.DELETE("/:id/checklist/:itemId", checklistController.Delete)
+
+		taskGroup.POST("/:id/dependencies", dependencyController.Insert)
+		taskGroup.DELETE("/:id/dependencies/:blockedById", dependencyController.Delete)
+
+		taskGroup.POST("/:id/assignees", participantController.AddAssignee)
+		taskGroup.DELETE("/:id/assignees/:accountId", participantController.RemoveAssignee)
+		taskGroup.POST("/:id/watchers", participantController.AddWatcher)
+		taskGroup.DELETE("/:id/watchers/:accountId", participantController.RemoveWatcher)
+
+		taskGroup.GET("/:id/recurrence", recurrenceController.FindByTaskID)
+		taskGroup.PUT("/:id/recurrence", recurrenceController.Update)
+		taskGroup.DELETE("/:id/recurrence", recurrenceController.Delete)
+		taskGroup.POST("/:id/recurrence/skip", recurrenceController.Skip)
+
+		taskGroup.POST("/:id/timer/start", workLogController.StartTimer)
+		taskGroup.POST("/:id/timer/stop", workLogController.StopTimer)
+		taskGroup.GET("/:id/worklogs", workLogController.All)
+		taskGroup.POST("/:id/worklogs", workLogController.Insert)
+		taskGroup.DELETE("/:id/worklogs/:logId", workLogController.Delete)
 	}
 }
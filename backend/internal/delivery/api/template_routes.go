@@ -0,0 +1,38 @@
+package api
+
+import (
+	"backend/internal/controller"
+	"backend/internal/middleware"
+	"backend/internal/repository"
+	"backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func TemplateRoutes(r *gin.RouterGroup, db *gorm.DB, jwtService service.JWTService, workspaceService service.WorkspaceService) {
+	var (
+		repo              repository.TemplateRepository   = repository.NewTemplateRepository(db)
+		taskRepo          repository.TaskRepository       = repository.NewTaskRepository(db)
+		dependencyRepo    repository.DependencyRepository = repository.NewDependencyRepository(db)
+		labelRepo         repository.LabelRepository      = repository.NewLabelRepository(db)
+		projectRepo       repository.ProjectRepository    = repository.NewProjectRepository(db)
+		workspaceRepo     repository.WorkspaceRepository  = repository.NewWorkspaceRepository(db)
+		recurrenceService service.RecurrenceService       = service.NewRecurrenceService(taskRepo, repository.NewRecurrenceRepository(db))
+		taskService       service.TaskService             = service.NewTaskService(taskRepo, dependencyRepo, labelRepo, projectRepo, workspaceRepo, recurrenceService)
+		templateService   service.TemplateService         = service.NewTemplateService(repo, labelRepo, taskRepo, taskService)
+		controller        *controller.TemplateController  = controller.NewTemplateController(templateService)
+	)
+
+	templateGroup := r.Group("/template", middleware.AuthorizeJWT(jwtService, workspaceService))
+
+	{
+		templateGroup.GET("/", controller.All)
+		templateGroup.POST("/", controller.Insert)
+		templateGroup.GET("/:id", controller.FindByID)
+		templateGroup.PUT("/:id", controller.Update)
+		templateGroup.DELETE("/:id", controller.Delete)
+	}
+
+	r.Group("/task", middleware.AuthorizeJWT(jwtService, workspaceService)).POST("/from-template/:id", controller.CreateTask)
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"backend/internal/controller"
+	"backend/internal/middleware"
+	"backend/internal/repository"
+	"backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func ViewRoutes(r *gin.RouterGroup, db *gorm.DB, jwtService service.JWTService, workspaceService service.WorkspaceService) {
+	var (
+		repo              repository.ViewRepository       = repository.NewViewRepository(db)
+		taskRepo          repository.TaskRepository       = repository.NewTaskRepository(db)
+		dependencyRepo    repository.DependencyRepository = repository.NewDependencyRepository(db)
+		labelRepo         repository.LabelRepository      = repository.NewLabelRepository(db)
+		projectRepo       repository.ProjectRepository    = repository.NewProjectRepository(db)
+		workspaceRepo     repository.WorkspaceRepository  = repository.NewWorkspaceRepository(db)
+		recurrenceService service.RecurrenceService       = service.NewRecurrenceService(taskRepo, repository.NewRecurrenceRepository(db))
+		taskService       service.TaskService             = service.NewTaskService(taskRepo, dependencyRepo, labelRepo, projectRepo, workspaceRepo, recurrenceService)
+		viewService       service.ViewService             = service.NewViewService(repo, projectRepo, taskService)
+		controller        *controller.ViewController      = controller.NewViewController(viewService)
+	)
+
+	viewGroup := r.Group("/views", middleware.AuthorizeJWT(jwtService, workspaceService))
+
+	{
+		viewGroup.GET("/", controller.All)
+		viewGroup.POST("/", controller.Insert)
+		viewGroup.GET("/:id", controller.FindByID)
+		viewGroup.PUT("/:id", controller.Update)
+		viewGroup.DELETE("/:id", controller.Delete)
+		viewGroup.GET("/:id/tasks", controller.Tasks)
+	}
+}
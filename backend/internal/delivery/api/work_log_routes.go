@@ -0,0 +1,25 @@
+package api
+
+import (
+	"backend/internal/controller"
+	"backend/internal/middleware"
+	"backend/internal/repository"
+	"backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// WorkLogRoutes mendaftarkan endpoint time tracking di luar task tertentu;
+// timer dan work log per task ada di TaskRoutes
+func WorkLogRoutes(r *gin.RouterGroup, db *gorm.DB, jwtService service.JWTService, workspaceService service.WorkspaceService) {
+	var (
+		repo           repository.WorkLogRepository  = repository.NewWorkLogRepository(db)
+		taskRepo       repository.TaskRepository     = repository.NewTaskRepository(db)
+		workLogService service.WorkLogService        = service.NewWorkLogService(repo, taskRepo, repository.NewWorkspaceRepository(db))
+		controller     *controller.WorkLogController = controller.NewWorkLogController(workLogService)
+	)
+
+	r.GET("/timer", middleware.AuthorizeJWT(jwtService, workspaceService), controller.RunningTimer)
+	r.GET("/timesheet", middleware.AuthorizeJWT(jwtService, workspaceService), controller.Timesheet)
+}
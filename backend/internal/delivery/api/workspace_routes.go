@@ -0,0 +1,29 @@
+package api
+
+import (
+	"backend/internal/controller"
+	"backend/internal/middleware"
+	"backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func WorkspaceRoutes(r *gin.RouterGroup, db *gorm.DB, jwtService service.JWTService, workspaceService service.WorkspaceService) {
+	var (
+		controller *controller.WorkspaceController = controller.NewWorkspaceController(workspaceService)
+	)
+
+	workspaceGroup := r.Group("/workspace", middleware.AuthorizeJWT(jwtService, workspaceService))
+
+	{
+		workspaceGroup.GET("/", controller.All)
+		workspaceGroup.POST("/", controller.Insert)
+		workspaceGroup.GET("/:id", controller.FindByID)
+		workspaceGroup.PUT("/:id", controller.Update)
+
+		workspaceGroup.GET("/:id/members", controller.Members)
+		workspaceGroup.POST("/:id/members", controller.AddMember)
+		workspaceGroup.DELETE("/:id/members/:accountId", controller.RemoveMember)
+	}
+}
@@ -0,0 +1,15 @@
+package dto
+
+import (
+	"backend/internal/models"
+)
+
+type CreateAppPasswordRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+// AppPasswordResponse berisi Password hanya saat baru dibuat
+type AppPasswordResponse struct {
+	models.AppPassword
+	Password string `json:"password,omitempty"`
+}
@@ -1,8 +1,11 @@
 package dto
 
+import "backend/internal/models"
+
 type LoginRequest struct {
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required"`
+	Email       string `json:"email" binding:"required,email"`
+	Password    string `json:"password" binding:"required"`
+	WorkspaceID *uint  `json:"workspace_id"`
 }
 
 type RegisterRequest struct {
@@ -12,13 +15,18 @@ type RegisterRequest struct {
 }
 
 type AuthResponse struct {
-	Account     *AccountResponse `json:"account"`
-	AccessToken string           `json:"access_token"`
-	TokenType   string           `json:"token_type"`
-	ExpiresAt   string           `json:"expires_at"`
+	Account     *AccountResponse  `json:"account"`
+	Workspace   *models.Workspace `json:"workspace"`
+	AccessToken string            `json:"access_token"`
+	TokenType   string            `json:"token_type"`
+	ExpiresAt   string            `json:"expires_at"`
 }
 
 type ChangePasswordRequest struct {
 	OldPassword string `json:"old_password" binding:"required"`
 	NewPassword string `json:"new_password" binding:"required,min=6"`
 }
+
+type SwitchWorkspaceRequest struct {
+	WorkspaceID uint `json:"workspace_id" binding:"required"`
+}
@@ -0,0 +1,107 @@
+package dto
+
+import (
+	"backend/internal/models"
+	"encoding/xml"
+)
+
+// CalDAVCollection adalah satu kalender CalDAV berisi VTODO: "tasks" untuk
+// task tanpa project dan "project-<id>" untuk setiap project, jadi setiap
+// task ada di tepat satu collection
+type CalDAVCollection struct {
+	Name        string
+	DisplayName string
+	ProjectID   *uint
+	// CTag berubah setiap kali isi collection berubah
+	CTag string
+}
+
+// CalDAVObject adalah satu resource VTODO di collection
+type CalDAVObject struct {
+	Name string
+	UID  string
+	Task models.Task
+}
+
+// CalDAVPrecondition berisi header If-Match / If-None-Match: * dari request
+type CalDAVPrecondition struct {
+	IfMatch     []uint
+	IfNoneMatch bool
+}
+
+// DAVPropfind adalah body PROPFIND (RFC 4918 14.20); body kosong berarti allprop
+type DAVPropfind struct {
+	XMLName  xml.Name      `xml:"DAV: propfind"`
+	AllProp  *struct{}     `xml:"DAV: allprop"`
+	PropName *struct{}     `xml:"DAV: propname"`
+	Prop     *DAVPropNames `xml:"DAV: prop"`
+}
+
+type DAVPropNames struct {
+	Names []DAVElement `xml:",any"`
+}
+
+type DAVElement struct {
+	XMLName xml.Name
+}
+
+// CalDAVReport adalah body REPORT calendar-query atau calendar-multiget
+// (RFC 4791 7.8 dan 7.9), dibedakan dari XMLName
+type CalDAVReport struct {
+	XMLName xml.Name
+	Prop    *DAVPropNames `xml:"DAV: prop"`
+	Hrefs   []string      `xml:"DAV: href"`
+	Filter  *struct {
+		CompFilter CalDAVCompFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+	} `xml:"urn:ietf:params:xml:ns:caldav filter"`
+}
+
+type CalDAVCompFilter struct {
+	Name     string             `xml:"name,attr"`
+	Children []CalDAVCompFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+}
+
+// Namespace XML yang dipakai CalDAV
+const (
+	DAVNamespace            = "DAV:"
+	CalDAVNamespace         = "urn:ietf:params:xml:ns:caldav"
+	CalendarServerNamespace = "http://calendarserver.org/ns/"
+)
+
+// DAVMultistatus adalah body response 207 (RFC 4918 13). Elemen ditulis
+// dengan prefix tetap supaya dibaca dengan benar oleh client yang
+// mencocokkan prefix, bukan namespace.
+type DAVMultistatus struct {
+	XMLName   xml.Name      `xml:"D:multistatus"`
+	DAV       string        `xml:"xmlns:D,attr"`
+	CalDAV    string        `xml:"xmlns:C,attr"`
+	CS        string        `xml:"xmlns:CS,attr"`
+	Responses []DAVResponse `xml:"D:response"`
+}
+
+func NewDAVMultistatus(responses []DAVResponse) DAVMultistatus {
+	return DAVMultistatus{
+		DAV:       DAVNamespace,
+		CalDAV:    CalDAVNamespace,
+		CS:        CalendarServerNamespace,
+		Responses: responses,
+	}
+}
+
+// DAVResponse berisi Status saja untuk href yang tidak ditemukan, atau
+// Propstats untuk resource yang ada
+type DAVResponse struct {
+	Href      string        `xml:"D:href"`
+	Status    string        `xml:"D:status,omitempty"`
+	Propstats []DAVPropstat `xml:"D:propstat"`
+}
+
+type DAVPropstat struct {
+	Prop   DAVProp `xml:"D:prop"`
+	Status string  `xml:"D:status"`
+}
+
+// DAVProp berisi elemen property yang sudah di-render
+type DAVProp struct {
+	InnerXML string `xml:",innerxml"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"time"
+)
+
+// Komponen iCalendar untuk task di feed
+const (
+	CalendarComponentTodo  = "todo"
+	CalendarComponentEvent = "event"
+	CalendarComponentBoth  = "both"
+)
+
+// CalendarFeedRequest adalah filter feed dari query string URL langganan,
+// jadi satu token bisa dipakai untuk beberapa kalender berbeda
+type CalendarFeedRequest struct {
+	AssignedToMe bool     `form:"assigned_to_me"`
+	ProjectID    *uint    `form:"project_id"`
+	Status       []string `form:"status" binding:"omitempty,dive,oneof=todo in_progress done"`
+	// Component memilih VTODO, VEVENT atau keduanya; default event karena
+	// banyak aplikasi kalender tidak menampilkan VTODO
+	Component string `form:"component" binding:"omitempty,oneof=todo event both"`
+}
+
+// CalendarTokenResponse berisi Token dan URL hanya saat token baru dibuat
+type CalendarTokenResponse struct {
+	Token      string     `json:"token,omitempty"`
+	URL        string     `json:"url,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
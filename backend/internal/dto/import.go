@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"backend/internal/models"
+	"mime/multipart"
+)
+
+// ImportRequest adalah form multipart untuk preview dan import. Columns,
+// StatusMap dan AccountMap berupa objek JSON:
+//   - Columns: field task -> nama kolom CSV (hanya format csv)
+//   - StatusMap: status di sistem sumber -> todo/in_progress/done
+//   - AccountMap: assignee di sistem sumber (username Trello, nama Jira) -> email
+type ImportRequest struct {
+	Format     string                `form:"format" binding:"required,oneof=csv trello jira"`
+	File       *multipart.FileHeader `form:"file" binding:"required"`
+	Columns    string                `form:"columns"`
+	StatusMap  string                `form:"status_map"`
+	AccountMap string                `form:"account_map"`
+	ProjectID  *uint                 `form:"project_id"`
+	// SkipInvalid menjalankan import untuk baris yang valid saja; tanpa ini
+	// import ditolak jika ada baris yang tidak valid
+	SkipInvalid bool `form:"skip_invalid"`
+}
+
+// ImportOptions adalah ImportRequest setelah objek JSON-nya diparse
+type ImportOptions struct {
+	Format      string
+	Columns     map[string]string
+	StatusMap   map[string]string
+	AccountMap  map[string]string
+	ProjectID   *uint
+	SkipInvalid bool
+}
+
+type ImportRowResult struct {
+	Line   int                   `json:"line"`
+	Task   *models.ImportJobTask `json:"task,omitempty"`
+	Errors []string              `json:"errors,omitempty"`
+}
+
+type ImportPreviewResponse struct {
+	Format  string `json:"format"`
+	Total   int    `json:"total"`
+	Valid   int    `json:"valid"`
+	Invalid int    `json:"invalid"`
+	// NewLabels adalah label yang belum ada dan akan dibuat saat import
+	NewLabels []string          `json:"new_labels"`
+	Rows      []ImportRowResult `json:"rows"`
+}
+
+type ImportJobResponse struct {
+	models.ImportJob
+	// Progress adalah persentase baris yang sudah diproses (0-100)
+	Progress int `json:"progress"`
+}
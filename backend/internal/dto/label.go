@@ -0,0 +1,16 @@
+package dto
+
+const (
+	LabelMatchAny = "any"
+	LabelMatchAll = "all"
+)
+
+type CreateLabelRequest struct {
+	Name  string `json:"name" binding:"required,max=50"`
+	Color string `json:"color" binding:"omitempty,hexcolor"`
+}
+
+type UpdateLabelRequest struct {
+	Name  *string `json:"name" binding:"omitempty,max=50"`
+	Color *string `json:"color" binding:"omitempty,hexcolor"`
+}
@@ -0,0 +1,76 @@
+package dto
+
+import "time"
+
+// MetricsRequest adalah filter endpoint metrics, dari query string. From
+// dan To (tanggal, inklusif) membatasi task yang dihitung: waktu selesai
+// untuk lead/cycle time dan throughput, waktu masuk status untuk time in
+// status, dan hari yang ditampilkan untuk cumulative flow. Default-nya 30
+// hari terakhir (12 minggu untuk throughput) di Timezone, default UTC.
+type MetricsRequest struct {
+	ProjectID  *uint      `form:"project_id"`
+	AssigneeID *uint      `form:"assignee_id"`
+	LabelIDs   []uint     `form:"label_ids"`
+	LabelMatch string     `form:"label_match" binding:"omitempty,oneof=any all"`
+	From       *time.Time `form:"from" time_format:"2006-01-02"`
+	To         *time.Time `form:"to" time_format:"2006-01-02"`
+	Timezone   string     `form:"timezone"`
+}
+
+// MetricsRange adalah rentang tanggal yang benar-benar dipakai
+type MetricsRange struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Timezone string `json:"timezone"`
+}
+
+// DurationStats meringkas sekumpulan durasi dalam jam. Persentil dihitung
+// dengan percentile_cont; semua 0 jika Count 0.
+type DurationStats struct {
+	Count    int64   `json:"count"`
+	AvgHours float64 `json:"avg_hours"`
+	P50Hours float64 `json:"p50_hours"`
+	P85Hours float64 `json:"p85_hours"`
+	P95Hours float64 `json:"p95_hours"`
+}
+
+// DurationMetricResponse dipakai lead time (dibuat sampai done) dan cycle
+// time (pertama kali in_progress sampai done)
+type DurationMetricResponse struct {
+	MetricsRange
+	DurationStats
+}
+
+type StatusDuration struct {
+	Status string `json:"status"`
+	DurationStats
+}
+
+type TimeInStatusResponse struct {
+	MetricsRange
+	Statuses []StatusDuration `json:"statuses"`
+}
+
+// FlowDay adalah jumlah task di setiap status pada akhir hari Date
+type FlowDay struct {
+	Date   string           `json:"date"`
+	Counts map[string]int64 `json:"counts"`
+}
+
+type CumulativeFlowResponse struct {
+	MetricsRange
+	Statuses []string  `json:"statuses"`
+	Days     []FlowDay `json:"days"`
+}
+
+// ThroughputWeek adalah jumlah task yang selesai di minggu yang dimulai
+// hari Senin Week
+type ThroughputWeek struct {
+	Week      string `json:"week"`
+	Completed int64  `json:"completed"`
+}
+
+type ThroughputResponse struct {
+	MetricsRange
+	Weeks []ThroughputWeek `json:"weeks"`
+}
@@ -0,0 +1,22 @@
+package dto
+
+type CreateProjectRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Key         string `json:"key" binding:"required,min=2,max=10,alphanum"`
+	Description string `json:"description"`
+}
+
+type UpdateProjectRequest struct {
+	Name        *string `json:"name" binding:"omitempty,max=100"`
+	Description *string `json:"description"`
+	IsArchived  *bool   `json:"is_archived"`
+}
+
+type ProjectListRequest struct {
+	IncludeArchived bool `form:"include_archived"`
+}
+
+type AddProjectMemberRequest struct {
+	AccountID uint   `json:"account_id" binding:"required"`
+	Role      string `json:"role" binding:"omitempty,oneof=owner member"`
+}
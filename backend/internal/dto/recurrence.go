@@ -0,0 +1,31 @@
+package dto
+
+import "time"
+
+const (
+	RecurrenceScopeThis   = "this"
+	RecurrenceScopeFuture = "future"
+)
+
+type SetRecurrenceRequest struct {
+	RRule string `json:"rrule" binding:"required"`
+	// Timezone adalah nama IANA (misalnya "Asia/Jakarta"), default UTC
+	Timezone string `json:"timezone"`
+}
+
+// SkipOccurrenceRequest tanpa OccurrenceAt men-skip occurrence task itu sendiri,
+// dengan OccurrenceAt men-skip occurrence yang belum dibuat
+type SkipOccurrenceRequest struct {
+	OccurrenceAt *time.Time `json:"occurrence_at"`
+}
+
+type RecurrenceResponse struct {
+	ID               uint        `json:"id"`
+	RRule            string      `json:"rrule"`
+	Timezone         string      `json:"timezone"`
+	StartAt          time.Time   `json:"start_at"`
+	LastOccurrenceAt time.Time   `json:"last_occurrence_at"`
+	NextOccurrenceAt *time.Time  `json:"next_occurrence_at"`
+	Skipped          []time.Time `json:"skipped"`
+	Upcoming         []time.Time `json:"upcoming"`
+}
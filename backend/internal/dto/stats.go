@@ -0,0 +1,76 @@
+package dto
+
+import (
+	"backend/internal/models"
+	"time"
+)
+
+const (
+	StatsBucketDay  = "day"
+	StatsBucketWeek = "week"
+)
+
+// TaskStatsRequest memakai filter yang sama dengan list, dari query string.
+// From dan To (tanggal, inklusif) membatasi time series; default 30 hari
+// terakhir untuk bucket day dan 12 minggu terakhir untuk bucket week.
+// Timezone menentukan batas hari dan minggu (Senin), default UTC.
+type TaskStatsRequest struct {
+	TaskListRequest
+	Bucket   string     `form:"bucket" binding:"omitempty,oneof=day week"`
+	From     *time.Time `form:"from" time_format:"2006-01-02"`
+	To       *time.Time `form:"to" time_format:"2006-01-02"`
+	Timezone string     `form:"timezone"`
+}
+
+// TaskStatsCounts adalah hitungan ringkas task yang cocok dengan filter.
+// Overdue dan DueThisWeek hanya menghitung task yang belum done.
+type TaskStatsCounts struct {
+	Total       int64 `json:"total"`
+	Done        int64 `json:"done"`
+	Overdue     int64 `json:"overdue"`
+	DueThisWeek int64 `json:"due_this_week"`
+}
+
+type TaskStatusCount struct {
+	Status string `json:"status"`
+	Count  int64  `json:"count"`
+}
+
+type TaskPriorityCount struct {
+	Priority models.Priority `json:"priority"`
+	Count    int64           `json:"count"`
+}
+
+// TaskAssigneeCount menghitung task per assignee; task dengan beberapa
+// assignee dihitung sekali untuk setiap assignee
+type TaskAssigneeCount struct {
+	AccountID uint   `json:"account_id"`
+	Name      string `json:"name"`
+	Count     int64  `json:"count"`
+}
+
+// TaskSeriesPoint adalah jumlah task yang dibuat dan yang selesai dalam
+// satu bucket, Date adalah awal bucket
+type TaskSeriesPoint struct {
+	Date      string `json:"date"`
+	Created   int64  `json:"created"`
+	Completed int64  `json:"completed"`
+}
+
+type TaskSeries struct {
+	Bucket   string            `json:"bucket"`
+	From     string            `json:"from"`
+	To       string            `json:"to"`
+	Timezone string            `json:"timezone"`
+	Points   []TaskSeriesPoint `json:"points"`
+}
+
+type TaskStatsResponse struct {
+	TaskStatsCounts
+	// CompletionRate adalah Done / Total (0..1), 0 jika tidak ada task
+	CompletionRate float64             `json:"completion_rate"`
+	ByStatus       []TaskStatusCount   `json:"by_status"`
+	ByPriority     []TaskPriorityCount `json:"by_priority"`
+	ByAssignee     []TaskAssigneeCount `json:"by_assignee"`
+	Series         TaskSeries          `json:"series"`
+}
@@ -2,49 +2,385 @@ package dto
 
 import (
 	"backend/internal/models"
+	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 )
 
 type CreateTaskRequest struct {
-	Title       string    `json:"title" binding:"required"`
-	Description string    `json:"description"`
-	Status      string    `json:"status"`
-	Deadline    time.Time `json:"deadline"`
-	AccountID   uint      `json:"account_id" binding:"required"`
+	Title       string           `json:"title" binding:"required"`
+	Description string           `json:"description"`
+	Status      string           `json:"status"`
+	Priority    *models.Priority `json:"priority"`
+	Deadline    time.Time        `json:"deadline"`
+	// EstimateMinutes adalah estimasi awal waktu pengerjaan
+	EstimateMinutes *int `json:"estimate_minutes" binding:"omitempty,min=0"`
+	// AccountID adalah assignee utama; boleh kosong jika AssigneeIDs diisi,
+	// assignee utama lalu diambil dari AssigneeIDs pertama
+	AccountID   uint   `json:"account_id" binding:"required_without=AssigneeIDs"`
+	AssigneeIDs []uint `json:"assignee_ids"`
+	WatcherIDs  []uint `json:"watcher_ids"`
+	ProjectID   *uint  `json:"project_id"`
+	ParentID    *uint  `json:"parent_id"`
+	LabelIDs    []uint `json:"label_ids"`
+	// Recurrence menjadikan task ini occurrence pertama dari seri berulang
+	Recurrence *SetRecurrenceRequest `json:"recurrence"`
 }
 
 type UpdateTaskRequest struct {
-	Title       *string    `json:"title"`
-	Description *string    `json:"description"`
-	Status      *string    `json:"status"`
-	Deadline    *time.Time `json:"deadline"`
+	Title       *string          `json:"title"`
+	Description *string          `json:"description"`
+	Status      *string          `json:"status"`
+	Priority    *models.Priority `json:"priority"`
+	Deadline    *time.Time       `json:"deadline"`
+	ProjectID   *uint            `json:"project_id"`
+	// EstimateMinutes = 0 menghapus estimasi
+	EstimateMinutes *int   `json:"estimate_minutes" binding:"omitempty,min=0"`
+	ParentID        *uint  `json:"parent_id"`
+	AddLabelIDs     []uint `json:"add_label_ids"`
+	RemoveLabelIDs  []uint `json:"remove_label_ids"`
+	// Scope untuk task berulang: "this" (default) hanya occurrence ini,
+	// "future" juga mengubah template occurrence berikutnya
+	Scope string `json:"scope" binding:"omitempty,oneof=this future"`
+	// IfMatch diisi dari header If-Match (bukan dari body): daftar version
+	// yang diterima, nil berarti update tanpa precondition
+	IfMatch []uint `json:"-"`
 }
 
 type TaskListRequest struct {
-	Search    *string `json:"search"`
-	Status    *string `json:"status"`
-	StartDate *string `json:"start_date"`
-	EndDate   *string `json:"end_date"`
-	Limit     string  `json:"limit" default:"10"`
-	Page      string  `json:"page" default:"1"`
-	Order     string  `json:"order" default:"id desc"`
+	// Query adalah query bahasa task, misalnya `status:todo assignee:me due<7d`,
+	// digabung (AND) dengan filter lain
+	Query string `json:"query" form:"query"`
+	// Search memakai sintaks websearch: "frasa persis", -kecuali, or
+	Search     *string           `json:"search" form:"search"`
+	Status     *string           `json:"status" form:"status"`
+	Priorities []models.Priority `json:"priorities" form:"priorities"`
+	ProjectID  *uint             `json:"project_id" form:"project_id"`
+	ParentID   *uint             `json:"parent_id" form:"parent_id"`
+	AssigneeID *uint             `json:"assignee_id" form:"assignee_id"`
+	WatcherID  *uint             `json:"watcher_id" form:"watcher_id"`
+	RootOnly   bool              `json:"root_only" form:"root_only"`
+	Ready      *bool             `json:"ready" form:"ready"`
+	Overdue    *bool             `json:"overdue" form:"overdue"`
+	LabelIDs   []uint            `json:"label_ids" form:"label_ids"`
+	LabelMatch string            `json:"label_match" form:"label_match" binding:"omitempty,oneof=any all"`
+	StartDate  *string           `json:"start_date" form:"start_date"`
+	EndDate    *string           `json:"end_date" form:"end_date"`
+	Limit      string            `json:"limit" form:"limit" default:"10"`
+	Page       string            `json:"page" form:"page" default:"1"`
+	// Pagination "cursor" memakai keyset: halaman lain diminta dengan Cursor
+	// dari next_cursor/prev_cursor dan Page diabaikan. Cursor yang diisi
+	// otomatis berarti mode cursor.
+	Pagination string `json:"pagination" form:"pagination" binding:"omitempty,oneof=offset cursor"`
+	Cursor     string `json:"cursor" form:"cursor"`
+	// IncludeTotal menghitung total di mode cursor; mode offset selalu
+	// menghitung total
+	IncludeTotal bool `json:"include_total" form:"include_total"`
+	// Order adalah urutan bebas dari field yang diizinkan, misalnya
+	// [{"field":"assignee"},{"field":"deadline","nulls":"last"}]
+	Order TaskOrders `json:"order" form:"order" binding:"omitempty,dive"`
+	// Sort adalah urutan bawaan (lihat TaskSort*), diprioritaskan di atas Order
+	Sort string `json:"sort" form:"sort" binding:"omitempty,oneof=newest oldest priority deadline priority_deadline rank relevance"`
 }
 
-type TaskResponse struct {
+const (
+	PaginationOffset = "offset"
+	PaginationCursor = "cursor"
+)
+
+func (r *TaskListRequest) UsesCursor() bool {
+	return r.Pagination == PaginationCursor || r.Cursor != ""
+}
+
+// TaskPageInfo adalah navigasi list mode cursor. Cursor nil berarti tidak
+// ada halaman ke arah tersebut; Total hanya diisi jika IncludeTotal.
+type TaskPageInfo struct {
+	NextCursor *string `json:"next_cursor"`
+	PrevCursor *string `json:"prev_cursor"`
+	Total      *int64  `json:"total,omitempty"`
+}
+
+// Format export task
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+	ExportFormatJSON   = "json"
+)
+
+// TaskExportRequest memakai filter dan urutan yang sama dengan list, dari
+// query string. Limit dan Page diabaikan karena export tidak dipaginasi.
+type TaskExportRequest struct {
+	TaskListRequest
+	Format string `form:"format" binding:"omitempty,oneof=csv ndjson json"`
+}
+
+// TaskExportRow adalah satu baris export; relasi diratakan menjadi teks
+// dipisah koma supaya mudah dibuka di spreadsheet
+type TaskExportRow struct {
 	ID              uint            `json:"id"`
-	CreateAccountID uint            `json:"create_accounts_id"`
-	CreateUser      *models.Account `json:"create_accounts"`
-	UpdateAccountID *uint           `json:"update_accounts_id"`
-	UpdateUser      *models.Account `json:"update_accounts"`
-	AccountID       uint            `json:"accounts_id"`
-	Account         *models.Account `json:"accounts"`
 	Title           string          `json:"title"`
 	Description     string          `json:"description"`
 	Status          string          `json:"status"`
+	Priority        models.Priority `json:"priority"`
 	Deadline        time.Time       `json:"deadline"`
+	EstimateMinutes *int            `json:"estimate_minutes"`
+	OverdueAt       *time.Time      `json:"overdue_at"`
+	ProjectID       *uint           `json:"project_id"`
+	ProjectName     *string         `json:"project_name"`
+	ParentID        *uint           `json:"parent_id"`
+	Assignees       *string         `json:"assignees"`
+	Labels          *string         `json:"labels"`
+	Version         uint            `json:"version"`
+}
+
+const (
+	TaskSortNewest           = "newest"
+	TaskSortOldest           = "oldest"
+	TaskSortPriority         = "priority"
+	TaskSortDeadline         = "deadline"
+	TaskSortPriorityDeadline = "priority_deadline"
+	TaskSortRank             = "rank"
+	// TaskSortRelevance mengurutkan hasil Search dari yang paling relevan,
+	// default jika Search diisi tanpa Sort dan Order
+	TaskSortRelevance = "relevance"
+)
+
+// TaskOrder adalah satu kunci urutan list task. Direction default asc;
+// Nulls kosong mengikuti postgres (NULL paling besar). Task dengan nilai
+// sama selalu diurutkan lagi berdasarkan id supaya urutan stabil.
+type TaskOrder struct {
+	Field     string `json:"field" binding:"required,oneof=id title status priority deadline estimate_minutes created_at updated_at rank project assignee"`
+	Direction string `json:"direction" binding:"omitempty,oneof=asc desc"`
+	Nulls     string `json:"nulls" binding:"omitempty,oneof=first last"`
+}
+
+// TaskOrders bisa dikirim sebagai array TaskOrder atau sebagai teks ringkas
+// "deadline asc nulls last, title" (juga lewat query string)
+type TaskOrders []TaskOrder
+
+func (o *TaskOrders) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		return o.UnmarshalParam(text)
+	}
+
+	var orders []TaskOrder
+	if err := json.Unmarshal(data, &orders); err != nil {
+		return err
+	}
+	*o = orders
+	return nil
+}
+
+// UnmarshalParam mem-parse bentuk teks: kunci dipisah koma, masing-masing
+// "field [asc|desc] [nulls first|last]". Field divalidasi oleh binding.
+func (o *TaskOrders) UnmarshalParam(param string) error {
+	var orders TaskOrders
+	for _, part := range strings.Split(param, ",") {
+		words := strings.Fields(strings.ToLower(part))
+		if len(words) == 0 {
+			continue
+		}
+
+		order := TaskOrder{Field: words[0]}
+		rest := words[1:]
+		if len(rest) > 0 && (rest[0] == "asc" || rest[0] == "desc") {
+			order.Direction = rest[0]
+			rest = rest[1:]
+		}
+		if len(rest) == 2 && rest[0] == "nulls" && (rest[1] == "first" || rest[1] == "last") {
+			order.Nulls = rest[1]
+			rest = nil
+		}
+		if len(rest) > 0 {
+			return fmt.Errorf("invalid order %q, expected \"field [asc|desc] [nulls first|last]\"", strings.TrimSpace(part))
+		}
+		orders = append(orders, order)
+	}
+	*o = orders
+	return nil
+}
+
+type TaskResponse struct {
+	ID              uint             `json:"id"`
+	CreateAccountID uint             `json:"create_accounts_id"`
+	CreateUser      *models.Account  `json:"create_accounts"`
+	UpdateAccountID *uint            `json:"update_accounts_id"`
+	UpdateUser      *models.Account  `json:"update_accounts"`
+	AccountID       uint             `json:"accounts_id"`
+	Account         *models.Account  `json:"accounts"`
+	Assignees       []models.Account `json:"assignees"`
+	Watchers        []models.Account `json:"watchers"`
+	ProjectID       *uint            `json:"project_id"`
+	Project         *models.Project  `json:"project"`
+	ParentID        *uint            `json:"parent_id"`
+	RecurrenceID    *uint            `json:"recurrence_id"`
+	OccurrenceAt    *time.Time       `json:"occurrence_at"`
+	Title           string           `json:"title"`
+	Description     string           `json:"description"`
+	Status          string           `json:"status"`
+	Rank            string           `json:"rank"`
+	Priority        models.Priority  `json:"priority"`
+	Deadline        time.Time        `json:"deadline"`
+	OverdueAt       *time.Time       `json:"overdue_at"`
+	CompletedAt     *time.Time       `json:"completed_at"`
+	EstimateMinutes *int             `json:"estimate_minutes"`
+	// TimeSpentMinutes adalah total work log, termasuk timer yang berjalan
+	TimeSpentMinutes int64                  `json:"time_spent_minutes"`
+	Version          uint                   `json:"version"`
+	CreatedAt        time.Time              `json:"created_at"`
+	UpdatedAt        time.Time              `json:"updated_at"`
+	ChecklistItems   []models.ChecklistItem `json:"checklist_items,omitempty"`
+	Labels           []models.Label         `json:"labels"`
+	Progress         TaskProgress           `json:"progress"`
+	BlockedBy        []TaskLink             `json:"blocked_by"`
+	Blocking         []TaskLink             `json:"blocking"`
+	IsBlocked        bool                   `json:"is_blocked"`
+	// Highlight hanya diisi pada hasil pencarian
+	Highlight *TaskHighlight `json:"highlight,omitempty"`
+}
+
+// TaskHighlight berisi title dan cuplikan description yang sudah di-escape
+// HTML, dengan kata yang cocok dibungkus <mark></mark>
+type TaskHighlight struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// TaskLink adalah ringkasan task yang direferensikan oleh task lain
+type TaskLink struct {
+	ID     uint   `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+}
+
+// TaskProgress merangkum subtask dan checklist item milik sebuah task
+type TaskProgress struct {
+	SubtasksDone   int64 `json:"subtasks_done"`
+	SubtasksTotal  int64 `json:"subtasks_total"`
+	ChecklistDone  int64 `json:"checklist_done"`
+	ChecklistTotal int64 `json:"checklist_total"`
+	CompletedCount int64 `json:"completed_count"`
+	TotalCount     int64 `json:"total_count"`
+	Percent        int   `json:"percent"`
 }
+
+func (p TaskProgress) HasOpenChildren() bool {
+	return p.CompletedCount < p.TotalCount
+}
+
 type TaskFilterRequest struct {
-	Status    *string    `json:"status"`
-	StartDate *time.Time `json:"start_date"`
-	EndDate   *time.Time `json:"end_date"`
+	// Query sama dengan TaskListRequest.Query
+	Query      string     `json:"query"`
+	ProjectID  *uint      `json:"project_id"`
+	Status     *string    `json:"status"`
+	AssigneeID *uint      `json:"assignee_id"`
+	WatcherID  *uint      `json:"watcher_id"`
+	StartDate  *time.Time `json:"start_date"`
+	EndDate    *time.Time `json:"end_date"`
+	LabelIDs   []uint     `json:"label_ids"`
+	LabelMatch string     `json:"label_match" binding:"omitempty,oneof=any all"`
+}
+
+type CreateChecklistItemRequest struct {
+	Title    string `json:"title" binding:"required"`
+	Position *int   `json:"position"`
+}
+
+type UpdateChecklistItemRequest struct {
+	Title    *string `json:"title"`
+	IsDone   *bool   `json:"is_done"`
+	Position *int    `json:"position"`
+}
+
+// MoveTaskRequest memindahkan kartu ke kolom Status di antara dua kartu:
+// BeforeID adalah kartu tepat di atasnya, AfterID kartu tepat di bawahnya.
+// Tanpa keduanya kartu ditaruh di akhir kolom.
+type MoveTaskRequest struct {
+	Status   string `json:"status" binding:"required,oneof=todo in_progress done"`
+	BeforeID *uint  `json:"before_id"`
+	AfterID  *uint  `json:"after_id"`
+}
+
+type BoardRequest struct {
+	ProjectID  *uint  `form:"project_id"`
+	AssigneeID *uint  `form:"assignee_id"`
+	LabelIDs   []uint `form:"label_ids"`
+	LabelMatch string `form:"label_match" binding:"omitempty,oneof=any all"`
+}
+
+type BoardColumn struct {
+	Status string         `json:"status"`
+	Count  int            `json:"count"`
+	Tasks  []TaskResponse `json:"tasks"`
+}
+
+type BoardResponse struct {
+	Columns []BoardColumn `json:"columns"`
+}
+
+const (
+	BulkOpSetStatus   = "set_status"
+	BulkOpReassign    = "reassign"
+	BulkOpSetDeadline = "set_deadline"
+	BulkOpAddLabel    = "add_label"
+	BulkOpDelete      = "delete"
+)
+
+// BulkTaskRequest menjalankan satu operasi ke banyak task sekaligus. Task
+// dipilih lewat IDs atau Filter; parameter yang dipakai tergantung Operation.
+// DryRun menjalankan operasi lalu me-rollback-nya, jadi hasilnya sama persis
+// dengan eksekusi sebenarnya tanpa mengubah data.
+type BulkTaskRequest struct {
+	IDs       []uint             `json:"ids" binding:"required_without=Filter"`
+	Filter    *TaskFilterRequest `json:"filter" binding:"required_without=IDs"`
+	Operation string             `json:"operation" binding:"required,oneof=set_status reassign set_deadline add_label delete"`
+	Status    *string            `json:"status" binding:"omitempty,oneof=todo in_progress done"`
+	AccountID *uint              `json:"account_id"`
+	Deadline  *time.Time         `json:"deadline"`
+	LabelID   *uint              `json:"label_id"`
+	DryRun    bool               `json:"dry_run"`
+}
+
+const (
+	BulkResultUpdated   = "updated"
+	BulkResultUnchanged = "unchanged"
+	BulkResultDeleted   = "deleted"
+	BulkResultFailed    = "failed"
+)
+
+// BulkFieldChange adalah perubahan satu field oleh operasi bulk
+type BulkFieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+type BulkTaskResult struct {
+	ID      uint              `json:"id"`
+	Result  string            `json:"result"`
+	Changes []BulkFieldChange `json:"changes,omitempty"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// BulkTaskResponse berisi hasil per task. Applied false berarti tidak ada
+// perubahan yang tersimpan: dry run, atau ada task yang gagal sehingga
+// seluruh operasi di-rollback.
+type BulkTaskResponse struct {
+	Operation string           `json:"operation"`
+	DryRun    bool             `json:"dry_run"`
+	Applied   bool             `json:"applied"`
+	Total     int              `json:"total"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Results   []BulkTaskResult `json:"results"`
+}
+
+type AddParticipantRequest struct {
+	AccountID uint `json:"account_id" binding:"required"`
+}
+
+type AddDependencyRequest struct {
+	BlockedByID uint `json:"blocked_by_id" binding:"required"`
 }
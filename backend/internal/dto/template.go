@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"backend/internal/models"
+	"time"
+)
+
+type TemplateSubtaskRequest struct {
+	Title                 string           `json:"title" binding:"required"`
+	Description           string           `json:"description"`
+	Priority              *models.Priority `json:"priority"`
+	DeadlineOffsetMinutes *int             `json:"deadline_offset_minutes" binding:"omitempty,min=0"`
+}
+
+type CreateTemplateRequest struct {
+	Name                  string                   `json:"name" binding:"required,max=100"`
+	Title                 string                   `json:"title" binding:"required"`
+	Description           string                   `json:"description"`
+	Status                string                   `json:"status" binding:"omitempty,oneof=todo in_progress done"`
+	Priority              *models.Priority         `json:"priority"`
+	LabelIDs              []uint                   `json:"label_ids"`
+	DeadlineOffsetMinutes *int                     `json:"deadline_offset_minutes" binding:"omitempty,min=0"`
+	Subtasks              []TemplateSubtaskRequest `json:"subtasks" binding:"dive"`
+}
+
+// UpdateTemplateRequest mengubah field yang diisi saja; LabelIDs dan
+// Subtasks jika diisi menggantikan seluruh isi sebelumnya
+type UpdateTemplateRequest struct {
+	Name                  *string                   `json:"name" binding:"omitempty,max=100"`
+	Title                 *string                   `json:"title"`
+	Description           *string                   `json:"description"`
+	Status                *string                   `json:"status" binding:"omitempty,oneof=todo in_progress done"`
+	Priority              *models.Priority          `json:"priority"`
+	LabelIDs              *[]uint                   `json:"label_ids"`
+	DeadlineOffsetMinutes *int                      `json:"deadline_offset_minutes" binding:"omitempty,min=0"`
+	ClearDeadline         bool                      `json:"clear_deadline"`
+	Subtasks              *[]TemplateSubtaskRequest `json:"subtasks" binding:"omitempty,dive"`
+}
+
+// CreateFromTemplateRequest mengisi placeholder template. Deadline dihitung
+// dari StartAt (default: sekarang) ditambah offset di template.
+type CreateFromTemplateRequest struct {
+	Variables map[string]string `json:"variables"`
+	AccountID uint              `json:"account_id"`
+	ProjectID *uint             `json:"project_id"`
+	ParentID  *uint             `json:"parent_id"`
+	StartAt   *time.Time        `json:"start_at"`
+}
+
+type TemplateResponse struct {
+	models.TaskTemplate
+	// Variables adalah placeholder yang harus diisi saat membuat task
+	Variables []string `json:"variables"`
+}
@@ -0,0 +1,44 @@
+package dto
+
+import "backend/internal/models"
+
+type CreateViewRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+	// Visibility default private; project membutuhkan ProjectID
+	Visibility string                 `json:"visibility" binding:"omitempty,oneof=private project workspace"`
+	ProjectID  *uint                  `json:"project_id"`
+	Filters    models.SavedViewFilter `json:"filters"`
+	Sort       string                 `json:"sort" binding:"omitempty,oneof=newest oldest priority deadline priority_deadline rank relevance"`
+	// Order adalah urutan terstruktur seperti di /task/list; Sort yang diisi
+	// tetap diprioritaskan
+	Order TaskOrders `json:"order" binding:"omitempty,dive"`
+	// Columns adalah field TaskResponse yang ditampilkan, sesuai urutan
+	Columns []string `json:"columns" binding:"omitempty,dive,oneof=id title description status priority deadline estimate_minutes time_spent_minutes accounts assignees watchers project parent_id labels progress is_blocked rank created_at updated_at"`
+	GroupBy string   `json:"group_by" binding:"omitempty,oneof=status priority assignee project label"`
+}
+
+// UpdateViewRequest mengubah field yang diisi saja; Filters dan Columns
+// jika diisi menggantikan seluruh isi sebelumnya
+type UpdateViewRequest struct {
+	Name       *string                 `json:"name" binding:"omitempty,max=100"`
+	Visibility *string                 `json:"visibility" binding:"omitempty,oneof=private project workspace"`
+	ProjectID  *uint                   `json:"project_id"`
+	Filters    *models.SavedViewFilter `json:"filters"`
+	Sort       *string                 `json:"sort" binding:"omitempty,oneof=newest oldest priority deadline priority_deadline rank relevance"`
+	// Order jika diisi menggantikan urutan sebelumnya; [] menghapusnya
+	Order   *TaskOrders `json:"order" binding:"omitempty,dive"`
+	Columns *[]string   `json:"columns" binding:"omitempty,dive,oneof=id title description status priority deadline estimate_minutes time_spent_minutes accounts assignees watchers project parent_id labels progress is_blocked rank created_at updated_at"`
+	// GroupBy "" menghapus pengelompokan
+	GroupBy *string `json:"group_by" binding:"omitempty,oneof=status priority assignee project label"`
+}
+
+type ViewResponse struct {
+	models.SavedView
+	// Editable true jika akun yang login adalah pemilik view
+	Editable bool `json:"editable"`
+}
+
+type ViewListRequest struct {
+	// ProjectID hanya menampilkan view yang dibagikan ke project tersebut
+	ProjectID *uint `form:"project_id"`
+}
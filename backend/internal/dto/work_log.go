@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"time"
+)
+
+type StopTimerRequest struct {
+	Note string `json:"note" binding:"max=1000"`
+}
+
+// CreateWorkLogRequest mencatat waktu kerja manual. StartedAt default-nya
+// sekarang dikurangi durasi.
+type CreateWorkLogRequest struct {
+	DurationMinutes int        `json:"duration_minutes" binding:"required,min=1,max=1440"`
+	StartedAt       *time.Time `json:"started_at"`
+	Note            string     `json:"note" binding:"max=1000"`
+}
+
+// TimesheetRequest memilih rentang tanggal (inklusif, UTC) untuk timesheet.
+// AccountID default-nya user yang login; akun lain hanya untuk owner/admin.
+type TimesheetRequest struct {
+	From      time.Time `form:"from" time_format:"2006-01-02" binding:"required"`
+	To        time.Time `form:"to" time_format:"2006-01-02" binding:"required,gtefield=From"`
+	AccountID *uint     `form:"account_id"`
+}
+
+// TimesheetRow adalah total detik satu task pada satu hari
+type TimesheetRow struct {
+	Day       time.Time
+	TaskID    uint
+	Title     string
+	ProjectID *uint
+	Seconds   int64
+}
+
+type TimesheetTask struct {
+	TaskID    uint   `json:"task_id"`
+	Title     string `json:"title"`
+	ProjectID *uint  `json:"project_id"`
+	Minutes   int64  `json:"minutes"`
+}
+
+type TimesheetDay struct {
+	Date    string          `json:"date"`
+	Minutes int64           `json:"minutes"`
+	Tasks   []TimesheetTask `json:"tasks"`
+}
+
+type TimesheetResponse struct {
+	AccountID uint            `json:"account_id"`
+	From      string          `json:"from"`
+	To        string          `json:"to"`
+	Minutes   int64           `json:"minutes"`
+	Days      []TimesheetDay  `json:"days"`
+	Tasks     []TimesheetTask `json:"tasks"`
+}
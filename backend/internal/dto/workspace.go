@@ -0,0 +1,15 @@
+package dto
+
+type CreateWorkspaceRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+	Slug string `json:"slug" binding:"omitempty,min=2,max=50"`
+}
+
+type UpdateWorkspaceRequest struct {
+	Name *string `json:"name" binding:"omitempty,max=100"`
+}
+
+type AddWorkspaceMemberRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"omitempty,oneof=admin member"`
+}
@@ -0,0 +1,32 @@
+package helper
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetUserID mengambil user_id yang di-set oleh middleware AuthorizeJWT
+func GetUserID(ctx *gin.Context) (uint, error) {
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		return 0, errors.New("user not authenticated")
+	}
+
+	userID, err := strconv.ParseUint(userIDStr.(string), 10, 32)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint(userID), nil
+}
+
+// GetParamID membaca path parameter numerik, misalnya :id
+func GetParamID(ctx *gin.Context, key string) (uint, error) {
+	id, err := strconv.ParseUint(ctx.Param(key), 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint(id), nil
+}
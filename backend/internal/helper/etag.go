@@ -0,0 +1,31 @@
+package helper
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ETag membentuk entity tag dari version resource, misalnya "3"
+func ETag(version uint) string {
+	return `"` + strconv.FormatUint(uint64(version), 10) + `"`
+}
+
+// ParseIfMatch membaca header If-Match menjadi daftar version. nil berarti
+// tidak ada precondition (header kosong atau "*"); entity tag yang tidak
+// dikenali diabaikan sehingga tidak pernah cocok.
+func ParseIfMatch(header string) []uint {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return nil
+	}
+
+	versions := []uint{}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		version, err := strconv.ParseUint(strings.Trim(tag, `"`), 10, 32)
+		if err == nil {
+			versions = append(versions, uint(version))
+		}
+	}
+	return versions
+}
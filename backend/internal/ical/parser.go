@@ -0,0 +1,209 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Component adalah satu komponen iCalendar (VCALENDAR, VTODO, ...) beserta
+// property dan komponen di dalamnya
+type Component struct {
+	Name       string
+	Properties []Property
+	Children   []*Component
+}
+
+// Property adalah satu baris content line; Value masih dalam bentuk
+// ter-escape, pakai Text untuk nilai bertipe TEXT
+type Property struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// Text mengembalikan nilai TEXT yang sudah di-unescape
+func (p *Property) Text() string {
+	return UnescapeText(p.Value)
+}
+
+// Prop mengembalikan property pertama bernama name, nil jika tidak ada
+func (c *Component) Prop(name string) *Property {
+	for i := range c.Properties {
+		if c.Properties[i].Name == name {
+			return &c.Properties[i]
+		}
+	}
+	return nil
+}
+
+// Find mencari komponen name di dalam c (tidak termasuk c sendiri)
+func (c *Component) Find(name string) *Component {
+	for _, child := range c.Children {
+		if child.Name == name {
+			return child
+		}
+		if found := child.Find(name); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// Parse membaca satu objek iCalendar (biasanya VCALENDAR)
+func Parse(r io.Reader) (*Component, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var root *Component
+	var stack []*Component
+	for n, line := range lines {
+		if line == "" {
+			continue
+		}
+		prop, err := parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+
+		switch prop.Name {
+		case "BEGIN":
+			component := &Component{Name: strings.ToUpper(prop.Value)}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, component)
+			} else if root == nil {
+				root = component
+			} else {
+				return nil, errors.New("multiple root components")
+			}
+			stack = append(stack, component)
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].Name != strings.ToUpper(prop.Value) {
+				return nil, fmt.Errorf("line %d: unexpected END:%s", n+1, prop.Value)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("line %d: property outside component", n+1)
+			}
+			current := stack[len(stack)-1]
+			current.Properties = append(current.Properties, prop)
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New("no component found")
+	}
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("missing END:%s", stack[len(stack)-1].Name)
+	}
+	return root, nil
+}
+
+// unfold menggabungkan baris lanjutan (diawali spasi atau tab)
+func unfold(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// parseLine memecah "NAME;PARAM=value:VALUE". Titik dua dan titik koma di
+// dalam parameter yang dikutip tidak dihitung sebagai pemisah.
+func parseLine(line string) (Property, error) {
+	prop := Property{Params: map[string]string{}}
+
+	inQuote := false
+	split := -1
+	for i, r := range line {
+		if r == '"' {
+			inQuote = !inQuote
+		} else if r == ':' && !inQuote {
+			split = i
+			break
+		}
+	}
+	if split < 0 {
+		return prop, fmt.Errorf("invalid content line %q", line)
+	}
+
+	head, value := line[:split], line[split+1:]
+	parts := splitParams(head)
+	prop.Name = strings.ToUpper(parts[0])
+	prop.Value = value
+	for _, param := range parts[1:] {
+		key, val, _ := strings.Cut(param, "=")
+		prop.Params[strings.ToUpper(key)] = strings.Trim(val, `"`)
+	}
+	if prop.Name == "" {
+		return prop, fmt.Errorf("invalid content line %q", line)
+	}
+	return prop, nil
+}
+
+func splitParams(head string) []string {
+	var parts []string
+	inQuote := false
+	start := 0
+	for i, r := range head {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+		case r == ';' && !inQuote:
+			parts = append(parts, head[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, head[start:])
+}
+
+var textUnescaper = strings.NewReplacer(
+	`\\`, `\`,
+	`\;`, ";",
+	`\,`, ",",
+	`\n`, "\n",
+	`\N`, "\n",
+)
+
+// UnescapeText kebalikan EscapeText
+func UnescapeText(value string) string {
+	return textUnescaper.Replace(value)
+}
+
+// Time membaca nilai DATE-TIME atau DATE. Waktu dengan TZID memakai zona
+// tersebut, waktu floating (tanpa Z dan TZID) dianggap UTC, dan DATE
+// menjadi tengah malam UTC.
+func (p *Property) Time() (time.Time, error) {
+	value := strings.TrimSpace(p.Value)
+	if p.Params["VALUE"] == "DATE" || len(value) == len("20060102") {
+		return time.Parse("20060102", value)
+	}
+	if strings.HasSuffix(value, "Z") {
+		return time.Parse(dateTimeLayout, value)
+	}
+
+	location := time.UTC
+	if tzid := p.Params["TZID"]; tzid != "" {
+		loaded, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("unknown TZID %q", tzid)
+		}
+		location = loaded
+	}
+	return time.ParseInLocation("20060102T150405", value, location)
+}
@@ -0,0 +1,105 @@
+package ical
+
+import (
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxLineOctets adalah panjang baris maksimum sebelum dilipat (RFC 5545 3.1)
+const maxLineOctets = 75
+
+const dateTimeLayout = "20060102T150405Z"
+
+// Writer menulis objek iCalendar (RFC 5545). Setiap baris diakhiri CRLF
+// dan dilipat jika lebih dari 75 oktet. Error penulisan pertama disimpan
+// dan dikembalikan oleh Err, jadi pemanggil cukup memeriksanya di akhir.
+type Writer struct {
+	w   io.Writer
+	err error
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+func (w *Writer) Begin(component string) {
+	w.Property("BEGIN", component)
+}
+
+func (w *Writer) End(component string) {
+	w.Property("END", component)
+}
+
+// Property menulis nilai apa adanya; name boleh berisi parameter,
+// misalnya "DUE;VALUE=DATE"
+func (w *Writer) Property(name, value string) {
+	w.writeLine(name + ":" + value)
+}
+
+// Text menulis nilai bertipe TEXT dengan escaping
+func (w *Writer) Text(name, value string) {
+	w.Property(name, EscapeText(value))
+}
+
+// TextList menulis beberapa nilai TEXT dipisah koma, misalnya CATEGORIES
+func (w *Writer) TextList(name string, values []string) {
+	escaped := make([]string, len(values))
+	for i, value := range values {
+		escaped[i] = EscapeText(value)
+	}
+	w.Property(name, strings.Join(escaped, ","))
+}
+
+// Time menulis DATE-TIME dalam UTC
+func (w *Writer) Time(name string, t time.Time) {
+	w.Property(name, FormatTime(t))
+}
+
+func (w *Writer) Err() error {
+	return w.err
+}
+
+func (w *Writer) writeLine(line string) {
+	if w.err != nil {
+		return
+	}
+
+	var b strings.Builder
+	// baris lanjutan diawali satu spasi yang ikut dihitung
+	limit := maxLineOctets
+	for len(line) > limit {
+		// potong di batas rune supaya karakter UTF-8 tidak terbelah
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = maxLineOctets - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+
+	_, w.err = io.WriteString(w.w, b.String())
+}
+
+func FormatTime(t time.Time) string {
+	return t.UTC().Format(dateTimeLayout)
+}
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+// EscapeText meng-escape nilai TEXT (RFC 5545 3.3.11)
+func EscapeText(value string) string {
+	return textEscaper.Replace(value)
+}
@@ -0,0 +1,194 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Field task yang bisa dipetakan dari kolom CSV generik
+const (
+	FieldTitle           = "title"
+	FieldDescription     = "description"
+	FieldStatus          = "status"
+	FieldPriority        = "priority"
+	FieldDeadline        = "deadline"
+	FieldEstimateMinutes = "estimate_minutes"
+	FieldAssignees       = "assignees"
+	FieldLabels          = "labels"
+	FieldExternalID      = "external_id"
+	FieldParentID        = "parent_id"
+)
+
+var CSVFields = []string{
+	FieldTitle, FieldDescription, FieldStatus, FieldPriority, FieldDeadline,
+	FieldEstimateMinutes, FieldAssignees, FieldLabels, FieldExternalID, FieldParentID,
+}
+
+var ErrMissingColumn = errors.New("missing column")
+
+// CSVAdapter membaca CSV dengan header. Columns memetakan field ke nama
+// kolom; field yang tidak dipetakan dicari dari kolom bernama sama dengan
+// field-nya (tidak case sensitive).
+type CSVAdapter struct {
+	Columns map[string]string
+}
+
+func NewCSVAdapter(columns map[string]string) (*CSVAdapter, error) {
+	known := make(map[string]bool, len(CSVFields))
+	for _, field := range CSVFields {
+		known[field] = true
+	}
+	for field := range columns {
+		if !known[field] {
+			return nil, fmt.Errorf("unknown import field %q", field)
+		}
+	}
+	return &CSVAdapter{Columns: columns}, nil
+}
+
+func (a *CSVAdapter) Parse(r io.Reader) ([]Record, error) {
+	table, err := readCSV(r)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(CSVFields))
+	for _, field := range CSVFields {
+		header := field
+		if mapped, ok := a.Columns[field]; ok {
+			header = mapped
+		}
+		if index := table.column(header); index >= 0 {
+			columns[field] = index
+		} else if _, ok := a.Columns[field]; ok {
+			return nil, fmt.Errorf("%w %q", ErrMissingColumn, header)
+		}
+	}
+	if _, ok := columns[FieldTitle]; !ok {
+		return nil, fmt.Errorf("%w for field %q", ErrMissingColumn, FieldTitle)
+	}
+
+	records := make([]Record, 0, len(table.rows))
+	for _, row := range table.rows {
+		value := func(field string) string {
+			if index, ok := columns[field]; ok {
+				return row.value(index)
+			}
+			return ""
+		}
+
+		record := Record{
+			Line:        row.line,
+			ExternalID:  value(FieldExternalID),
+			ParentID:    value(FieldParentID),
+			Title:       value(FieldTitle),
+			Description: value(FieldDescription),
+			Status:      value(FieldStatus),
+			Assignees:   splitList(value(FieldAssignees)),
+			Labels:      splitList(value(FieldLabels)),
+		}
+		if record.Priority, err = parsePriority(value(FieldPriority)); err != nil {
+			record.addError("%s", err.Error())
+		}
+		if record.Deadline, err = parseDate(value(FieldDeadline)); err != nil {
+			record.addError("%s", err.Error())
+		}
+		if record.EstimateMinutes, err = parseMinutes(value(FieldEstimateMinutes), 60); err != nil {
+			record.addError("%s", err.Error())
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+type csvRow struct {
+	line   int
+	fields []string
+}
+
+func (r csvRow) value(index int) string {
+	if index < 0 || index >= len(r.fields) {
+		return ""
+	}
+	return strings.TrimSpace(r.fields[index])
+}
+
+type csvTable struct {
+	header []string
+	rows   []csvRow
+}
+
+// column mengembalikan index kolom pertama bernama name, -1 jika tidak ada
+func (t csvTable) column(name string) int {
+	for i, header := range t.header {
+		if strings.EqualFold(strings.TrimSpace(header), strings.TrimSpace(name)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// columns mengembalikan semua index kolom bernama name; export Jira
+// mengulang kolom yang bernilai banyak seperti "Labels"
+func (t csvTable) columns(name string) []int {
+	var indexes []int
+	for i, header := range t.header {
+		if strings.EqualFold(strings.TrimSpace(header), name) {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// readCSV membaca seluruh CSV; baris kosong dilewati dan nomor baris
+// disimpan sesuai posisi di file (baris multiline dihitung dari awalnya)
+func readCSV(r io.Reader) (*csvTable, error) {
+	buffered := bufio.NewReader(r)
+	// lewati BOM UTF-8 dari export Excel/Jira
+	if bom, err := buffered.Peek(3); err == nil && string(bom) == "\xef\xbb\xbf" {
+		buffered.Discard(3)
+	}
+
+	reader := csv.NewReader(buffered)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	table := &csvTable{header: header}
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if isBlank(fields) {
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		table.rows = append(table.rows, csvRow{line: line, fields: fields})
+	}
+	return table, nil
+}
+
+func isBlank(fields []string) bool {
+	for _, field := range fields {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,146 @@
+package importer
+
+import (
+	"backend/internal/models"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format file import yang didukung
+const (
+	FormatCSV    = "csv"
+	FormatTrello = "trello"
+	FormatJira   = "jira"
+)
+
+var ErrUnknownFormat = errors.New("unknown import format")
+
+// Record adalah satu task hasil parsing file sumber. Status dan assignee
+// masih berupa nilai dari sistem sumber; pemetaannya ke status dan akun
+// dilakukan oleh service. Errors berisi kesalahan parsing baris ini.
+type Record struct {
+	// Line adalah nomor baris (CSV) atau urutan card (Trello), untuk pesan error
+	Line            int
+	ExternalID      string
+	ParentID        string
+	Title           string
+	Description     string
+	Status          string
+	Priority        *models.Priority
+	Deadline        *time.Time
+	EstimateMinutes *int
+	Assignees       []string
+	Labels          []string
+	Errors          []string
+}
+
+func (r *Record) addError(format string, args ...interface{}) {
+	r.Errors = append(r.Errors, fmt.Sprintf(format, args...))
+}
+
+// Adapter membaca file export dari sistem lain menjadi daftar Record.
+// Error hanya dikembalikan jika file secara keseluruhan tidak bisa dibaca;
+// kesalahan per baris dicatat di Record.Errors.
+type Adapter interface {
+	Parse(r io.Reader) ([]Record, error)
+}
+
+// NewAdapter memilih adapter untuk format. columns hanya dipakai format csv
+// (field -> nama kolom), lihat CSVFields.
+func NewAdapter(format string, columns map[string]string) (Adapter, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVAdapter(columns)
+	case FormatTrello:
+		return TrelloAdapter{}, nil
+	case FormatJira:
+		return JiraAdapter{}, nil
+	}
+	return nil, ErrUnknownFormat
+}
+
+// dateLayouts adalah format tanggal yang dikenali, termasuk format default
+// export Jira ("02/Jan/06 3:04 PM")
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"02/Jan/06 3:04 PM",
+	"02/Jan/06",
+	"01/02/2006",
+}
+
+func parseDate(value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range dateLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return &parsed, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid date %q", value)
+}
+
+// priorityNames memetakan nama prioritas umum (termasuk prioritas Jira) ke P0..P4
+var priorityNames = map[string]models.Priority{
+	"highest":  models.PriorityP0,
+	"critical": models.PriorityP0,
+	"urgent":   models.PriorityP0,
+	"blocker":  models.PriorityP0,
+	"high":     models.PriorityP1,
+	"major":    models.PriorityP1,
+	"medium":   models.PriorityP2,
+	"normal":   models.PriorityP2,
+	"low":      models.PriorityP3,
+	"minor":    models.PriorityP3,
+	"lowest":   models.PriorityP4,
+	"trivial":  models.PriorityP4,
+}
+
+func parsePriority(value string) (*models.Priority, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	if priority, ok := priorityNames[strings.ToLower(value)]; ok {
+		return &priority, nil
+	}
+	priority, err := models.ParsePriority(value)
+	if err != nil {
+		return nil, err
+	}
+	return &priority, nil
+}
+
+// parseMinutes membaca angka non-negatif dalam satuan unit detik
+func parseMinutes(value string, unitSeconds int) (*int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return nil, fmt.Errorf("invalid estimate %q", value)
+	}
+	minutes := n * unitSeconds / 60
+	return &minutes, nil
+}
+
+// splitList memecah nilai multi (assignee, label) yang dipisah koma atau titik koma
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
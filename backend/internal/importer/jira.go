@@ -0,0 +1,75 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+)
+
+// JiraAdapter membaca export CSV Jira ("Export Excel CSV (all fields)").
+// Subtask dihubungkan ke parent lewat kolom "Parent id" yang merujuk ke
+// "Issue id". Assignee berisi nama tampilan Jira, jadi biasanya perlu
+// dipetakan ke email lewat account map.
+type JiraAdapter struct{}
+
+func (JiraAdapter) Parse(r io.Reader) ([]Record, error) {
+	table, err := readCSV(r)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := table.column("Summary")
+	if summary < 0 {
+		return nil, fmt.Errorf("%w %q", ErrMissingColumn, "Summary")
+	}
+	issueID := firstColumn(table, "Issue id", "Issue key")
+	parentID := firstColumn(table, "Parent id", "Parent")
+	status := table.column("Status")
+	priority := table.column("Priority")
+	assignee := table.column("Assignee")
+	dueDate := table.column("Due Date")
+	description := table.column("Description")
+	estimate := table.column("Original Estimate")
+	labels := table.columns("Labels")
+
+	records := make([]Record, 0, len(table.rows))
+	for _, row := range table.rows {
+		record := Record{
+			Line:        row.line,
+			ExternalID:  row.value(issueID),
+			ParentID:    row.value(parentID),
+			Title:       row.value(summary),
+			Description: row.value(description),
+			Status:      row.value(status),
+		}
+		if name := row.value(assignee); name != "" {
+			record.Assignees = []string{name}
+		}
+		for _, index := range labels {
+			// label Jira tidak boleh mengandung spasi, satu kolom bisa berisi
+			// beberapa label jika diexport tanpa kolom berulang
+			record.Labels = append(record.Labels, splitList(row.value(index))...)
+		}
+		if record.Priority, err = parsePriority(row.value(priority)); err != nil {
+			record.addError("%s", err.Error())
+		}
+		if record.Deadline, err = parseDate(row.value(dueDate)); err != nil {
+			record.addError("%s", err.Error())
+		}
+		// Original Estimate diexport dalam detik
+		if record.EstimateMinutes, err = parseMinutes(row.value(estimate), 1); err != nil {
+			record.addError("%s", err.Error())
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func firstColumn(table *csvTable, names ...string) int {
+	for _, name := range names {
+		if index := table.column(name); index >= 0 {
+			return index
+		}
+	}
+	return -1
+}
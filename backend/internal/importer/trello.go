@@ -0,0 +1,98 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// TrelloAdapter membaca export JSON board Trello. Status diambil dari nama
+// list card; card dan list yang diarsipkan dilewati. Export Trello tidak
+// berisi email member, jadi assignee berupa username Trello.
+type TrelloAdapter struct{}
+
+type trelloBoard struct {
+	Lists []struct {
+		ID     string `json:"id"`
+		Name   string `json:"name"`
+		Closed bool   `json:"closed"`
+	} `json:"lists"`
+	Labels []struct {
+		ID    string `json:"id"`
+		Name  string `json:"name"`
+		Color string `json:"color"`
+	} `json:"labels"`
+	Members []struct {
+		ID       string `json:"id"`
+		Username string `json:"username"`
+	} `json:"members"`
+	Cards []struct {
+		ID        string     `json:"id"`
+		Name      string     `json:"name"`
+		Desc      string     `json:"desc"`
+		ListID    string     `json:"idList"`
+		Due       *time.Time `json:"due"`
+		Closed    bool       `json:"closed"`
+		MemberIDs []string   `json:"idMembers"`
+		LabelIDs  []string   `json:"idLabels"`
+	} `json:"cards"`
+}
+
+func (TrelloAdapter) Parse(r io.Reader) ([]Record, error) {
+	var board trelloBoard
+	if err := json.NewDecoder(r).Decode(&board); err != nil {
+		return nil, fmt.Errorf("invalid trello export: %w", err)
+	}
+
+	lists := make(map[string]string, len(board.Lists))
+	closedLists := make(map[string]bool)
+	for _, list := range board.Lists {
+		lists[list.ID] = list.Name
+		closedLists[list.ID] = list.Closed
+	}
+	labels := make(map[string]string, len(board.Labels))
+	for _, label := range board.Labels {
+		// label Trello boleh tanpa nama, hanya warna
+		name := label.Name
+		if name == "" {
+			name = label.Color
+		}
+		labels[label.ID] = name
+	}
+	members := make(map[string]string, len(board.Members))
+	for _, member := range board.Members {
+		members[member.ID] = member.Username
+	}
+
+	records := make([]Record, 0, len(board.Cards))
+	for i, card := range board.Cards {
+		if card.Closed || closedLists[card.ListID] {
+			continue
+		}
+
+		record := Record{
+			Line:        i + 1,
+			ExternalID:  card.ID,
+			Title:       card.Name,
+			Description: card.Desc,
+			Status:      lists[card.ListID],
+			Deadline:    card.Due,
+		}
+		for _, id := range card.MemberIDs {
+			if username, ok := members[id]; ok {
+				record.Assignees = append(record.Assignees, username)
+			} else {
+				record.addError("unknown trello member %q", id)
+			}
+		}
+		for _, id := range card.LabelIDs {
+			if name, ok := labels[id]; ok && name != "" {
+				record.Labels = append(record.Labels, name)
+			}
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"backend/internal/service"
+	"backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuthorizeAppPassword mengautentikasi client non-browser (misalnya CalDAV)
+// dengan HTTP Basic: username email akun, password berupa app password.
+// Context request diisi sama seperti AuthorizeJWT. App password hanya
+// berlaku selama pemiliknya masih member workspace.
+func AuthorizeAppPassword(appPasswordService service.AppPasswordService, workspaceService service.WorkspaceService, realm string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		email, password, ok := c.Request.BasicAuth()
+		if !ok {
+			unauthorizedBasic(c, realm, "Authorization header missing")
+			return
+		}
+
+		appPassword, err := appPasswordService.Authenticate(c.Request.Context(), email, password)
+		if errors.Is(err, service.ErrAppPasswordInvalid) {
+			unauthorizedBasic(c, realm, err.Error())
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to authenticate", "details": err.Error()})
+			c.Abort()
+			return
+		}
+
+		isMember, err := workspaceService.IsMember(c.Request.Context(), appPassword.WorkspaceID, appPassword.AccountID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to authenticate", "details": err.Error()})
+			c.Abort()
+			return
+		}
+		if !isMember {
+			unauthorizedBasic(c, realm, service.ErrNotWorkspaceMember.Error())
+			return
+		}
+
+		c.Set("user_id", strconv.FormatUint(uint64(appPassword.AccountID), 10))
+		c.Set("email", appPassword.Account.Email)
+		c.Set("workspace_id", appPassword.WorkspaceID)
+
+		requestCtx := utils.WithAccountID(c.Request.Context(), appPassword.AccountID)
+		requestCtx = utils.WithWorkspaceID(requestCtx, appPassword.WorkspaceID)
+		c.Request = c.Request.WithContext(requestCtx)
+
+		c.Next()
+	}
+}
+
+func unauthorizedBasic(c *gin.Context, realm, message string) {
+	c.Header("WWW-Authenticate", `Basic realm="`+realm+`", charset="UTF-8"`)
+	c.JSON(http.StatusUnauthorized, gin.H{"error": message})
+	c.Abort()
+}
@@ -2,14 +2,18 @@ package middleware
 
 import (
 	"net/http"
+	"strconv"
 	"strings"
 
 	"backend/internal/service"
+	"backend/internal/utils"
 
 	"github.com/gin-gonic/gin"
 )
 
-func AuthorizeJWT(jwtService service.JWTService) gin.HandlerFunc {
+// AuthorizeJWT memvalidasi token dan memastikan akun masih member workspace
+// di token, supaya member yang dikeluarkan langsung kehilangan akses
+func AuthorizeJWT(jwtService service.JWTService, workspaceService service.WorkspaceService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -34,9 +38,41 @@ func AuthorizeJWT(jwtService service.JWTService) gin.HandlerFunc {
 			return
 		}
 
+		userID, err := strconv.ParseUint(claims.UserID, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
+			c.Abort()
+			return
+		}
+
+		// token lama (sebelum ada workspace) tidak membawa workspace, wajib login ulang
+		if claims.WorkspaceID == 0 {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token has no workspace, please login again"})
+			c.Abort()
+			return
+		}
+
+		isMember, err := workspaceService.IsMember(c.Request.Context(), claims.WorkspaceID, uint(userID))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to authorize", "details": err.Error()})
+			c.Abort()
+			return
+		}
+		if !isMember {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "No longer a member of the workspace, please login again"})
+			c.Abort()
+			return
+		}
+
 		// simpan data klaim ke context supaya bisa dipakai di controller
 		c.Set("user_id", claims.UserID)
 		c.Set("email", claims.Email)
+		c.Set("workspace_id", claims.WorkspaceID)
+
+		// dan ke context request supaya repository bisa membatasi data per akun dan workspace
+		requestCtx := utils.WithAccountID(c.Request.Context(), uint(userID))
+		requestCtx = utils.WithWorkspaceID(requestCtx, claims.WorkspaceID)
+		c.Request = c.Request.WithContext(requestCtx)
 
 		c.Next()
 	}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// AppPassword adalah password acak khusus untuk aplikasi pihak ketiga
+// (misalnya client CalDAV) yang tidak bisa memakai JWT. Berlaku untuk satu
+// workspace dan bisa dicabut satu per satu tanpa mengganti password akun.
+type AppPassword struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	WorkspaceID  uint       `gorm:"column:workspace_id;NOT NULL;index" json:"workspace_id"`
+	AccountID    uint       `gorm:"column:accounts_id;NOT NULL;index" json:"accounts_id"`
+	Account      *Account   `gorm:"foreignKey:AccountID;constraint:onDelete:CASCADE,onUpdate:RESTRICT" json:"-"`
+	Name         string     `gorm:"column:name;NOT NULL" json:"name"`
+	PasswordHash string     `gorm:"column:password_hash;NOT NULL;uniqueIndex" json:"-"`
+	LastUsedAt   *time.Time `gorm:"column:last_used_at" json:"last_used_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+func (p *AppPassword) TableName() string {
+	return "app_passwords"
+}
+
+func (p *AppPassword) GetWorkspaceID() uint {
+	return p.WorkspaceID
+}
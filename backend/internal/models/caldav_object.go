@@ -0,0 +1,21 @@
+package models
+
+// CalDAVObject menyimpan nama resource dan UID yang dipilih client CalDAV
+// saat membuat task lewat PUT, supaya task tetap bisa diakses di URL dan
+// UID yang sama. Task yang dibuat dari aplikasi tidak punya baris ini dan
+// memakai nama "<id>.ics".
+type CalDAVObject struct {
+	TaskID      uint   `gorm:"column:task_id;primaryKey" json:"task_id"`
+	Task        *Task  `gorm:"foreignKey:TaskID;constraint:onDelete:CASCADE,onUpdate:RESTRICT" json:"-"`
+	WorkspaceID uint   `gorm:"column:workspace_id;NOT NULL;uniqueIndex:idx_caldav_objects_name,priority:1;uniqueIndex:idx_caldav_objects_uid,priority:1" json:"workspace_id"`
+	Name        string `gorm:"column:name;NOT NULL;uniqueIndex:idx_caldav_objects_name,priority:2" json:"name"`
+	UID         string `gorm:"column:uid;NOT NULL;uniqueIndex:idx_caldav_objects_uid,priority:2" json:"uid"`
+}
+
+func (o *CalDAVObject) TableName() string {
+	return "caldav_objects"
+}
+
+func (o *CalDAVObject) GetWorkspaceID() uint {
+	return o.WorkspaceID
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+)
+
+// CalendarToken adalah token acak untuk URL feed kalender (ICS) seorang
+// akun di satu workspace. Hanya hash-nya yang disimpan; token asli hanya
+// ditampilkan sekali saat dibuat. Membuat token baru mencabut token lama.
+type CalendarToken struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	WorkspaceID uint       `gorm:"column:workspace_id;NOT NULL;uniqueIndex:idx_calendar_tokens_account,priority:1" json:"workspace_id"`
+	AccountID   uint       `gorm:"column:accounts_id;NOT NULL;uniqueIndex:idx_calendar_tokens_account,priority:2" json:"accounts_id"`
+	Account     *Account   `gorm:"foreignKey:AccountID;constraint:onDelete:CASCADE,onUpdate:RESTRICT" json:"-"`
+	TokenHash   string     `gorm:"column:token_hash;NOT NULL;uniqueIndex" json:"-"`
+	LastUsedAt  *time.Time `gorm:"column:last_used_at" json:"last_used_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+func (t *CalendarToken) TableName() string {
+	return "calendar_tokens"
+}
+
+func (t *CalendarToken) GetWorkspaceID() uint {
+	return t.WorkspaceID
+}
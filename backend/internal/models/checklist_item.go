@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+// ChecklistItem adalah item ringan di dalam task, tanpa status/assignee sendiri
+type ChecklistItem struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	TaskID    uint      `gorm:"column:task_id;index;NOT NULL" json:"task_id"`
+	Title     string    `gorm:"column:title;NOT NULL" json:"title"`
+	IsDone    bool      `gorm:"column:is_done;NOT NULL;default:false" json:"is_done"`
+	Position  int       `gorm:"column:position;NOT NULL;default:0" json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (c *ChecklistItem) TableName() string {
+	return "checklist_items"
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	ImportJobPending   = "pending"
+	ImportJobRunning   = "running"
+	ImportJobCompleted = "completed"
+	ImportJobFailed    = "failed"
+)
+
+// ImportJob adalah import task yang dijalankan worker di background. Tasks
+// berisi baris yang sudah divalidasi saat job dibuat, jadi worker hanya
+// perlu membuat task-nya.
+type ImportJob struct {
+	ID              uint             `gorm:"primaryKey" json:"id"`
+	WorkspaceID     uint             `gorm:"column:workspace_id;NOT NULL;index" json:"workspace_id"`
+	CreateAccountID uint             `gorm:"column:create_accounts_id;NOT NULL;index" json:"create_accounts_id"`
+	CreateUser      *Account         `gorm:"foreignKey:CreateAccountID;constraint:onDelete:CASCADE,onUpdate:RESTRICT" json:"-"`
+	Format          string           `gorm:"column:format;NOT NULL" json:"format"`
+	ProjectID       *uint            `gorm:"column:project_id" json:"project_id"`
+	Status          string           `gorm:"column:status;NOT NULL;index" json:"status"`
+	Tasks           []ImportJobTask  `gorm:"column:tasks;type:jsonb;serializer:json" json:"-"`
+	Total           int              `gorm:"column:total;NOT NULL" json:"total"`
+	Processed       int              `gorm:"column:processed;NOT NULL;default:0" json:"processed"`
+	Created         int              `gorm:"column:created;NOT NULL;default:0" json:"created"`
+	Failed          int              `gorm:"column:failed;NOT NULL;default:0" json:"failed"`
+	Errors          []ImportJobError `gorm:"column:errors;type:jsonb;serializer:json" json:"errors"`
+	StartedAt       *time.Time       `gorm:"column:started_at" json:"started_at"`
+	FinishedAt      *time.Time       `gorm:"column:finished_at" json:"finished_at"`
+	CreatedAt       time.Time        `json:"created_at"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+}
+
+func (j *ImportJob) TableName() string {
+	return "import_jobs"
+}
+
+func (j *ImportJob) GetWorkspaceID() uint {
+	return j.WorkspaceID
+}
+
+func (j *ImportJob) IsFinished() bool {
+	return j.Status == ImportJobCompleted || j.Status == ImportJobFailed
+}
+
+// ImportJobTask adalah satu baris import yang sudah dipetakan ke field task.
+// ParentExternalID merujuk ke ExternalID baris lain di file yang sama.
+type ImportJobTask struct {
+	Line             int        `json:"line"`
+	ExternalID       string     `json:"external_id,omitempty"`
+	ParentExternalID string     `json:"parent_external_id,omitempty"`
+	Title            string     `json:"title"`
+	Description      string     `json:"description"`
+	Status           string     `json:"status"`
+	Priority         Priority   `json:"priority"`
+	Deadline         *time.Time `json:"deadline"`
+	EstimateMinutes  *int       `json:"estimate_minutes"`
+	AssigneeIDs      []uint     `json:"assignee_ids"`
+	Labels           []string   `json:"labels,omitempty"`
+}
+
+type ImportJobError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
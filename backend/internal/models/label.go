@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+)
+
+type Label struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	WorkspaceID     uint      `gorm:"column:workspace_id;NOT NULL;uniqueIndex:idx_labels_workspace_name,priority:1" json:"workspace_id"`
+	Name            string    `gorm:"column:name;NOT NULL;uniqueIndex:idx_labels_workspace_name,priority:2" json:"name"`
+	Color           string    `gorm:"column:color;NOT NULL" json:"color"`
+	CreateAccountID uint      `gorm:"column:create_accounts_id;NOT NULL" json:"create_accounts_id"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+func (l *Label) TableName() string {
+	return "labels"
+}
+
+func (l *Label) GetWorkspaceID() uint {
+	return l.WorkspaceID
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Priority adalah prioritas task berurutan, P0 paling mendesak dan P4 paling rendah.
+// Disimpan sebagai angka supaya bisa diurutkan di SQL, dan dikirim ke client sebagai "P0".."P4".
+type Priority int
+
+const (
+	PriorityP0 Priority = iota
+	PriorityP1
+	PriorityP2
+	PriorityP3
+	PriorityP4
+
+	DefaultPriority = PriorityP2
+)
+
+func ParsePriority(value string) (Priority, error) {
+	trimmed := strings.ToUpper(strings.TrimSpace(value))
+	trimmed = strings.TrimPrefix(trimmed, "P")
+
+	n, err := strconv.Atoi(trimmed)
+	if err != nil || !Priority(n).IsValid() {
+		return 0, fmt.Errorf("invalid priority %q, expected P0-P4", value)
+	}
+	return Priority(n), nil
+}
+
+func (p Priority) IsValid() bool {
+	return p >= PriorityP0 && p <= PriorityP4
+}
+
+func (p Priority) String() string {
+	return fmt.Sprintf("P%d", int(p))
+}
+
+func (p Priority) Value() (driver.Value, error) {
+	return int64(p), nil
+}
+
+func (p Priority) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+// UnmarshalJSON menerima "P1", "p1" maupun angka 1
+func (p *Priority) UnmarshalJSON(data []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var parsed Priority
+	var err error
+	switch value := raw.(type) {
+	case string:
+		parsed, err = ParsePriority(value)
+	case float64:
+		parsed, err = ParsePriority(strconv.FormatFloat(value, 'f', -1, 64))
+	default:
+		err = fmt.Errorf("invalid priority %s, expected P0-P4", string(data))
+	}
+	if err != nil {
+		return err
+	}
+
+	*p = parsed
+	return nil
+}
+
+// UnmarshalParam dipakai binding query/form gin, menerima "P1" maupun "1"
+func (p *Priority) UnmarshalParam(param string) error {
+	parsed, err := ParsePriority(param)
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
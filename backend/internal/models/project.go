@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	ProjectRoleOwner  = "owner"
+	ProjectRoleMember = "member"
+)
+
+type Project struct {
+	ID          uint            `gorm:"primaryKey" json:"id"`
+	WorkspaceID uint            `gorm:"column:workspace_id;NOT NULL;uniqueIndex:idx_projects_workspace_key,priority:1" json:"workspace_id"`
+	Name        string          `gorm:"column:name;NOT NULL" json:"name"`
+	Key         string          `gorm:"column:key;NOT NULL;uniqueIndex:idx_projects_workspace_key,priority:2" json:"key"`
+	Description string          `gorm:"column:description" json:"description"`
+	OwnerID     uint            `gorm:"column:owner_accounts_id;NOT NULL" json:"owner_accounts_id"`
+	Owner       *Account        `gorm:"foreignKey:OwnerID;constraint:onDelete:RESTRICT,onUpdate:RESTRICT" json:"owner,omitempty"`
+	Members     []ProjectMember `gorm:"foreignKey:ProjectID;constraint:onDelete:CASCADE,onUpdate:RESTRICT" json:"members,omitempty"`
+	IsArchived  bool            `gorm:"column:is_archived;NOT NULL;default:false" json:"is_archived"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+func (p *Project) TableName() string {
+	return "projects"
+}
+
+func (p *Project) GetWorkspaceID() uint {
+	return p.WorkspaceID
+}
+
+type ProjectMember struct {
+	ProjectID uint      `gorm:"column:project_id;primaryKey" json:"project_id"`
+	AccountID uint      `gorm:"column:accounts_id;primaryKey;index" json:"accounts_id"`
+	Account   *Account  `gorm:"foreignKey:AccountID;constraint:onDelete:CASCADE,onUpdate:RESTRICT" json:"account,omitempty"`
+	Role      string    `gorm:"column:role;NOT NULL" json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (m *ProjectMember) TableName() string {
+	return "project_members"
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+)
+
+// Visibility saved view: private hanya untuk pemiliknya, project untuk
+// semua member ProjectID, workspace untuk semua anggota workspace
+const (
+	ViewVisibilityPrivate   = "private"
+	ViewVisibilityProject   = "project"
+	ViewVisibilityWorkspace = "workspace"
+)
+
+// SavedView adalah filter task yang disimpan dengan nama, beserta urutan,
+// kolom yang ditampilkan dan pengelompokan di UI. Hanya pemiliknya yang
+// boleh mengubah atau menghapusnya.
+type SavedView struct {
+	ID          uint             `gorm:"primaryKey" json:"id"`
+	WorkspaceID uint             `gorm:"column:workspace_id;NOT NULL;index" json:"workspace_id"`
+	OwnerID     uint             `gorm:"column:owner_accounts_id;NOT NULL;index" json:"owner_accounts_id"`
+	Owner       *Account         `gorm:"foreignKey:OwnerID;constraint:onDelete:CASCADE,onUpdate:RESTRICT" json:"owner,omitempty"`
+	Name        string           `gorm:"column:name;NOT NULL" json:"name"`
+	Visibility  string           `gorm:"column:visibility;NOT NULL;default:private" json:"visibility"`
+	ProjectID   *uint            `gorm:"column:project_id;index" json:"project_id"`
+	Project     *Project         `gorm:"foreignKey:ProjectID;constraint:onDelete:CASCADE,onUpdate:RESTRICT" json:"-"`
+	Filters     SavedViewFilter  `gorm:"column:filters;type:jsonb;serializer:json" json:"filters"`
+	Sort        string           `gorm:"column:sort" json:"sort"`
+	Order       []SavedViewOrder `gorm:"column:sort_order;type:jsonb;serializer:json" json:"order"`
+	Columns     []string         `gorm:"column:columns;type:jsonb;serializer:json" json:"columns"`
+	GroupBy     string           `gorm:"column:group_by" json:"group_by"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+func (v *SavedView) TableName() string {
+	return "saved_views"
+}
+
+func (v *SavedView) GetWorkspaceID() uint {
+	return v.WorkspaceID
+}
+
+// SavedViewOrder adalah satu kunci urutan terstruktur yang disimpan, dengan
+// arti yang sama seperti TaskOrder di TaskListRequest
+type SavedViewOrder struct {
+	Field     string `json:"field"`
+	Direction string `json:"direction,omitempty"`
+	Nulls     string `json:"nulls,omitempty"`
+}
+
+// SavedViewFilter adalah filter list task yang disimpan, dengan arti yang
+// sama seperti field di TaskListRequest
+type SavedViewFilter struct {
+	Query      string     `json:"query,omitempty"`
+	Search     *string    `json:"search,omitempty"`
+	Status     *string    `json:"status,omitempty"`
+	Priorities []Priority `json:"priorities,omitempty"`
+	ProjectID  *uint      `json:"project_id,omitempty"`
+	ParentID   *uint      `json:"parent_id,omitempty"`
+	AssigneeID *uint      `json:"assignee_id,omitempty"`
+	WatcherID  *uint      `json:"watcher_id,omitempty"`
+	RootOnly   bool       `json:"root_only,omitempty"`
+	Ready      *bool      `json:"ready,omitempty"`
+	Overdue    *bool      `json:"overdue,omitempty"`
+	LabelIDs   []uint     `json:"label_ids,omitempty"`
+	LabelMatch string     `json:"label_match,omitempty"`
+	StartDate  *string    `json:"start_date,omitempty"`
+	EndDate    *string    `json:"end_date,omitempty"`
+}
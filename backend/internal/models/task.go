@@ -4,20 +4,64 @@ import (
 	"time"
 )
 
+const (
+	TaskStatusTodo       = "todo"
+	TaskStatusInProgress = "in_progress"
+	TaskStatusDone       = "done"
+)
+
 type Task struct {
-	ID              uint      `gorm:"primaryKey" json:"id"`
-	CreateAccountID uint      `gorm:"column:create_accounts_id;NOT NULL" json:"create_accounts_id"`
-	CreateUser      *Account  `gorm:"foreignKey:CreateAccountID;constraint:onDelete:RESTRICT,onUpdate:RESTRICT" json:"create_accounts"`
-	UpdateAccountID *uint     `gorm:"column:update_accounts_id" json:"update_accounts_id"`
-	UpdateUser      *Account  `gorm:"foreignKey:UpdateAccountID;constraint:onDelete:RESTRICT,onUpdate:RESTRICT" json:"update_accounts"`
-	AccountID       uint      `gorm:"column:accounts_id;NOT NULL" json:"accounts_id"`
-	Account         *Account  `gorm:"foreignKey:AccountID;constraint:onDelete:RESTRICT,onUpdate:RESTRICT" json:"accounts"`
-	Title           string    `gorm:"column:title" json:"title"`
-	Description     string    `gorm:"column:description" json:"description"`
-	Status          string    `gorm:"column:status" json:"status"`
-	Deadline        time.Time `gorm:"column:deadline" json:"deadline"`
+	ID              uint     `gorm:"primaryKey" json:"id"`
+	WorkspaceID     uint     `gorm:"column:workspace_id;NOT NULL;index" json:"workspace_id"`
+	CreateAccountID uint     `gorm:"column:create_accounts_id;NOT NULL" json:"create_accounts_id"`
+	CreateUser      *Account `gorm:"foreignKey:CreateAccountID;constraint:onDelete:RESTRICT,onUpdate:RESTRICT" json:"create_accounts"`
+	UpdateAccountID *uint    `gorm:"column:update_accounts_id" json:"update_accounts_id"`
+	UpdateUser      *Account `gorm:"foreignKey:UpdateAccountID;constraint:onDelete:RESTRICT,onUpdate:RESTRICT" json:"update_accounts"`
+	// AccountID adalah assignee utama, selalu termasuk di Assignees
+	AccountID      uint            `gorm:"column:accounts_id;NOT NULL" json:"accounts_id"`
+	Account        *Account        `gorm:"foreignKey:AccountID;constraint:onDelete:RESTRICT,onUpdate:RESTRICT" json:"accounts"`
+	Assignees      []Account       `gorm:"many2many:task_assignees;joinForeignKey:TaskID;joinReferences:AccountID;constraint:onDelete:CASCADE,onUpdate:RESTRICT" json:"assignees,omitempty"`
+	Watchers       []Account       `gorm:"many2many:task_watchers;joinForeignKey:TaskID;joinReferences:AccountID;constraint:onDelete:CASCADE,onUpdate:RESTRICT" json:"watchers,omitempty"`
+	ProjectID      *uint           `gorm:"column:project_id;index" json:"project_id"`
+	Project        *Project        `gorm:"foreignKey:ProjectID;constraint:onDelete:RESTRICT,onUpdate:RESTRICT" json:"project,omitempty"`
+	ParentID       *uint           `gorm:"column:parent_id;index" json:"parent_id"`
+	Subtasks       []Task          `gorm:"foreignKey:ParentID;constraint:onDelete:SET NULL,onUpdate:RESTRICT" json:"subtasks,omitempty"`
+	ChecklistItems []ChecklistItem `gorm:"foreignKey:TaskID;constraint:onDelete:CASCADE,onUpdate:RESTRICT" json:"checklist_items,omitempty"`
+	Labels         []Label         `gorm:"many2many:task_labels;constraint:onDelete:CASCADE,onUpdate:RESTRICT" json:"labels,omitempty"`
+	RecurrenceID   *uint           `gorm:"column:recurrence_id;uniqueIndex:idx_tasks_recurrence_occurrence" json:"recurrence_id"`
+	Recurrence     *TaskRecurrence `gorm:"foreignKey:RecurrenceID;constraint:onDelete:SET NULL,onUpdate:RESTRICT" json:"recurrence,omitempty"`
+	OccurrenceAt   *time.Time      `gorm:"column:occurrence_at;uniqueIndex:idx_tasks_recurrence_occurrence" json:"occurrence_at"`
+	Title          string          `gorm:"column:title" json:"title"`
+	Description    string          `gorm:"column:description" json:"description"`
+	Status         string          `gorm:"column:status" json:"status"`
+	// Rank adalah fractional index urutan kartu di kolom status (board),
+	// dibandingkan dengan collation "C"
+	Rank     string    `gorm:"column:rank;type:varchar(255);index" json:"rank"`
+	Priority Priority  `gorm:"column:priority;NOT NULL;index" json:"priority"`
+	Deadline time.Time `gorm:"column:deadline" json:"deadline"`
+	// EstimateMinutes adalah estimasi awal waktu pengerjaan
+	EstimateMinutes *int `gorm:"column:estimate_minutes" json:"estimate_minutes"`
+	// OverdueAt diisi worker reminder saat deadline lewat dan task belum done
+	OverdueAt *time.Time `gorm:"column:overdue_at;index" json:"overdue_at"`
+	// CompletedAt adalah waktu task terakhir kali menjadi done, nil selama
+	// belum done. Diisi repository setiap kali status berubah.
+	CompletedAt *time.Time `gorm:"column:completed_at;index" json:"completed_at"`
+	// Version naik setiap kali kolom task diubah, dipakai sebagai ETag
+	// untuk optimistic concurrency
+	Version uint `gorm:"column:version;NOT NULL;default:1" json:"version"`
+	// default hanya untuk mengisi baris lama saat kolom ditambahkan
+	CreatedAt time.Time `gorm:"column:created_at;NOT NULL;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at;NOT NULL;default:CURRENT_TIMESTAMP" json:"updated_at"`
 }
 
 func (t *Task) TableName() string {
 	return "Tasks"
 }
+
+func (t *Task) GetWorkspaceID() uint {
+	return t.WorkspaceID
+}
+
+func (t *Task) IsDone() bool {
+	return t.Status == TaskStatusDone
+}
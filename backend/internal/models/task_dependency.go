@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+// TaskDependency berarti Task tidak bisa dimulai sebelum BlockedBy selesai
+type TaskDependency struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	TaskID          uint      `gorm:"column:task_id;NOT NULL;uniqueIndex:idx_task_dependencies_pair" json:"task_id"`
+	Task            *Task     `gorm:"foreignKey:TaskID;constraint:onDelete:CASCADE,onUpdate:RESTRICT" json:"task,omitempty"`
+	BlockedByID     uint      `gorm:"column:blocked_by_id;NOT NULL;uniqueIndex:idx_task_dependencies_pair;index" json:"blocked_by_id"`
+	BlockedBy       *Task     `gorm:"foreignKey:BlockedByID;constraint:onDelete:CASCADE,onUpdate:RESTRICT" json:"blocked_by,omitempty"`
+	CreateAccountID uint      `gorm:"column:create_accounts_id;NOT NULL" json:"create_accounts_id"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+func (d *TaskDependency) TableName() string {
+	return "task_dependencies"
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+)
+
+// TaskRecurrence adalah seri task berulang. Setiap occurrence dibuat sebagai
+// Task biasa dengan RecurrenceID dan OccurrenceAt; field Title s/d ProjectID
+// adalah template untuk occurrence berikutnya ("all future occurrences").
+type TaskRecurrence struct {
+	ID               uint                 `gorm:"primaryKey" json:"id"`
+	WorkspaceID      uint                 `gorm:"column:workspace_id;NOT NULL;index" json:"workspace_id"`
+	CreateAccountID  uint                 `gorm:"column:create_accounts_id;NOT NULL" json:"create_accounts_id"`
+	RRule            string               `gorm:"column:rrule;NOT NULL" json:"rrule"`
+	Timezone         string               `gorm:"column:timezone;NOT NULL" json:"timezone"`
+	StartAt          time.Time            `gorm:"column:start_at;NOT NULL" json:"start_at"`
+	Title            string               `gorm:"column:title" json:"title"`
+	Description      string               `gorm:"column:description" json:"description"`
+	Priority         Priority             `gorm:"column:priority;NOT NULL" json:"priority"`
+	AccountID        uint                 `gorm:"column:accounts_id;NOT NULL" json:"accounts_id"`
+	ProjectID        *uint                `gorm:"column:project_id" json:"project_id"`
+	LastOccurrenceAt time.Time            `gorm:"column:last_occurrence_at;NOT NULL" json:"last_occurrence_at"`
+	NextOccurrenceAt *time.Time           `gorm:"column:next_occurrence_at;index" json:"next_occurrence_at"`
+	Skips            []TaskRecurrenceSkip `gorm:"foreignKey:RecurrenceID;constraint:onDelete:CASCADE,onUpdate:RESTRICT" json:"skips,omitempty"`
+	CreatedAt        time.Time            `json:"created_at"`
+	UpdatedAt        time.Time            `json:"updated_at"`
+}
+
+func (r *TaskRecurrence) TableName() string {
+	return "task_recurrences"
+}
+
+func (r *TaskRecurrence) GetWorkspaceID() uint {
+	return r.WorkspaceID
+}
+
+// IsFinished bernilai true jika rule sudah tidak punya occurrence berikutnya
+func (r *TaskRecurrence) IsFinished() bool {
+	return r.NextOccurrenceAt == nil
+}
+
+// TaskRecurrenceSkip mencatat occurrence yang di-skip (EXDATE)
+type TaskRecurrenceSkip struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	RecurrenceID uint      `gorm:"column:recurrence_id;NOT NULL;uniqueIndex:idx_recurrence_skips_occurrence" json:"recurrence_id"`
+	OccurrenceAt time.Time `gorm:"column:occurrence_at;NOT NULL;uniqueIndex:idx_recurrence_skips_occurrence" json:"occurrence_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (s *TaskRecurrenceSkip) TableName() string {
+	return "task_recurrence_skips"
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	ReminderKindDueSoon = "due_soon"
+	ReminderKindOverdue = "overdue"
+)
+
+// TaskReminder mencatat reminder yang sudah terkirim. Unique index-nya
+// menjamin satu reminder per task, penerima, jenis, lead time dan deadline,
+// jadi reminder tidak terkirim ulang setelah restart. Jika deadline diubah,
+// reminder untuk deadline baru akan dikirim lagi.
+type TaskReminder struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	TaskID      uint      `gorm:"column:task_id;NOT NULL;uniqueIndex:idx_task_reminders_once" json:"task_id"`
+	Task        *Task     `gorm:"foreignKey:TaskID;constraint:onDelete:CASCADE,onUpdate:RESTRICT" json:"task,omitempty"`
+	AccountID   uint      `gorm:"column:accounts_id;NOT NULL;uniqueIndex:idx_task_reminders_once" json:"accounts_id"`
+	Kind        string    `gorm:"column:kind;NOT NULL;uniqueIndex:idx_task_reminders_once" json:"kind"`
+	LeadMinutes int       `gorm:"column:lead_minutes;NOT NULL;uniqueIndex:idx_task_reminders_once" json:"lead_minutes"`
+	Deadline    time.Time `gorm:"column:deadline;NOT NULL;uniqueIndex:idx_task_reminders_once" json:"deadline"`
+	Channel     string    `gorm:"column:channel" json:"channel"`
+	SentAt      time.Time `gorm:"column:sent_at;NOT NULL" json:"sent_at"`
+}
+
+func (r *TaskReminder) TableName() string {
+	return "task_reminders"
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+)
+
+// TaskStatusChange mencatat saat task masuk ke Status, termasuk status awal
+// ketika task dibuat. Lama task di suatu status adalah jarak ke perubahan
+// berikutnya (atau sampai sekarang jika belum berubah lagi).
+type TaskStatusChange struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	WorkspaceID uint      `gorm:"column:workspace_id;NOT NULL;index" json:"workspace_id"`
+	TaskID      uint      `gorm:"column:task_id;NOT NULL;index:idx_task_status_changes_task" json:"task_id"`
+	Task        *Task     `gorm:"foreignKey:TaskID;constraint:onDelete:CASCADE,onUpdate:RESTRICT" json:"task,omitempty"`
+	Status      string    `gorm:"column:status;NOT NULL" json:"status"`
+	ChangedAt   time.Time `gorm:"column:changed_at;NOT NULL;index:idx_task_status_changes_task" json:"changed_at"`
+	// AccountID kosong untuk riwayat hasil backfill
+	AccountID *uint    `gorm:"column:accounts_id" json:"accounts_id"`
+	Account   *Account `gorm:"foreignKey:AccountID;constraint:onDelete:SET NULL,onUpdate:RESTRICT" json:"account,omitempty"`
+}
+
+func (c *TaskStatusChange) TableName() string {
+	return "task_status_changes"
+}
+
+func (c *TaskStatusChange) GetWorkspaceID() uint {
+	return c.WorkspaceID
+}
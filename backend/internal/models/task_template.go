@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+)
+
+// TaskTemplate adalah cetakan task yang sering dibuat ulang (onboarding,
+// release, dll). Title dan Description boleh berisi placeholder {{nama}}
+// yang diisi saat task dibuat dari template.
+type TaskTemplate struct {
+	ID              uint     `gorm:"primaryKey" json:"id"`
+	WorkspaceID     uint     `gorm:"column:workspace_id;NOT NULL;uniqueIndex:idx_task_templates_workspace_name,priority:1" json:"workspace_id"`
+	Name            string   `gorm:"column:name;NOT NULL;uniqueIndex:idx_task_templates_workspace_name,priority:2" json:"name"`
+	CreateAccountID uint     `gorm:"column:create_accounts_id;NOT NULL" json:"create_accounts_id"`
+	Title           string   `gorm:"column:title;NOT NULL" json:"title"`
+	Description     string   `gorm:"column:description" json:"description"`
+	Status          string   `gorm:"column:status;NOT NULL" json:"status"`
+	Priority        Priority `gorm:"column:priority;NOT NULL" json:"priority"`
+	// DeadlineOffsetMinutes adalah jarak deadline dari waktu task dibuat,
+	// nil berarti task dibuat tanpa deadline
+	DeadlineOffsetMinutes *int                  `gorm:"column:deadline_offset_minutes" json:"deadline_offset_minutes"`
+	Labels                []Label               `gorm:"many2many:task_template_labels;constraint:onDelete:CASCADE,onUpdate:RESTRICT" json:"labels"`
+	Subtasks              []TaskTemplateSubtask `gorm:"foreignKey:TemplateID;constraint:onDelete:CASCADE,onUpdate:RESTRICT" json:"subtasks"`
+	CreatedAt             time.Time             `json:"created_at"`
+	UpdatedAt             time.Time             `json:"updated_at"`
+}
+
+func (t *TaskTemplate) TableName() string {
+	return "task_templates"
+}
+
+func (t *TaskTemplate) GetWorkspaceID() uint {
+	return t.WorkspaceID
+}
+
+// TaskTemplateSubtask adalah subtask yang ikut dibuat bersama template
+type TaskTemplateSubtask struct {
+	ID                    uint     `gorm:"primaryKey" json:"id"`
+	TemplateID            uint     `gorm:"column:template_id;NOT NULL;index" json:"template_id"`
+	Position              int      `gorm:"column:position;NOT NULL;default:0" json:"position"`
+	Title                 string   `gorm:"column:title;NOT NULL" json:"title"`
+	Description           string   `gorm:"column:description" json:"description"`
+	Priority              Priority `gorm:"column:priority;NOT NULL" json:"priority"`
+	DeadlineOffsetMinutes *int     `gorm:"column:deadline_offset_minutes" json:"deadline_offset_minutes"`
+}
+
+func (s *TaskTemplateSubtask) TableName() string {
+	return "task_template_subtasks"
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+)
+
+// WorkLog adalah waktu kerja seorang akun pada sebuah task, dari timer atau
+// input manual. EndedAt nil berarti timer masih berjalan; partial unique
+// index menjamin paling banyak satu timer berjalan per akun.
+type WorkLog struct {
+	ID              uint       `gorm:"primaryKey" json:"id"`
+	WorkspaceID     uint       `gorm:"column:workspace_id;NOT NULL;index" json:"workspace_id"`
+	TaskID          uint       `gorm:"column:task_id;NOT NULL;index" json:"task_id"`
+	Task            *Task      `gorm:"foreignKey:TaskID;constraint:onDelete:CASCADE,onUpdate:RESTRICT" json:"task,omitempty"`
+	AccountID       uint       `gorm:"column:accounts_id;NOT NULL;index;uniqueIndex:idx_work_logs_running,where:ended_at IS NULL" json:"accounts_id"`
+	Account         *Account   `gorm:"foreignKey:AccountID;constraint:onDelete:CASCADE,onUpdate:RESTRICT" json:"account,omitempty"`
+	StartedAt       time.Time  `gorm:"column:started_at;NOT NULL;index" json:"started_at"`
+	EndedAt         *time.Time `gorm:"column:ended_at" json:"ended_at"`
+	DurationSeconds int64      `gorm:"column:duration_seconds;NOT NULL;default:0" json:"duration_seconds"`
+	Note            string     `gorm:"column:note" json:"note"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+func (w *WorkLog) TableName() string {
+	return "work_logs"
+}
+
+func (w *WorkLog) GetWorkspaceID() uint {
+	return w.WorkspaceID
+}
+
+func (w *WorkLog) IsRunning() bool {
+	return w.EndedAt == nil
+}
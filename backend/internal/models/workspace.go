@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	WorkspaceRoleOwner  = "owner"
+	WorkspaceRoleAdmin  = "admin"
+	WorkspaceRoleMember = "member"
+)
+
+// WorkspaceScoped menandai model yang datanya milik tepat satu workspace.
+// Query terhadap model ini otomatis dibatasi ke workspace aktif oleh repository.
+type WorkspaceScoped interface {
+	GetWorkspaceID() uint
+}
+
+type Workspace struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Name      string    `gorm:"column:name;NOT NULL" json:"name"`
+	Slug      string    `gorm:"column:slug;NOT NULL;uniqueIndex" json:"slug"`
+	OwnerID   uint      `gorm:"column:owner_accounts_id;NOT NULL" json:"owner_accounts_id"`
+	Owner     *Account  `gorm:"foreignKey:OwnerID;constraint:onDelete:RESTRICT,onUpdate:RESTRICT" json:"owner,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (w *Workspace) TableName() string {
+	return "workspaces"
+}
+
+type WorkspaceMember struct {
+	WorkspaceID uint       `gorm:"column:workspace_id;primaryKey" json:"workspace_id"`
+	Workspace   *Workspace `gorm:"foreignKey:WorkspaceID;constraint:onDelete:CASCADE,onUpdate:RESTRICT" json:"workspace,omitempty"`
+	AccountID   uint       `gorm:"column:accounts_id;primaryKey;index" json:"accounts_id"`
+	Account     *Account   `gorm:"foreignKey:AccountID;constraint:onDelete:CASCADE,onUpdate:RESTRICT" json:"account,omitempty"`
+	Role        string     `gorm:"column:role;NOT NULL" json:"role"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+func (m *WorkspaceMember) TableName() string {
+	return "workspace_members"
+}
+
+func (m *WorkspaceMember) CanManage() bool {
+	return m.Role == WorkspaceRoleOwner || m.Role == WorkspaceRoleAdmin
+}
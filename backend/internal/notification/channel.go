@@ -0,0 +1,92 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+)
+
+// Notification adalah pesan untuk satu penerima
+type Notification struct {
+	Kind      string    `json:"kind"`
+	AccountID uint      `json:"account_id"`
+	Email     string    `json:"email"`
+	TaskID    uint      `json:"task_id"`
+	TaskTitle string    `json:"task_title"`
+	Deadline  time.Time `json:"deadline"`
+	Message   string    `json:"message"`
+}
+
+// Channel mengirim notifikasi ke penerima (email, chat, webhook, dll).
+// Send harus mengembalikan error jika pengiriman gagal supaya bisa diulang.
+type Channel interface {
+	Name() string
+	Send(ctx context.Context, notification Notification) error
+}
+
+// NewChannel memilih channel dari environment: webhook jika
+// NOTIFICATION_WEBHOOK_URL diisi, selain itu hanya ditulis ke log
+func NewChannel() Channel {
+	if url := os.Getenv("NOTIFICATION_WEBHOOK_URL"); url != "" {
+		return NewWebhookChannel(url)
+	}
+	return LogChannel{}
+}
+
+// LogChannel menulis notifikasi ke log aplikasi, berguna untuk development
+type LogChannel struct{}
+
+func (LogChannel) Name() string {
+	return "log"
+}
+
+func (LogChannel) Send(ctx context.Context, notification Notification) error {
+	log.Printf("[notification] to=%s task=%d %s", notification.Email, notification.TaskID, notification.Message)
+	return nil
+}
+
+// WebhookChannel mengirim notifikasi sebagai JSON POST ke URL tertentu
+type WebhookChannel struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookChannel(url string) *WebhookChannel {
+	return &WebhookChannel{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (c *WebhookChannel) Name() string {
+	return "webhook"
+}
+
+func (c *WebhookChannel) Send(ctx context.Context, notification Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
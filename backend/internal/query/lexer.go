@@ -0,0 +1,117 @@
+package query
+
+import (
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOp
+	tokenMinus
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	span  Span
+}
+
+// lex memecah input menjadi token. "-" hanya menjadi negasi di awal term,
+// jadi nilai seperti 2024-05-01 atau due<-3d tetap utuh.
+func lex(input string) ([]token, error) {
+	runes := []rune(input)
+	var tokens []token
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		start := i
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, value: "(", span: Span{start, i + 1}})
+			i++
+
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, value: ")", span: Span{start, i + 1}})
+			i++
+
+		case r == '"':
+			value, end, ok := lexString(runes, i)
+			if !ok {
+				return nil, Errorf(Span{start, len(runes)}, "unterminated quoted string")
+			}
+			tokens = append(tokens, token{kind: tokenString, value: value, span: Span{start, end}})
+			i = end
+
+		case isOpStart(runes, i):
+			op := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				op += "="
+			}
+			i += len([]rune(op))
+			tokens = append(tokens, token{kind: tokenOp, value: op, span: Span{start, i}})
+
+		case r == '-' && !afterOp(tokens):
+			tokens = append(tokens, token{kind: tokenMinus, value: "-", span: Span{start, i + 1}})
+			i++
+
+		default:
+			for i < len(runes) && isWordRune(runes, i) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenWord, value: string(runes[start:i]), span: Span{start, i}})
+		}
+	}
+
+	tokens = append(tokens, token{kind: tokenEOF, span: Span{len(runes), len(runes)}})
+	return tokens, nil
+}
+
+// lexString membaca string bertanda kutip mulai dari runes[start]; \" dan
+// \\ di dalamnya di-escape
+func lexString(runes []rune, start int) (string, int, bool) {
+	var value strings.Builder
+	for i := start + 1; i < len(runes); i++ {
+		switch {
+		case runes[i] == '\\' && i+1 < len(runes):
+			i++
+			value.WriteRune(runes[i])
+		case runes[i] == '"':
+			return value.String(), i + 1, true
+		default:
+			value.WriteRune(runes[i])
+		}
+	}
+	return "", 0, false
+}
+
+// isOpStart true untuk ":", "=", "<", ">" dan "!=" ("!" sendirian adalah
+// bagian dari kata)
+func isOpStart(runes []rune, i int) bool {
+	switch runes[i] {
+	case ':', '=', '<', '>':
+		return true
+	case '!':
+		return i+1 < len(runes) && runes[i+1] == '='
+	}
+	return false
+}
+
+func isWordRune(runes []rune, i int) bool {
+	r := runes[i]
+	return !unicode.IsSpace(r) && r != '(' && r != ')' && r != '"' && !isOpStart(runes, i)
+}
+
+func afterOp(tokens []token) bool {
+	return len(tokens) > 0 && tokens[len(tokens)-1].kind == tokenOp
+}
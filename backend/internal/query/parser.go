@@ -0,0 +1,200 @@
+package query
+
+import "unicode/utf8"
+
+const (
+	// MaxLength adalah panjang input maksimal dalam karakter
+	MaxLength = 1024
+	// MaxDepth membatasi kurung dan negasi bersarang, karena parser
+	// rekursif dan stack overflow tidak bisa di-recover
+	MaxDepth = 32
+)
+
+// Grammar:
+//
+//	query   = or
+//	or      = and { "OR" and }
+//	and     = unary { ["AND"] unary }
+//	unary   = "-" unary | primary
+//	primary = "(" or ")" | word op value | word | string
+//	value   = word | string
+type parser struct {
+	tokens []token
+	pos    int
+	depth  int
+}
+
+// Parse mengubah input menjadi AST; input kosong menghasilkan nil, nil.
+// Error yang dikembalikan selalu bertipe *Error.
+func Parse(input string) (Node, error) {
+	if length := utf8.RuneCountInString(input); length > MaxLength {
+		return nil, Errorf(Span{Start: MaxLength, End: length}, "query is longer than %d characters", MaxLength)
+	}
+
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+	if tokens[0].kind == tokenEOF {
+		return nil, nil
+	}
+
+	p := &parser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if next := p.peek(); next.kind != tokenEOF {
+		return nil, Errorf(next.span, "unexpected %q", next.value)
+	}
+	return node, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// enter menambah kedalaman rekursi di tok; pemanggil wajib memanggil
+// p.depth-- setelah selesai
+func (p *parser) enter(tok token) error {
+	p.depth++
+	if p.depth > MaxDepth {
+		return Errorf(tok.span, "query is nested more than %d levels deep", MaxDepth)
+	}
+	return nil
+}
+
+func (p *parser) atKeyword(keyword string) bool {
+	tok := p.peek()
+	return tok.kind == tokenWord && tok.value == keyword
+}
+
+func (p *parser) parseOr() (Node, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := []Node{first}
+	for p.atKeyword("OR") {
+		p.next()
+		node, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+
+	if len(nodes) == 1 {
+		return first, nil
+	}
+	return &Or{Nodes: nodes}, nil
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	var nodes []Node
+	for {
+		if p.atKeyword("AND") && len(nodes) > 0 {
+			p.next()
+		} else if tok := p.peek(); tok.kind == tokenEOF || tok.kind == tokenRParen || p.atKeyword("OR") {
+			break
+		}
+
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+
+	if len(nodes) == 0 {
+		tok := p.peek()
+		if tok.kind == tokenEOF {
+			return nil, Errorf(tok.span, "expected a term at end of query")
+		}
+		return nil, Errorf(tok.span, "expected a term before %q", tok.value)
+	}
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+	return &And{Nodes: nodes}, nil
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	if p.peek().kind != tokenMinus {
+		return p.parsePrimary()
+	}
+
+	minus := p.next()
+	if tok := p.peek(); tok.kind == tokenEOF || tok.kind == tokenRParen {
+		return nil, Errorf(minus.span, "expected a term after \"-\"")
+	}
+	if err := p.enter(minus); err != nil {
+		return nil, err
+	}
+	node, err := p.parseUnary()
+	p.depth--
+	if err != nil {
+		return nil, err
+	}
+	return &Not{Node: node, Span: Span{Start: minus.span.Start, End: node.Pos().End}}, nil
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	tok := p.next()
+
+	switch tok.kind {
+	case tokenLParen:
+		if err := p.enter(tok); err != nil {
+			return nil, err
+		}
+		node, err := p.parseOr()
+		p.depth--
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokenRParen {
+			return nil, Errorf(tok.span, "missing closing parenthesis")
+		}
+		p.next()
+		return node, nil
+
+	case tokenString:
+		return &Text{Value: tok.value, Phrase: true, Span: tok.span}, nil
+
+	case tokenWord:
+		if p.peek().kind != tokenOp {
+			return &Text{Value: tok.value, Span: tok.span}, nil
+		}
+		op := p.next()
+		value := p.next()
+		if value.kind != tokenWord && value.kind != tokenString {
+			return nil, Errorf(Span{Start: op.span.Start, End: value.span.End}, "expected a value after %q", op.value)
+		}
+		return &Term{
+			Field:     tok.value,
+			FieldSpan: tok.span,
+			Op:        Op(op.value),
+			OpSpan:    op.span,
+			Value:     value.value,
+			ValueSpan: value.span,
+		}, nil
+
+	case tokenOp:
+		return nil, Errorf(tok.span, "expected a field name before %q", tok.value)
+
+	case tokenEOF:
+		return nil, Errorf(tok.span, "unexpected end of query")
+
+	default:
+		return nil, Errorf(tok.span, "unexpected %q", tok.value)
+	}
+}
@@ -0,0 +1,105 @@
+// Package query mem-parse bahasa query task untuk power user, misalnya
+//
+//	status:todo assignee:me due<7d -label:blocked "release notes"
+//
+// menjadi AST. Term dipisah spasi berarti AND, OR dan kurung untuk
+// alternatif, "-" di depan term untuk negasi, dan teks tanpa field menjadi
+// pencarian bebas. Package ini hanya memeriksa sintaks; field dan nilai
+// divalidasi oleh pemakai AST.
+package query
+
+import "fmt"
+
+// Op adalah operator perbandingan pada term field
+type Op string
+
+const (
+	OpMatch        Op = ":"
+	OpEqual        Op = "="
+	OpNotEqual     Op = "!="
+	OpLess         Op = "<"
+	OpLessEqual    Op = "<="
+	OpGreater      Op = ">"
+	OpGreaterEqual Op = ">="
+)
+
+// Span adalah posisi di input dalam hitungan karakter (rune), End eksklusif
+type Span struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// Node adalah satu simpul AST
+type Node interface {
+	Pos() Span
+}
+
+// Term adalah perbandingan field dengan nilai, misalnya due<7d
+type Term struct {
+	Field     string
+	FieldSpan Span
+	Op        Op
+	OpSpan    Span
+	Value     string
+	ValueSpan Span
+}
+
+func (t *Term) Pos() Span {
+	return Span{Start: t.FieldSpan.Start, End: t.ValueSpan.End}
+}
+
+// Text adalah teks bebas; Phrase true jika ditulis dalam tanda kutip
+type Text struct {
+	Value  string
+	Phrase bool
+	Span   Span
+}
+
+func (t *Text) Pos() Span {
+	return t.Span
+}
+
+type Not struct {
+	Node Node
+	Span Span
+}
+
+func (n *Not) Pos() Span {
+	return n.Span
+}
+
+type And struct {
+	Nodes []Node
+}
+
+func (a *And) Pos() Span {
+	return spanOf(a.Nodes)
+}
+
+type Or struct {
+	Nodes []Node
+}
+
+func (o *Or) Pos() Span {
+	return spanOf(o.Nodes)
+}
+
+func spanOf(nodes []Node) Span {
+	return Span{Start: nodes[0].Pos().Start, End: nodes[len(nodes)-1].Pos().End}
+}
+
+// Error adalah kesalahan sintaks atau validasi beserta posisinya di input,
+// supaya frontend bisa menandai bagian query yang salah
+type Error struct {
+	Message string `json:"message"`
+	Span
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s at %d-%d", e.Message, e.Start, e.End)
+}
+
+// Errorf membuat Error untuk bagian input di span
+func Errorf(span Span, format string, args ...interface{}) *Error {
+	return &Error{Message: fmt.Sprintf(format, args...), Span: span}
+}
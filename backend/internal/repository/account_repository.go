@@ -30,12 +30,12 @@ func NewAccountRepository(db *gorm.DB) AccountRepository {
 }
 
 func (r *accountRepository) Create(ctx context.Context, account *models.Account) error {
-	return r.db.WithContext(ctx).Create(account).Error
+	return r.conn(ctx).Create(account).Error
 }
 
 func (r *accountRepository) GetByCode(ctx context.Context, code string) (*models.Account, error) {
 	var account models.Account
-	err := r.db.WithContext(ctx).Where("code = ?", code).First(&account).Error
+	err := r.conn(ctx).Where("code = ?", code).First(&account).Error
 
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil // tidak ditemukan → return nil, nil
@@ -50,14 +50,14 @@ func (r *accountRepository) GetByCode(ctx context.Context, code string) (*models
 
 func (r *accountRepository) GetByID(ctx context.Context, id uint) (*models.Account, error) {
 	var account models.Account
-	err := r.db.WithContext(ctx).First(&account, id).Error
+	err := r.conn(ctx).First(&account, id).Error
 	return &account, err
 }
 
 // Get account by email
 func (r *accountRepository) GetByEmail(ctx context.Context, email string) (*models.Account, error) {
 	var account models.Account
-	err := r.db.WithContext(ctx).Where("email = ?", email).First(&account).Error
+	err := r.conn(ctx).Where("email = ?", email).First(&account).Error
 
 	//EXPLICITLY HANDLE "record not found"
 	if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -75,12 +75,12 @@ func (r *accountRepository) GetAccounts(ctx context.Context, limit, offset int)
 	var accounts []models.Account
 	var total int64
 
-	err := r.db.WithContext(ctx).Model(&models.Account{}).Count(&total).Error
+	err := r.conn(ctx).Model(&models.Account{}).Count(&total).Error
 	if err != nil {
 		return nil, 0, err
 	}
 
-	err = r.db.WithContext(ctx).
+	err = r.conn(ctx).
 		Limit(limit).
 		Offset(offset).
 		Order("created_at DESC").
@@ -90,7 +90,7 @@ func (r *accountRepository) GetAccounts(ctx context.Context, limit, offset int)
 }
 
 func (r *accountRepository) UpdateBalance(ctx context.Context, accountID uint, amount int64) error {
-	return r.db.WithContext(ctx).
+	return r.conn(ctx).
 		Model(&models.Account{}).
 		Where("id = ?", accountID).
 		Update("balance", gorm.Expr("balance + ?", amount)).
@@ -99,12 +99,12 @@ func (r *accountRepository) UpdateBalance(ctx context.Context, accountID uint, a
 
 // NEW: Update account
 func (r *accountRepository) Update(ctx context.Context, account *models.Account) error {
-	return r.db.WithContext(ctx).Save(account).Error
+	return r.conn(ctx).Save(account).Error
 }
 
 // NEW: Update last login
 func (r *accountRepository) UpdateLastLogin(ctx context.Context, accountID uint) error {
-	return r.db.WithContext(ctx).
+	return r.conn(ctx).
 		Model(&models.Account{}).
 		Where("id = ?", accountID).
 		Update("last_login", "NOW()").
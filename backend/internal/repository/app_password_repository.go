@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AppPasswordRepository interface {
+	Create(ctx context.Context, password *models.AppPassword) error
+	GetByAccount(ctx context.Context, accountID uint) ([]models.AppPassword, error)
+	GetByHash(ctx context.Context, hash string) (*models.AppPassword, error)
+	Touch(ctx context.Context, id uint, at time.Time) error
+	Delete(ctx context.Context, id, accountID uint) (bool, error)
+}
+
+type appPasswordRepository struct {
+	*BaseRepository
+}
+
+func NewAppPasswordRepository(db *gorm.DB) AppPasswordRepository {
+	return &appPasswordRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+func (r *appPasswordRepository) Create(ctx context.Context, password *models.AppPassword) error {
+	return r.conn(ctx).Create(password).Error
+}
+
+func (r *appPasswordRepository) GetByAccount(ctx context.Context, accountID uint) ([]models.AppPassword, error) {
+	var passwords []models.AppPassword
+	err := r.conn(ctx).
+		Where("accounts_id = ?", accountID).
+		Order("created_at desc").
+		Find(&passwords).Error
+	return passwords, err
+}
+
+// GetByHash dipanggil sebelum workspace diketahui, jadi tidak dibatasi
+// workspace; Account ikut dimuat untuk dicocokkan dengan username
+func (r *appPasswordRepository) GetByHash(ctx context.Context, hash string) (*models.AppPassword, error) {
+	var password models.AppPassword
+	err := r.conn(ctx).
+		Preload("Account").
+		Where("password_hash = ?", hash).
+		First(&password).Error
+	if err != nil {
+		return nil, err
+	}
+	return &password, nil
+}
+
+func (r *appPasswordRepository) Touch(ctx context.Context, id uint, at time.Time) error {
+	return r.conn(ctx).
+		Model(&models.AppPassword{}).
+		Where("id = ?", id).
+		Update("last_used_at", at).Error
+}
+
+// Delete mengembalikan false jika password tidak ada atau milik akun lain
+func (r *appPasswordRepository) Delete(ctx context.Context, id, accountID uint) (bool, error) {
+	result := r.conn(ctx).
+		Where("id = ? AND accounts_id = ?", id, accountID).
+		Delete(&models.AppPassword{})
+	return result.RowsAffected > 0, result.Error
+}
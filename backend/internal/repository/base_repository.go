@@ -34,18 +34,39 @@ func (r *BaseRepository) Rollback() error {
 	return r.db.Rollback().Error
 }
 
+// txKey menyimpan transaksi aktif di context, lihat Transaction
+type txKey struct{}
+
+// Transaction menjalankan fn dalam satu transaksi database. Semua repository
+// yang dipanggil dengan ctx dari fn memakai transaksi yang sama, jadi operasi
+// dari beberapa repository bisa di-commit atau di-rollback bersama.
+// Transaction di dalam transaksi lain menjadi savepoint.
+func (r *BaseRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// conn mengembalikan transaksi aktif di ctx, atau koneksi biasa jika tidak ada
+func (r *BaseRepository) conn(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
 func (r *BaseRepository) Create(ctx context.Context, model interface{}) error {
-	return r.db.WithContext(ctx).Create(model).Error
+	return r.conn(ctx).Create(model).Error
 }
 
 func (r *BaseRepository) FindByID(ctx context.Context, model interface{}, id uint) error {
-	return r.db.WithContext(ctx).First(model, id).Error
+	return r.conn(ctx).First(model, id).Error
 }
 
 func (r *BaseRepository) Update(ctx context.Context, model interface{}) error {
-	return r.db.WithContext(ctx).Save(model).Error
+	return r.conn(ctx).Save(model).Error
 }
 
 func (r *BaseRepository) Delete(ctx context.Context, model interface{}) error {
-	return r.db.WithContext(ctx).Delete(model).Error
+	return r.conn(ctx).Delete(model).Error
 }
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type CalDAVRepository interface {
+	GetTasks(ctx context.Context, projectID *uint) ([]models.Task, error)
+	GetState(ctx context.Context, projectID *uint) (int64, time.Time, error)
+	GetObjects(ctx context.Context, taskIDs []uint) (map[uint]models.CalDAVObject, error)
+	GetObjectByName(ctx context.Context, name string) (*models.CalDAVObject, error)
+	GetObjectByUID(ctx context.Context, uid string) (*models.CalDAVObject, error)
+	CreateObject(ctx context.Context, object *models.CalDAVObject) error
+}
+
+type caldavRepository struct {
+	*BaseRepository
+}
+
+func NewCalDAVRepository(db *gorm.DB) CalDAVRepository {
+	return &caldavRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// inCollection membatasi task ke satu collection: project tertentu, atau
+// task tanpa project jika projectID nil
+func inCollection(projectID *uint) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if projectID == nil {
+			return db.Where(`"Tasks".project_id IS NULL`)
+		}
+		return db.Where(`"Tasks".project_id = ?`, *projectID)
+	}
+}
+
+func (r *caldavRepository) GetTasks(ctx context.Context, projectID *uint) ([]models.Task, error) {
+	var tasks []models.Task
+	err := r.conn(ctx).
+		Scopes(visibleTasks(ctx), inCollection(projectID)).
+		Preload("Labels", func(db *gorm.DB) *gorm.DB {
+			return db.Order("labels.name asc")
+		}).
+		Order("id asc").
+		Find(&tasks).Error
+	return tasks, err
+}
+
+// GetState mengembalikan jumlah task dan waktu perubahan terakhir di
+// collection, dipakai sebagai CTag
+func (r *caldavRepository) GetState(ctx context.Context, projectID *uint) (int64, time.Time, error) {
+	var state struct {
+		Count        int64
+		LastModified *time.Time
+	}
+	err := r.conn(ctx).
+		Model(&models.Task{}).
+		Select("COUNT(*) AS count, MAX(updated_at) AS last_modified").
+		Scopes(visibleTasks(ctx), inCollection(projectID)).
+		Scan(&state).Error
+	if err != nil || state.LastModified == nil {
+		return state.Count, time.Time{}, err
+	}
+	return state.Count, *state.LastModified, nil
+}
+
+func (r *caldavRepository) GetObjects(ctx context.Context, taskIDs []uint) (map[uint]models.CalDAVObject, error) {
+	objects := make(map[uint]models.CalDAVObject, len(taskIDs))
+	if len(taskIDs) == 0 {
+		return objects, nil
+	}
+
+	var rows []models.CalDAVObject
+	if err := r.conn(ctx).Where("task_id IN ?", taskIDs).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		objects[row.TaskID] = row
+	}
+	return objects, nil
+}
+
+// GetObjectByName mengembalikan nil, nil jika nama tidak terdaftar
+func (r *caldavRepository) GetObjectByName(ctx context.Context, name string) (*models.CalDAVObject, error) {
+	return r.getObject(ctx, "name = ?", name)
+}
+
+// GetObjectByUID mengembalikan nil, nil jika UID tidak terdaftar
+func (r *caldavRepository) GetObjectByUID(ctx context.Context, uid string) (*models.CalDAVObject, error) {
+	return r.getObject(ctx, "uid = ?", uid)
+}
+
+func (r *caldavRepository) getObject(ctx context.Context, query string, value string) (*models.CalDAVObject, error) {
+	var object models.CalDAVObject
+	err := r.conn(ctx).Where(query, value).First(&object).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &object, nil
+}
+
+func (r *caldavRepository) CreateObject(ctx context.Context, object *models.CalDAVObject) error {
+	return r.conn(ctx).Create(object).Error
+}
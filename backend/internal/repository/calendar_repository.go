@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type CalendarRepository interface {
+	// Replace menghapus token lama akun (jika ada) lalu menyimpan token baru
+	Replace(ctx context.Context, token *models.CalendarToken) error
+	GetByAccount(ctx context.Context, accountID uint) (*models.CalendarToken, error)
+	GetByHash(ctx context.Context, hash string) (*models.CalendarToken, error)
+	Touch(ctx context.Context, id uint, at time.Time) error
+	DeleteByAccount(ctx context.Context, accountID uint) error
+}
+
+type calendarRepository struct {
+	*BaseRepository
+}
+
+func NewCalendarRepository(db *gorm.DB) CalendarRepository {
+	return &calendarRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+func (r *calendarRepository) Replace(ctx context.Context, token *models.CalendarToken) error {
+	return r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("accounts_id = ?", token.AccountID).Delete(&models.CalendarToken{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(token).Error
+	})
+}
+
+// GetByAccount mengembalikan nil, nil jika akun belum punya token
+func (r *calendarRepository) GetByAccount(ctx context.Context, accountID uint) (*models.CalendarToken, error) {
+	var token models.CalendarToken
+	err := r.conn(ctx).Where("accounts_id = ?", accountID).First(&token).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// GetByHash dipanggil tanpa workspace di context, karena workspace baru
+// diketahui dari token
+func (r *calendarRepository) GetByHash(ctx context.Context, hash string) (*models.CalendarToken, error) {
+	var token models.CalendarToken
+	err := r.conn(ctx).Where("token_hash = ?", hash).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *calendarRepository) Touch(ctx context.Context, id uint, at time.Time) error {
+	return r.conn(ctx).
+		Model(&models.CalendarToken{}).
+		Where("id = ?", id).
+		Update("last_used_at", at).Error
+}
+
+func (r *calendarRepository) DeleteByAccount(ctx context.Context, accountID uint) error {
+	return r.conn(ctx).Where("accounts_id = ?", accountID).Delete(&models.CalendarToken{}).Error
+}
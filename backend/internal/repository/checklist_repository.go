@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type ChecklistRepository interface {
+	Create(ctx context.Context, item *models.ChecklistItem) error
+	GetByID(ctx context.Context, taskID, id uint) (*models.ChecklistItem, error)
+	GetByTaskID(ctx context.Context, taskID uint) ([]models.ChecklistItem, error)
+	NextPosition(ctx context.Context, taskID uint) (int, error)
+	Update(ctx context.Context, item *models.ChecklistItem) error
+	Delete(ctx context.Context, taskID, id uint) error
+}
+
+type checklistRepository struct {
+	*BaseRepository
+}
+
+func NewChecklistRepository(db *gorm.DB) ChecklistRepository {
+	return &checklistRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+func (r *checklistRepository) Create(ctx context.Context, item *models.ChecklistItem) error {
+	return r.conn(ctx).Create(item).Error
+}
+
+func (r *checklistRepository) GetByID(ctx context.Context, taskID, id uint) (*models.ChecklistItem, error) {
+	var item models.ChecklistItem
+	err := r.conn(ctx).
+		Where("task_id = ?", taskID).
+		First(&item, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *checklistRepository) GetByTaskID(ctx context.Context, taskID uint) ([]models.ChecklistItem, error) {
+	var items []models.ChecklistItem
+	err := r.conn(ctx).
+		Where("task_id = ?", taskID).
+		Order("position asc, id asc").
+		Find(&items).Error
+	return items, err
+}
+
+func (r *checklistRepository) NextPosition(ctx context.Context, taskID uint) (int, error) {
+	var position int
+	err := r.conn(ctx).
+		Model(&models.ChecklistItem{}).
+		Select("COALESCE(MAX(position), -1) + 1").
+		Where("task_id = ?", taskID).
+		Scan(&position).Error
+	return position, err
+}
+
+func (r *checklistRepository) Update(ctx context.Context, item *models.ChecklistItem) error {
+	return r.conn(ctx).Save(item).Error
+}
+
+func (r *checklistRepository) Delete(ctx context.Context, taskID, id uint) error {
+	return r.conn(ctx).
+		Where("task_id = ?", taskID).
+		Delete(&models.ChecklistItem{}, id).Error
+}
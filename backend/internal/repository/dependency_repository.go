@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// dependencyLockKey adalah key advisory lock postgres (bersama workspace id)
+// untuk menserialisasi perubahan graph dependency dalam satu workspace,
+// supaya dua request paralel tidak bisa masing-masing lolos cek siklus lalu
+// bersama-sama membentuk siklus. Dependency tidak pernah melintasi workspace.
+const dependencyLockKey int32 = 270001
+
+var (
+	ErrDependencyCycle  = errors.New("dependency would create a cycle")
+	ErrDependencyExists = errors.New("dependency already exists")
+)
+
+type DependencyRepository interface {
+	Add(ctx context.Context, workspaceID uint, dependency *models.TaskDependency) error
+	Remove(ctx context.Context, taskID, blockedByID uint) error
+	GetByTaskIDs(ctx context.Context, ids []uint) ([]models.TaskDependency, error)
+	CountOpenBlockers(ctx context.Context, taskID uint) (int64, error)
+}
+
+type dependencyRepository struct {
+	*BaseRepository
+}
+
+func NewDependencyRepository(db *gorm.DB) DependencyRepository {
+	return &dependencyRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// Add menyimpan dependency setelah memastikan tidak terbentuk siklus. Cek
+// siklus dan insert berjalan dalam satu transaksi yang memegang advisory
+// lock workspace kedua task.
+func (r *dependencyRepository) Add(ctx context.Context, workspaceID uint, dependency *models.TaskDependency) error {
+	return r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", dependencyLockKey, int32(workspaceID)).Error; err != nil {
+			return err
+		}
+
+		var existing int64
+		err := tx.Model(&models.TaskDependency{}).
+			Where("task_id = ? AND blocked_by_id = ?", dependency.TaskID, dependency.BlockedByID).
+			Count(&existing).Error
+		if err != nil {
+			return err
+		}
+		if existing > 0 {
+			return ErrDependencyExists
+		}
+
+		// siklus terjadi jika blocker (langsung maupun tidak) sudah menunggu task ini
+		var count int64
+		err = tx.Raw(`
+			WITH RECURSIVE blockers AS (
+				SELECT blocked_by_id FROM task_dependencies WHERE task_id = ?
+				UNION
+				SELECT d.blocked_by_id FROM task_dependencies d JOIN blockers b ON d.task_id = b.blocked_by_id
+			)
+			SELECT COUNT(*) FROM blockers WHERE blocked_by_id = ?`, dependency.BlockedByID, dependency.TaskID).
+			Scan(&count).Error
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrDependencyCycle
+		}
+
+		return tx.Create(dependency).Error
+	})
+}
+
+func (r *dependencyRepository) Remove(ctx context.Context, taskID, blockedByID uint) error {
+	result := r.conn(ctx).
+		Where("task_id = ? AND blocked_by_id = ?", taskID, blockedByID).
+		Delete(&models.TaskDependency{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetByTaskIDs mengambil semua dependency di mana task dalam ids menjadi
+// task yang diblokir maupun blocker-nya. Task dan BlockedBy hanya dimuat
+// jika terlihat oleh akun di context, selain itu nil.
+func (r *dependencyRepository) GetByTaskIDs(ctx context.Context, ids []uint) ([]models.TaskDependency, error) {
+	var dependencies []models.TaskDependency
+	if len(ids) == 0 {
+		return dependencies, nil
+	}
+
+	visible := func(db *gorm.DB) *gorm.DB {
+		return db.Scopes(visibleTasks(ctx))
+	}
+	err := r.conn(ctx).
+		Preload("Task", visible).
+		Preload("BlockedBy", visible).
+		Where("task_id IN ? OR blocked_by_id IN ?", ids, ids).
+		Order("id asc").
+		Find(&dependencies).Error
+	return dependencies, err
+}
+
+func (r *dependencyRepository) CountOpenBlockers(ctx context.Context, taskID uint) (int64, error) {
+	var count int64
+	err := r.conn(ctx).
+		Model(&models.TaskDependency{}).
+		Joins(`JOIN "Tasks" blocker ON blocker.id = task_dependencies.blocked_by_id`).
+		Where("task_dependencies.task_id = ? AND blocker.status IS DISTINCT FROM ?", taskID, models.TaskStatusDone).
+		Count(&count).Error
+	return count, err
+}
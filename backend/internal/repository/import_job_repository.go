@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// importJobStaleAfter adalah batas waktu job running tanpa progress (progress
+// disimpan setiap beberapa baris) sebelum dianggap terhenti, misalnya karena
+// proses di-restart di tengah import
+const importJobStaleAfter = 10 * time.Minute
+
+type ImportJobRepository interface {
+	Create(ctx context.Context, job *models.ImportJob) error
+	GetByID(ctx context.Context, id uint) (*models.ImportJob, error)
+	GetByAccount(ctx context.Context, accountID uint, limit int) ([]models.ImportJob, error)
+	ClaimNext(ctx context.Context) (*models.ImportJob, error)
+	UpdateProgress(ctx context.Context, job *models.ImportJob) error
+}
+
+type importJobRepository struct {
+	*BaseRepository
+}
+
+func NewImportJobRepository(db *gorm.DB) ImportJobRepository {
+	return &importJobRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+func (r *importJobRepository) Create(ctx context.Context, job *models.ImportJob) error {
+	return r.conn(ctx).Create(job).Error
+}
+
+// GetByID tidak memuat kolom tasks karena hanya dibutuhkan worker
+func (r *importJobRepository) GetByID(ctx context.Context, id uint) (*models.ImportJob, error) {
+	var job models.ImportJob
+	err := r.conn(ctx).Omit("tasks").First(&job, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *importJobRepository) GetByAccount(ctx context.Context, accountID uint, limit int) ([]models.ImportJob, error) {
+	var jobs []models.ImportJob
+	err := r.conn(ctx).
+		Omit("tasks").
+		Where("create_accounts_id = ?", accountID).
+		Order("created_at desc").
+		Limit(limit).
+		Find(&jobs).Error
+	return jobs, err
+}
+
+// ClaimNext mengambil job pending tertua dan menandainya running. Baris
+// dikunci dengan SKIP LOCKED supaya beberapa instance worker tidak
+// mengambil job yang sama. nil, nil jika tidak ada job pending. Job running
+// yang terhenti ditandai failed lebih dulu.
+func (r *importJobRepository) ClaimNext(ctx context.Context) (*models.ImportJob, error) {
+	if err := r.failStale(ctx); err != nil {
+		return nil, err
+	}
+
+	var job models.ImportJob
+	err := r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ?", models.ImportJobPending).
+			Order("id asc").
+			First(&job).Error
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		job.Status = models.ImportJobRunning
+		job.StartedAt = &now
+		return tx.Model(&job).
+			Select("status", "started_at").
+			Updates(&job).Error
+	})
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// failStale menandai failed job running yang tidak menyimpan progress selama
+// importJobStaleAfter. Job tidak dijalankan ulang karena baris yang sudah
+// diproses akan menjadi task ganda; error job mencatat sampai mana import
+// berjalan.
+func (r *importJobRepository) failStale(ctx context.Context) error {
+	now := time.Now()
+	return r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		var jobs []models.ImportJob
+		err := tx.Omit("tasks").
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND updated_at < ?", models.ImportJobRunning, now.Add(-importJobStaleAfter)).
+			Find(&jobs).Error
+		if err != nil {
+			return err
+		}
+
+		for i := range jobs {
+			job := &jobs[i]
+			job.Status = models.ImportJobFailed
+			job.FinishedAt = &now
+			job.Errors = append(job.Errors, models.ImportJobError{
+				Message: fmt.Sprintf("import was interrupted; progress was last saved after %d of %d rows (%d created, %d failed), later rows may be partly imported and were not retried", job.Processed, job.Total, job.Created, job.Failed),
+			})
+			err := tx.Model(job).
+				Select("status", "errors", "finished_at").
+				Updates(job).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// UpdateProgress menyimpan status dan counter job, tanpa menyentuh tasks
+func (r *importJobRepository) UpdateProgress(ctx context.Context, job *models.ImportJob) error {
+	return r.conn(ctx).
+		Model(job).
+		Select("status", "processed", "created", "failed", "errors", "finished_at").
+		Updates(job).Error
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type LabelRepository interface {
+	Create(ctx context.Context, label *models.Label) error
+	GetAll(ctx context.Context, search *string) ([]models.Label, error)
+	GetByID(ctx context.Context, id uint) (*models.Label, error)
+	GetByIDs(ctx context.Context, ids []uint) ([]models.Label, error)
+	GetByName(ctx context.Context, name string) (*models.Label, error)
+	Update(ctx context.Context, label *models.Label) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type labelRepository struct {
+	*BaseRepository
+}
+
+func NewLabelRepository(db *gorm.DB) LabelRepository {
+	return &labelRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+func (r *labelRepository) Create(ctx context.Context, label *models.Label) error {
+	return r.conn(ctx).Create(label).Error
+}
+
+func (r *labelRepository) GetAll(ctx context.Context, search *string) ([]models.Label, error) {
+	var labels []models.Label
+	queryBuilder := r.conn(ctx).Order("name asc")
+	if search != nil {
+		queryBuilder = queryBuilder.Where("name ILIKE ?", "%"+*search+"%")
+	}
+	err := queryBuilder.Find(&labels).Error
+	return labels, err
+}
+
+func (r *labelRepository) GetByID(ctx context.Context, id uint) (*models.Label, error) {
+	var label models.Label
+	err := r.conn(ctx).First(&label, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &label, nil
+}
+
+func (r *labelRepository) GetByIDs(ctx context.Context, ids []uint) ([]models.Label, error) {
+	var labels []models.Label
+	if len(ids) == 0 {
+		return labels, nil
+	}
+	err := r.conn(ctx).Where("id IN ?", ids).Find(&labels).Error
+	return labels, err
+}
+
+// GetByName mencari label tanpa membedakan huruf besar/kecil
+func (r *labelRepository) GetByName(ctx context.Context, name string) (*models.Label, error) {
+	var label models.Label
+	err := r.conn(ctx).Where("LOWER(name) = LOWER(?)", name).First(&label).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &label, nil
+}
+
+func (r *labelRepository) Update(ctx context.Context, label *models.Label) error {
+	return r.conn(ctx).Save(label).Error
+}
+
+func (r *labelRepository) Delete(ctx context.Context, id uint) error {
+	return r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM task_labels WHERE label_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Label{}, id).Error
+	})
+}
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MetricsRepository menghitung metrik alur kerja dari riwayat status task.
+// Semua rentang waktu berbentuk [from, to).
+type MetricsRepository interface {
+	GetLeadTime(ctx context.Context, req *dto.MetricsRequest, from, to time.Time) (*dto.DurationStats, error)
+	GetCycleTime(ctx context.Context, req *dto.MetricsRequest, from, to time.Time) (*dto.DurationStats, error)
+	GetTimeInStatus(ctx context.Context, req *dto.MetricsRequest, from, to time.Time) ([]dto.StatusDuration, error)
+	// GetCumulativeFlow mengembalikan jumlah task per status pada akhir
+	// setiap hari dari first sampai last (tanggal di timezone), dengan key
+	// tanggal lalu status
+	GetCumulativeFlow(ctx context.Context, req *dto.MetricsRequest, first, last time.Time, timezone string) (map[string]map[string]int64, error)
+	// GetThroughput mengembalikan jumlah task selesai per minggu, dengan key
+	// tanggal Senin awal minggu
+	GetThroughput(ctx context.Context, req *dto.MetricsRequest, from, to time.Time, timezone string) (map[string]int64, error)
+}
+
+type metricsRepository struct {
+	*BaseRepository
+}
+
+func NewMetricsRepository(db *gorm.DB) MetricsRepository {
+	return &metricsRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// durationStatsColumns meringkas kolom hours dari subquery d
+const durationStatsColumns = `COUNT(*) AS count,
+	COALESCE(AVG(d.hours), 0) AS avg_hours,
+	COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY d.hours), 0) AS p50_hours,
+	COALESCE(percentile_cont(0.85) WITHIN GROUP (ORDER BY d.hours), 0) AS p85_hours,
+	COALESCE(percentile_cont(0.95) WITHIN GROUP (ORDER BY d.hours), 0) AS p95_hours`
+
+// metricTasks adalah task yang terlihat oleh akun di context dan cocok
+// dengan filter project, assignee dan label
+func (r *metricsRepository) metricTasks(ctx context.Context, req *dto.MetricsRequest) *gorm.DB {
+	filter := &dto.TaskListRequest{
+		ProjectID:  req.ProjectID,
+		AssigneeID: req.AssigneeID,
+		LabelIDs:   req.LabelIDs,
+		LabelMatch: req.LabelMatch,
+	}
+	return r.conn(ctx).
+		Model(&models.Task{}).
+		Scopes(visibleTasks(ctx), listTasks(ctx, filter))
+}
+
+// completedTasks adalah metricTasks yang terakhir kali selesai di [from, to)
+func (r *metricsRepository) completedTasks(ctx context.Context, req *dto.MetricsRequest, from, to time.Time) *gorm.DB {
+	return r.metricTasks(ctx, req).
+		Where(`"Tasks".completed_at >= ? AND "Tasks".completed_at < ?`, from, to)
+}
+
+// statusSegments adalah riwayat status task beserta waktu keluar dari
+// status tersebut (ended_at NULL jika masih di status itu)
+func (r *metricsRepository) statusSegments(ctx context.Context, req *dto.MetricsRequest) *gorm.DB {
+	return r.conn(ctx).
+		Model(&models.TaskStatusChange{}).
+		Select(`task_status_changes.task_id, task_status_changes.status, task_status_changes.changed_at,
+			LEAD(task_status_changes.changed_at) OVER (
+				PARTITION BY task_status_changes.task_id
+				ORDER BY task_status_changes.changed_at, task_status_changes.id
+			) AS ended_at`).
+		Where("task_status_changes.task_id IN (?)", r.metricTasks(ctx, req).Select(`"Tasks".id`))
+}
+
+func (r *metricsRepository) durationStats(ctx context.Context, hours *gorm.DB) (*dto.DurationStats, error) {
+	var stats dto.DurationStats
+	err := r.conn(ctx).
+		Table("(?) AS d", hours).
+		Select(durationStatsColumns).
+		Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+func (r *metricsRepository) GetLeadTime(ctx context.Context, req *dto.MetricsRequest, from, to time.Time) (*dto.DurationStats, error) {
+	hours := r.completedTasks(ctx, req, from, to).
+		Select(`EXTRACT(EPOCH FROM "Tasks".completed_at - "Tasks".created_at) / 3600 AS hours`)
+	return r.durationStats(ctx, hours)
+}
+
+// GetCycleTime menghitung dari pertama kali task masuk in_progress sampai
+// terakhir kali done. Task yang langsung done tanpa in_progress tidak
+// dihitung.
+func (r *metricsRepository) GetCycleTime(ctx context.Context, req *dto.MetricsRequest, from, to time.Time) (*dto.DurationStats, error) {
+	hours := r.completedTasks(ctx, req, from, to).
+		Select(`EXTRACT(EPOCH FROM "Tasks".completed_at - started.started_at) / 3600 AS hours`).
+		Joins(`JOIN (
+			SELECT task_id, MIN(changed_at) AS started_at FROM task_status_changes
+			WHERE status = ? GROUP BY task_id
+		) started ON started.task_id = "Tasks".id`, models.TaskStatusInProgress).
+		Where(`started.started_at <= "Tasks".completed_at`)
+	return r.durationStats(ctx, hours)
+}
+
+// GetTimeInStatus menghitung lama task berada di setiap status (kecuali
+// done) untuk perpindahan yang terjadi di [from, to). Status yang belum
+// ditinggalkan dihitung sampai sekarang.
+func (r *metricsRepository) GetTimeInStatus(ctx context.Context, req *dto.MetricsRequest, from, to time.Time) ([]dto.StatusDuration, error) {
+	hours := r.conn(ctx).
+		Table("(?) AS s", r.statusSegments(ctx, req)).
+		Select(`s.status, EXTRACT(EPOCH FROM COALESCE(s.ended_at, ?) - s.changed_at) / 3600 AS hours`, time.Now()).
+		Where("s.status <> ? AND s.changed_at >= ? AND s.changed_at < ?", models.TaskStatusDone, from, to)
+
+	var durations []dto.StatusDuration
+	err := r.conn(ctx).
+		Table("(?) AS d", hours).
+		Select("d.status AS status, " + durationStatsColumns).
+		Group("d.status").
+		Scan(&durations).Error
+	if err != nil {
+		return nil, err
+	}
+	return durations, nil
+}
+
+func (r *metricsRepository) GetCumulativeFlow(ctx context.Context, req *dto.MetricsRequest, first, last time.Time, timezone string) (map[string]map[string]int64, error) {
+	type flowCount struct {
+		Day    time.Time
+		Status string
+		Count  int64
+	}
+
+	// akhir hari d di timezone = awal hari berikutnya
+	var counts []flowCount
+	err := r.conn(ctx).Raw(`SELECT d.day AS day, s.status AS status, COUNT(*) AS count
+		FROM generate_series(CAST(? AS timestamp), CAST(? AS timestamp), interval '1 day') AS d(day)
+		JOIN (?) AS s ON s.changed_at < (d.day + interval '1 day') AT TIME ZONE ?
+			AND (s.ended_at IS NULL OR s.ended_at >= (d.day + interval '1 day') AT TIME ZONE ?)
+		GROUP BY d.day, s.status`,
+		first.Format("2006-01-02"), last.Format("2006-01-02"), r.statusSegments(ctx, req), timezone, timezone).
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	flow := make(map[string]map[string]int64)
+	for _, row := range counts {
+		date := row.Day.Format("2006-01-02")
+		if flow[date] == nil {
+			flow[date] = make(map[string]int64)
+		}
+		flow[date][row.Status] = row.Count
+	}
+	return flow, nil
+}
+
+func (r *metricsRepository) GetThroughput(ctx context.Context, req *dto.MetricsRequest, from, to time.Time, timezone string) (map[string]int64, error) {
+	type weekCount struct {
+		Week  time.Time
+		Count int64
+	}
+
+	var counts []weekCount
+	err := r.completedTasks(ctx, req, from, to).
+		Select(`date_trunc('week', "Tasks".completed_at AT TIME ZONE ?) AS week, COUNT(*) AS count`, timezone).
+		Group("week").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	throughput := make(map[string]int64, len(counts))
+	for _, row := range counts {
+		throughput[row.Week.Format("2006-01-02")] = row.Count
+	}
+	return throughput, nil
+}
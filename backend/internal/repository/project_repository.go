@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ProjectRepository interface {
+	Create(ctx context.Context, project *models.Project) error
+	GetByID(ctx context.Context, id uint) (*models.Project, error)
+	GetByKey(ctx context.Context, key string) (*models.Project, error)
+	GetAllForAccount(ctx context.Context, accountID uint, includeArchived bool) ([]models.Project, error)
+	Update(ctx context.Context, project *models.Project) error
+	Delete(ctx context.Context, id uint) error
+	CountTasks(ctx context.Context, id uint) (int64, error)
+	GetMembers(ctx context.Context, projectID uint) ([]models.ProjectMember, error)
+	GetMember(ctx context.Context, projectID, accountID uint) (*models.ProjectMember, error)
+	SaveMember(ctx context.Context, member *models.ProjectMember) error
+	RemoveMember(ctx context.Context, projectID, accountID uint) error
+}
+
+type projectRepository struct {
+	*BaseRepository
+}
+
+func NewProjectRepository(db *gorm.DB) ProjectRepository {
+	return &projectRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// Create menyimpan project beserta owner sebagai member pertama dalam satu transaksi
+func (r *projectRepository) Create(ctx context.Context, project *models.Project) error {
+	return r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Members").Create(project).Error; err != nil {
+			return err
+		}
+
+		owner := &models.ProjectMember{
+			ProjectID: project.ID,
+			AccountID: project.OwnerID,
+			Role:      models.ProjectRoleOwner,
+		}
+		return tx.Create(owner).Error
+	})
+}
+
+func (r *projectRepository) GetByID(ctx context.Context, id uint) (*models.Project, error) {
+	var project models.Project
+	err := r.conn(ctx).
+		Preload("Owner").
+		First(&project, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+func (r *projectRepository) GetByKey(ctx context.Context, key string) (*models.Project, error) {
+	var project models.Project
+	err := r.conn(ctx).Where("key = ?", key).First(&project).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+func (r *projectRepository) GetAllForAccount(ctx context.Context, accountID uint, includeArchived bool) ([]models.Project, error) {
+	var projects []models.Project
+
+	queryBuilder := r.conn(ctx).
+		Preload("Owner").
+		Where("id IN (SELECT project_id FROM project_members WHERE accounts_id = ?)", accountID)
+
+	if !includeArchived {
+		queryBuilder = queryBuilder.Where("is_archived = ?", false)
+	}
+
+	err := queryBuilder.Order("name asc").Find(&projects).Error
+	return projects, err
+}
+
+func (r *projectRepository) Update(ctx context.Context, project *models.Project) error {
+	return r.conn(ctx).Omit("Owner", "Members").Save(project).Error
+}
+
+func (r *projectRepository) Delete(ctx context.Context, id uint) error {
+	return r.conn(ctx).Delete(&models.Project{}, id).Error
+}
+
+func (r *projectRepository) CountTasks(ctx context.Context, id uint) (int64, error) {
+	var count int64
+	err := r.conn(ctx).
+		Model(&models.Task{}).
+		Where("project_id = ?", id).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *projectRepository) GetMembers(ctx context.Context, projectID uint) ([]models.ProjectMember, error) {
+	var members []models.ProjectMember
+	err := r.conn(ctx).
+		Preload("Account").
+		Where("project_id = ?", projectID).
+		Order("created_at asc").
+		Find(&members).Error
+	return members, err
+}
+
+// GetMember mengembalikan nil, nil jika akun bukan member project
+func (r *projectRepository) GetMember(ctx context.Context, projectID, accountID uint) (*models.ProjectMember, error) {
+	var member models.ProjectMember
+	err := r.conn(ctx).
+		Where("project_id = ? AND accounts_id = ?", projectID, accountID).
+		First(&member).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+// SaveMember menambah member baru atau mengubah role member yang sudah ada
+func (r *projectRepository) SaveMember(ctx context.Context, member *models.ProjectMember) error {
+	return r.conn(ctx).
+		Omit("Account").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "project_id"}, {Name: "accounts_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"role"}),
+		}).
+		Create(member).Error
+}
+
+func (r *projectRepository) RemoveMember(ctx context.Context, projectID, accountID uint) error {
+	return r.conn(ctx).
+		Where("project_id = ? AND accounts_id = ?", projectID, accountID).
+		Delete(&models.ProjectMember{}).Error
+}
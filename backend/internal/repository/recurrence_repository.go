@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrOccurrenceExists berarti occurrence sudah dibuat oleh proses lain
+// (worker dan completion bisa berjalan bersamaan untuk seri yang sama)
+var ErrOccurrenceExists = errors.New("occurrence already generated")
+
+type RecurrenceRepository interface {
+	Create(ctx context.Context, recurrence *models.TaskRecurrence, taskID uint) error
+	GetByID(ctx context.Context, id uint) (*models.TaskRecurrence, error)
+	GetDue(ctx context.Context, now time.Time, limit int) ([]models.TaskRecurrence, error)
+	Update(ctx context.Context, recurrence *models.TaskRecurrence) error
+	Delete(ctx context.Context, id uint) error
+	AddSkip(ctx context.Context, skip *models.TaskRecurrenceSkip) error
+	Materialize(ctx context.Context, recurrence *models.TaskRecurrence, next *time.Time) (*models.Task, error)
+}
+
+type recurrenceRepository struct {
+	*BaseRepository
+}
+
+func NewRecurrenceRepository(db *gorm.DB) RecurrenceRepository {
+	return &recurrenceRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// Create menyimpan seri baru dan menjadikan taskID occurrence pertamanya
+func (r *recurrenceRepository) Create(ctx context.Context, recurrence *models.TaskRecurrence, taskID uint) error {
+	return r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Skips").Create(recurrence).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Task{}).
+			Where("id = ?", taskID).
+			Updates(map[string]interface{}{
+				"recurrence_id": recurrence.ID,
+				"occurrence_at": recurrence.StartAt,
+				"version":       gorm.Expr("version + 1"),
+			}).Error
+	})
+}
+
+func (r *recurrenceRepository) GetByID(ctx context.Context, id uint) (*models.TaskRecurrence, error) {
+	var recurrence models.TaskRecurrence
+	err := r.conn(ctx).
+		Preload("Skips", func(db *gorm.DB) *gorm.DB {
+			return db.Order("occurrence_at asc")
+		}).
+		First(&recurrence, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &recurrence, nil
+}
+
+// GetDue mengambil seri yang occurrence terakhirnya sudah jatuh tempo
+// sehingga occurrence berikutnya perlu dibuat
+func (r *recurrenceRepository) GetDue(ctx context.Context, now time.Time, limit int) ([]models.TaskRecurrence, error) {
+	var recurrences []models.TaskRecurrence
+	err := r.conn(ctx).
+		Preload("Skips").
+		Where("next_occurrence_at IS NOT NULL AND last_occurrence_at <= ?", now).
+		Order("last_occurrence_at asc").
+		Limit(limit).
+		Find(&recurrences).Error
+	return recurrences, err
+}
+
+func (r *recurrenceRepository) Update(ctx context.Context, recurrence *models.TaskRecurrence) error {
+	return r.conn(ctx).Omit("Skips").Save(recurrence).Error
+}
+
+// Delete menghentikan seri; occurrence yang sudah ada tetap sebagai task biasa
+func (r *recurrenceRepository) Delete(ctx context.Context, id uint) error {
+	return r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.Task{}).
+			Where("recurrence_id = ?", id).
+			Updates(map[string]interface{}{
+				"recurrence_id": nil,
+				"occurrence_at": nil,
+				"version":       gorm.Expr("version + 1"),
+			}).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("recurrence_id = ?", id).Delete(&models.TaskRecurrenceSkip{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.TaskRecurrence{}, id).Error
+	})
+}
+
+func (r *recurrenceRepository) AddSkip(ctx context.Context, skip *models.TaskRecurrenceSkip) error {
+	return r.conn(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(skip).Error
+}
+
+// Materialize membuat task untuk recurrence.NextOccurrenceAt lalu memajukan
+// seri ke next. Baris seri dikunci (SELECT ... FOR UPDATE) dan state-nya
+// dibandingkan dengan yang dibaca pemanggil, jadi setiap occurrence hanya
+// dibuat sekali walaupun dipanggil paralel. Label, assignee dan watcher
+// disalin dari occurrence sebelumnya.
+func (r *recurrenceRepository) Materialize(ctx context.Context, recurrence *models.TaskRecurrence, next *time.Time) (*models.Task, error) {
+	if recurrence.NextOccurrenceAt == nil {
+		return nil, ErrOccurrenceExists
+	}
+	occurrenceAt := *recurrence.NextOccurrenceAt
+
+	var task *models.Task
+	err := r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		var locked models.TaskRecurrence
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&locked, recurrence.ID).Error
+		if err != nil {
+			return err
+		}
+		if locked.NextOccurrenceAt == nil || !locked.NextOccurrenceAt.Equal(occurrenceAt) ||
+			!locked.LastOccurrenceAt.Equal(recurrence.LastOccurrenceAt) {
+			return ErrOccurrenceExists
+		}
+
+		task = &models.Task{
+			WorkspaceID:     locked.WorkspaceID,
+			CreateAccountID: locked.CreateAccountID,
+			AccountID:       locked.AccountID,
+			ProjectID:       locked.ProjectID,
+			Title:           locked.Title,
+			Description:     locked.Description,
+			Status:          models.TaskStatusTodo,
+			Priority:        locked.Priority,
+			Deadline:        occurrenceAt,
+			RecurrenceID:    &locked.ID,
+			OccurrenceAt:    &occurrenceAt,
+		}
+		if err := assignRank(tx, task); err != nil {
+			return err
+		}
+		if err := tx.Omit("Assignees", "Watchers").Create(task).Error; err != nil {
+			return err
+		}
+		if err := saveInitialStatus(tx, task); err != nil {
+			return err
+		}
+
+		if err := saveTaskParticipants(tx, task); err != nil {
+			return err
+		}
+
+		copies := map[string]string{
+			"task_labels":    "label_id",
+			"task_assignees": "account_id",
+			"task_watchers":  "account_id",
+		}
+		for table, column := range copies {
+			err = tx.Exec(`
+				INSERT INTO `+table+` (task_id, `+column+`)
+				SELECT ?, pivot.`+column+` FROM `+table+` pivot
+				JOIN "Tasks" previous ON previous.id = pivot.task_id
+				WHERE previous.recurrence_id = ? AND previous.occurrence_at = ?
+				ON CONFLICT DO NOTHING`, task.ID, locked.ID, locked.LastOccurrenceAt).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		recurrence.LastOccurrenceAt = occurrenceAt
+		recurrence.NextOccurrenceAt = next
+		return tx.Model(&models.TaskRecurrence{}).
+			Where("id = ?", locked.ID).
+			Updates(map[string]interface{}{
+				"last_occurrence_at": occurrenceAt,
+				"next_occurrence_at": next,
+				"updated_at":         time.Now(),
+			}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrReminderSent berarti reminder yang sama sudah dicatat terkirim
+var ErrReminderSent = errors.New("reminder already sent")
+
+// reminderNotSent bernilai true jika masih ada penerima task (assignee atau
+// watcher) yang belum punya reminder dengan kind dan lead time tertentu untuk
+// deadline-nya saat ini. Penerima yang sudah dikirimi dilewati oleh unique
+// index idx_task_reminders_once saat Claim.
+const reminderNotSent = `EXISTS (
+	SELECT 1 FROM (
+		SELECT ta.account_id FROM task_assignees ta WHERE ta.task_id = "Tasks".id
+		UNION
+		SELECT tw.account_id FROM task_watchers tw WHERE tw.task_id = "Tasks".id
+	) recipient
+	WHERE NOT EXISTS (
+		SELECT 1 FROM task_reminders r
+		WHERE r.task_id = "Tasks".id AND r.accounts_id = recipient.account_id
+			AND r.kind = ? AND r.lead_minutes = ? AND r.deadline = "Tasks".deadline
+	)
+)`
+
+type ReminderRepository interface {
+	MarkOverdue(ctx context.Context, now time.Time) (int64, error)
+	ClearOverdue(ctx context.Context, now time.Time) (int64, error)
+	GetDueSoon(ctx context.Context, now time.Time, lead, floor time.Duration, limit int) ([]models.Task, error)
+	GetOverdue(ctx context.Context, limit int) ([]models.Task, error)
+	Claim(ctx context.Context, reminder *models.TaskReminder, send func() error) error
+}
+
+type reminderRepository struct {
+	*BaseRepository
+}
+
+func NewReminderRepository(db *gorm.DB) ReminderRepository {
+	return &reminderRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// openWithDeadline membatasi ke task belum done yang punya deadline
+func openWithDeadline(db *gorm.DB) *gorm.DB {
+	return db.
+		Where("status IS DISTINCT FROM ?", models.TaskStatusDone).
+		Where("deadline > ?", time.Time{})
+}
+
+// MarkOverdue mengisi overdue_at untuk task yang deadline-nya sudah lewat
+func (r *reminderRepository) MarkOverdue(ctx context.Context, now time.Time) (int64, error) {
+	result := r.conn(ctx).
+		Model(&models.Task{}).
+		Scopes(openWithDeadline).
+		Where("overdue_at IS NULL AND deadline < ?", now).
+		Update("overdue_at", now)
+	return result.RowsAffected, result.Error
+}
+
+// ClearOverdue mengosongkan overdue_at untuk task yang sudah done atau
+// deadline-nya dipindah ke masa depan
+func (r *reminderRepository) ClearOverdue(ctx context.Context, now time.Time) (int64, error) {
+	result := r.conn(ctx).
+		Model(&models.Task{}).
+		Where("overdue_at IS NOT NULL").
+		Where("status = ? OR deadline >= ?", models.TaskStatusDone, now).
+		Update("overdue_at", nil)
+	return result.RowsAffected, result.Error
+}
+
+// GetDueSoon mengambil task dengan deadline di rentang (now+floor, now+lead]
+// yang masih punya penerima tanpa reminder untuk lead tersebut. floor adalah
+// lead time yang lebih kecil berikutnya, supaya task yang dibuat mepet
+// deadline hanya mendapat reminder terdekat.
+func (r *reminderRepository) GetDueSoon(ctx context.Context, now time.Time, lead, floor time.Duration, limit int) ([]models.Task, error) {
+	var tasks []models.Task
+	err := r.conn(ctx).
+		Scopes(openWithDeadline).
+		Preload("Assignees").
+		Preload("Watchers").
+		Where("deadline > ? AND deadline <= ?", now.Add(floor), now.Add(lead)).
+		Where(reminderNotSent, models.ReminderKindDueSoon, int(lead.Minutes())).
+		Order("deadline asc").
+		Limit(limit).
+		Find(&tasks).Error
+	return tasks, err
+}
+
+// GetOverdue mengambil task overdue yang masih punya penerima tanpa
+// reminder overdue
+func (r *reminderRepository) GetOverdue(ctx context.Context, limit int) ([]models.Task, error) {
+	var tasks []models.Task
+	err := r.conn(ctx).
+		Scopes(openWithDeadline).
+		Preload("Assignees").
+		Preload("Watchers").
+		Where("overdue_at IS NOT NULL").
+		Where(reminderNotSent, models.ReminderKindOverdue, 0).
+		Order("deadline asc").
+		Limit(limit).
+		Find(&tasks).Error
+	return tasks, err
+}
+
+// Claim mencatat reminder lalu memanggil send dalam satu transaksi. Insert
+// yang bentrok dengan unique index menunggu transaksi lain selesai, jadi dua
+// worker tidak bisa mengirim reminder yang sama. Jika send gagal, catatan
+// di-rollback dan reminder dicoba lagi di putaran berikutnya.
+func (r *reminderRepository) Claim(ctx context.Context, reminder *models.TaskReminder, send func() error) error {
+	return r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Omit("Task").
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(reminder)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrReminderSent
+		}
+
+		return send()
+	})
+}
@@ -0,0 +1,307 @@
+package repository
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCursor berarti cursor rusak atau dibuat untuk urutan lain
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+const (
+	defaultPageLimit = 10
+	maxPageLimit     = 1000
+)
+
+// taskOrderTimeFields adalah field bertipe timestamp; nilainya disimpan di
+// cursor sebagai teks RFC 3339
+var taskOrderTimeFields = map[string]bool{"deadline": true, "created_at": true, "updated_at": true}
+
+// taskOrderNotNull adalah kunci yang tidak pernah NULL, jadi kondisi seek
+// tidak perlu memeriksa NULL
+var taskOrderNotNull = map[string]bool{"id": true, "priority": true, "created_at": true, "relevance": true, "similarity": true}
+
+// taskCursor adalah isi cursor sebelum di-encode: nilai setiap kunci urutan
+// (id selalu terakhir) dari baris batas, arah halaman, dan tanda tangan
+// urutan supaya cursor tidak dipakai dengan sort yang berbeda
+type taskCursor struct {
+	Values []interface{} `json:"v"`
+	Prev   bool          `json:"p,omitempty"`
+	Sort   string        `json:"s"`
+}
+
+// orderSignature meringkas kunci urutan (tanpa nilai parameter)
+func orderSignature(keys []orderKey) string {
+	hash := fnv.New32a()
+	for _, key := range keys {
+		fmt.Fprintf(hash, "%s %t %s;", key.Field, key.Desc, key.Nulls)
+	}
+	return strconv.FormatUint(uint64(hash.Sum32()), 36)
+}
+
+func encodeTaskCursor(keys []orderKey, values []interface{}, prev bool) *string {
+	data, _ := json.Marshal(taskCursor{Values: values, Prev: prev, Sort: orderSignature(keys)})
+	cursor := base64.RawURLEncoding.EncodeToString(data)
+	return &cursor
+}
+
+func decodeTaskCursor(keys []orderKey, value string) (*taskCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor taskCursor
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if cursor.Sort != orderSignature(keys) || len(cursor.Values) != len(keys) {
+		return nil, fmt.Errorf("%w: cursor belongs to a different sort order", ErrInvalidCursor)
+	}
+
+	for i, value := range cursor.Values {
+		switch v := value.(type) {
+		case json.Number:
+			if n, err := v.Int64(); err == nil {
+				cursor.Values[i] = n
+			} else if f, err := v.Float64(); err == nil {
+				cursor.Values[i] = f
+			} else {
+				return nil, ErrInvalidCursor
+			}
+		case string:
+			if taskOrderTimeFields[keys[i].Field] {
+				t, err := time.Parse(time.RFC3339Nano, v)
+				if err != nil {
+					return nil, ErrInvalidCursor
+				}
+				cursor.Values[i] = t
+			}
+		case nil:
+		default:
+			return nil, ErrInvalidCursor
+		}
+	}
+	if cursor.Values[len(keys)-1] == nil {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// reverseKeys membalik arah dan posisi NULL setiap kunci, untuk membaca
+// halaman sebelumnya mundur dari baris batas
+func reverseKeys(keys []orderKey) []orderKey {
+	reversed := make([]orderKey, len(keys))
+	for i, key := range keys {
+		// posisi NULL bawaan postgres ikut terbalik bersama arah
+		switch key.Nulls {
+		case "first":
+			key.Nulls = "last"
+		case "last":
+			key.Nulls = "first"
+		}
+		key.Desc = !key.Desc
+		reversed[i] = key
+	}
+	return reversed
+}
+
+// after adalah kondisi baris yang berada setelah value pada satu kunci,
+// false jika tidak ada (value NULL dan NULL berada di akhir)
+func (k orderKey) after(value interface{}) (sqlCondition, bool) {
+	if value == nil {
+		if k.nullsLast() {
+			return sqlCondition{}, false
+		}
+		return condition(k.Expr+" IS NOT NULL", k.Vars...), true
+	}
+
+	op := " > ?"
+	if k.Desc {
+		op = " < ?"
+	}
+	cond := condition(k.Expr+op, append(append([]interface{}{}, k.Vars...), value)...)
+	if k.nullsLast() && !taskOrderNotNull[k.Field] {
+		cond = joinConditions([]sqlCondition{cond, condition(k.Expr+" IS NULL", k.Vars...)}, "OR")
+	}
+	return cond, true
+}
+
+func (k orderKey) equal(value interface{}) sqlCondition {
+	if value == nil {
+		return condition(k.Expr+" IS NULL", k.Vars...)
+	}
+	return condition(k.Expr+" = ?", append(append([]interface{}{}, k.Vars...), value)...)
+}
+
+// seekCondition memilih baris setelah values menurut keys. Perbandingan
+// row (a, b) > (x, y) diurai manual karena arah dan posisi NULL setiap
+// kunci bisa berbeda.
+func seekCondition(keys []orderKey, values []interface{}) sqlCondition {
+	var alternatives, equals []sqlCondition
+	for i, key := range keys {
+		if after, ok := key.after(values[i]); ok {
+			alternative := append(append([]sqlCondition{}, equals...), after)
+			alternatives = append(alternatives, joinConditions(alternative, "AND"))
+		}
+		equals = append(equals, key.equal(values[i]))
+	}
+	if len(alternatives) == 0 {
+		return condition("FALSE")
+	}
+	return joinConditions(alternatives, "OR")
+}
+
+// GetPage mengambil satu halaman list dengan keyset pagination. Baris
+// batas dibaca dari cursor, jadi halaman tidak bergeser walaupun ada task
+// yang ditambah atau dihapus di antara request, dan tidak ada OFFSET.
+func (r *taskRepository) GetPage(ctx context.Context, req *dto.TaskListRequest) ([]models.Task, *dto.TaskPageInfo, error) {
+	limit, _ := strconv.Atoi(req.Limit)
+	if limit < 1 {
+		limit = defaultPageLimit
+	}
+	if limit > maxPageLimit {
+		limit = maxPageLimit
+	}
+
+	keys, err := listOrderKeys(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	var cursor *taskCursor
+	if req.Cursor != "" {
+		if cursor, err = decodeTaskCursor(keys, req.Cursor); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	seekKeys := keys
+	if cursor != nil && cursor.Prev {
+		seekKeys = reverseKeys(keys)
+	}
+
+	columns := make([]string, len(seekKeys))
+	var columnVars []interface{}
+	for i, key := range seekKeys {
+		columns[i] = key.Expr
+		columnVars = append(columnVars, key.Vars...)
+	}
+
+	queryBuilder := r.conn(ctx).
+		Model(&models.Task{}).
+		Select(strings.Join(columns, ", "), columnVars...).
+		Scopes(visibleTasks(ctx), listTasks(ctx, req))
+	if cursor != nil {
+		seek := seekCondition(seekKeys, cursor.Values)
+		queryBuilder = queryBuilder.Where(seek.SQL, seek.Vars...)
+	}
+
+	rows, err := queryBuilder.Order(orderClause(seekKeys)).Limit(limit + 1).Rows()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var page [][]interface{}
+	for rows.Next() {
+		values := make([]interface{}, len(keys))
+		pointers := make([]interface{}, len(keys))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, nil, err
+		}
+		page = append(page, values)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	// koneksi transaksi dipakai lagi untuk query berikutnya
+	rows.Close()
+
+	hasMore := len(page) > limit
+	if hasMore {
+		page = page[:limit]
+	}
+	backward := cursor != nil && cursor.Prev
+	if backward {
+		for i, j := 0, len(page)-1; i < j; i, j = i+1, j-1 {
+			page[i], page[j] = page[j], page[i]
+		}
+	}
+
+	info := &dto.TaskPageInfo{}
+	if len(page) > 0 {
+		first, last := page[0], page[len(page)-1]
+		if hasMore || backward {
+			info.NextCursor = encodeTaskCursor(keys, last, false)
+		}
+		if (backward && hasMore) || (!backward && cursor != nil) {
+			info.PrevCursor = encodeTaskCursor(keys, first, true)
+		}
+	}
+
+	if req.IncludeTotal {
+		var total int64
+		err := r.conn(ctx).
+			Model(&models.Task{}).
+			Scopes(visibleTasks(ctx), listTasks(ctx, req)).
+			Count(&total).Error
+		if err != nil {
+			return nil, nil, err
+		}
+		info.Total = &total
+	}
+
+	ids := make([]uint, len(page))
+	for i, values := range page {
+		id, ok := values[len(values)-1].(int64)
+		if !ok {
+			return nil, nil, fmt.Errorf("unexpected task id %T", values[len(values)-1])
+		}
+		ids[i] = uint(id)
+	}
+	tasks, err := r.getByIDsInOrder(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tasks, info, nil
+}
+
+// getByIDsInOrder memuat task beserta relasinya dengan urutan sesuai ids
+func (r *taskRepository) getByIDsInOrder(ctx context.Context, ids []uint) ([]models.Task, error) {
+	if len(ids) == 0 {
+		return []models.Task{}, nil
+	}
+
+	var found []models.Task
+	err := r.conn(ctx).Scopes(preloadTaskRelations).Where("id IN ?", ids).Find(&found).Error
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uint]models.Task, len(found))
+	for _, task := range found {
+		byID[task.ID] = task
+	}
+	tasks := make([]models.Task, 0, len(ids))
+	for _, id := range ids {
+		if task, ok := byID[id]; ok {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm/clause"
+)
+
+// ErrInvalidOrder berarti field urutan tidak ada di taskOrderColumns
+var ErrInvalidOrder = errors.New("invalid order field")
+
+// taskOrderColumns adalah allowlist field TaskOrder beserta ekspresi SQL-nya.
+// Relasi diurutkan lewat subquery (bukan join) supaya filter list tidak
+// ambigu, teks tanpa membedakan huruf besar/kecil, status sesuai alur kerja,
+// dan deadline zero time dianggap NULL.
+var taskOrderColumns = map[string]string{
+	"id":    `"Tasks".id`,
+	"title": `LOWER("Tasks".title)`,
+	"status": fmt.Sprintf(`CASE "Tasks".status WHEN '%s' THEN 0 WHEN '%s' THEN 1 WHEN '%s' THEN 2 END`,
+		models.TaskStatusTodo, models.TaskStatusInProgress, models.TaskStatusDone),
+	"priority":         `"Tasks".priority`,
+	"deadline":         `CASE WHEN "Tasks".deadline > '0001-01-01' THEN "Tasks".deadline END`,
+	"estimate_minutes": `"Tasks".estimate_minutes`,
+	"created_at":       `"Tasks".created_at`,
+	"updated_at":       `"Tasks".updated_at`,
+	"rank":             `"Tasks".rank`,
+	"project":          `(SELECT LOWER(p.name) FROM projects p WHERE p.id = "Tasks".project_id)`,
+	"assignee":         `(SELECT LOWER(a.name) FROM accounts a WHERE a.id = "Tasks".accounts_id)`,
+}
+
+// taskSorts memetakan named sort dari TaskListRequest.Sort ke urutan
+// terstruktur. id selalu ikut sebagai tiebreaker supaya urutan stabil antar
+// halaman.
+var taskSorts = map[string]dto.TaskOrders{
+	dto.TaskSortNewest:           {{Field: "id", Direction: "desc"}},
+	dto.TaskSortOldest:           {{Field: "id", Direction: "asc"}},
+	dto.TaskSortPriority:         {{Field: "priority"}, {Field: "id", Direction: "desc"}},
+	dto.TaskSortDeadline:         {{Field: "deadline", Nulls: "last"}, {Field: "id", Direction: "desc"}},
+	dto.TaskSortPriorityDeadline: {{Field: "priority"}, {Field: "deadline", Nulls: "last"}, {Field: "id", Direction: "desc"}},
+	dto.TaskSortRank:             {{Field: "rank", Nulls: "last"}, {Field: "id", Direction: "asc"}},
+}
+
+// orderKey adalah satu kunci ORDER BY yang sudah lolos allowlist. Vars
+// adalah parameter Expr (hanya untuk urutan relevansi).
+type orderKey struct {
+	Field string
+	Expr  string
+	Vars  []interface{}
+	Desc  bool
+	Nulls string
+}
+
+func (k orderKey) String() string {
+	sql := k.Expr + " ASC"
+	if k.Desc {
+		sql = k.Expr + " DESC"
+	}
+	if k.Nulls != "" {
+		sql += " NULLS " + strings.ToUpper(k.Nulls)
+	}
+	return sql
+}
+
+// nullsLast mengikuti bawaan postgres jika Nulls kosong: NULL dianggap
+// paling besar
+func (k orderKey) nullsLast() bool {
+	if k.Nulls == "" {
+		return !k.Desc
+	}
+	return k.Nulls == "last"
+}
+
+// taskOrderKeys memvalidasi orders terhadap allowlist dan menambahkan id
+// (searah kunci terakhir) jika belum ada. Tanpa orders urutannya newest.
+func taskOrderKeys(orders dto.TaskOrders) ([]orderKey, error) {
+	if len(orders) == 0 {
+		orders = taskSorts[dto.TaskSortNewest]
+	}
+
+	keys := make([]orderKey, 0, len(orders)+1)
+	hasID := false
+	for _, order := range orders {
+		expr, ok := taskOrderColumns[order.Field]
+		if !ok {
+			return nil, fmt.Errorf("%w %q", ErrInvalidOrder, order.Field)
+		}
+		keys = append(keys, orderKey{
+			Field: order.Field,
+			Expr:  expr,
+			Desc:  order.Direction == "desc",
+			Nulls: order.Nulls,
+		})
+		if order.Field == "id" {
+			hasID = true
+			break
+		}
+	}
+
+	if !hasID {
+		keys = append(keys, orderKey{Field: "id", Expr: taskOrderColumns["id"], Desc: keys[len(keys)-1].Desc})
+	}
+	return keys, nil
+}
+
+// ValidateTaskOrders memeriksa orders terhadap allowlist tanpa menjalankan
+// query, misalnya sebelum disimpan di saved view
+func ValidateTaskOrders(orders dto.TaskOrders) error {
+	_, err := taskOrderKeys(orders)
+	return err
+}
+
+// listOrderKeys mengembalikan kunci urutan list: Sort lebih dulu, lalu
+// Order. Sort relevance tanpa teks pencarian jatuh ke newest.
+func listOrderKeys(req *dto.TaskListRequest) ([]orderKey, error) {
+	if req.Sort == dto.TaskSortRelevance {
+		if search := searchText(req); search != "" {
+			return searchOrderKeys(search), nil
+		}
+	}
+
+	orders := req.Order
+	if sort, ok := taskSorts[req.Sort]; ok {
+		orders = sort
+	} else if req.Sort != "" {
+		orders = nil
+	}
+	return taskOrderKeys(orders)
+}
+
+// listOrder mengembalikan ORDER BY untuk list
+func listOrder(req *dto.TaskListRequest) (interface{}, error) {
+	keys, err := listOrderKeys(req)
+	if err != nil {
+		return nil, err
+	}
+	return orderClause(keys), nil
+}
+
+func orderClause(keys []orderKey) clause.OrderBy {
+	sql := make([]string, len(keys))
+	var vars []interface{}
+	for i, key := range keys {
+		sql[i] = key.String()
+		vars = append(vars, key.Vars...)
+	}
+	return clause.OrderBy{Expression: clause.Expr{SQL: strings.Join(sql, ", "), Vars: vars, WithoutParentheses: true}}
+}
@@ -0,0 +1,514 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"backend/internal/query"
+	"backend/internal/utils"
+	"context"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// sqlCondition adalah potongan WHERE beserta parameternya
+type sqlCondition struct {
+	SQL  string
+	Vars []interface{}
+}
+
+func condition(sql string, vars ...interface{}) sqlCondition {
+	return sqlCondition{SQL: sql, Vars: vars}
+}
+
+// negate aman terhadap NULL: kondisi yang bernilai NULL dianggap false
+// sebelum dibalik
+func (c sqlCondition) negate() sqlCondition {
+	return sqlCondition{SQL: "NOT COALESCE((" + c.SQL + "), false)", Vars: c.Vars}
+}
+
+func joinConditions(conditions []sqlCondition, operator string) sqlCondition {
+	parts := make([]string, len(conditions))
+	var vars []interface{}
+	for i, c := range conditions {
+		parts[i] = "(" + c.SQL + ")"
+		vars = append(vars, c.Vars...)
+	}
+	return sqlCondition{SQL: strings.Join(parts, " "+operator+" "), Vars: vars}
+}
+
+var (
+	equalityOps   = []query.Op{query.OpMatch, query.OpEqual, query.OpNotEqual}
+	comparisonOps = []query.Op{query.OpMatch, query.OpEqual, query.OpNotEqual,
+		query.OpLess, query.OpLessEqual, query.OpGreater, query.OpGreaterEqual}
+	matchOps = []query.Op{query.OpMatch}
+)
+
+// taskQueryField adalah field yang boleh dipakai di query task beserta
+// operator yang diizinkan
+type taskQueryField struct {
+	ops     []query.Op
+	compile func(c *taskQueryCompiler, term *query.Term) (sqlCondition, error)
+}
+
+// taskQueryFields adalah allowlist field query task. Nilai "me" merujuk
+// akun yang login, "none" berarti relasi kosong.
+var taskQueryFields = map[string]taskQueryField{
+	"id":          {comparisonOps, compileNumberField(`"Tasks".id`)},
+	"status":      {equalityOps, compileStatus},
+	"priority":    {comparisonOps, compilePriority},
+	"assignee":    {equalityOps, compileParticipant("task_assignees")},
+	"watcher":     {equalityOps, compileParticipant("task_watchers")},
+	"creator":     {equalityOps, compileCreator},
+	"label":       {equalityOps, compileLabel},
+	"project":     {equalityOps, compileProject},
+	"parent":      {equalityOps, compileParent},
+	"due":         {comparisonOps, compileDateField(`"Tasks".deadline`, true)},
+	"created":     {comparisonOps, compileDateField(`"Tasks".created_at`, false)},
+	"updated":     {comparisonOps, compileDateField(`"Tasks".updated_at`, false)},
+	"is":          {matchOps, compileIs},
+	"has":         {matchOps, compileHas},
+	"title":       {matchOps, compileContains(`"Tasks".title`)},
+	"description": {matchOps, compileContains(`"Tasks".description`)},
+}
+
+// taskQueryIs dan taskQueryHas adalah nilai untuk is: dan has:
+var (
+	taskQueryIs = map[string]string{
+		"open":      `"Tasks".status IS DISTINCT FROM 'done'`,
+		"done":      `"Tasks".status = 'done'`,
+		"overdue":   `"Tasks".overdue_at IS NOT NULL`,
+		"blocked":   openBlockerExists,
+		"ready":     `"Tasks".status IS DISTINCT FROM 'done' AND NOT ` + openBlockerExists,
+		"root":      `"Tasks".parent_id IS NULL`,
+		"subtask":   `"Tasks".parent_id IS NOT NULL`,
+		"recurring": `"Tasks".recurrence_id IS NOT NULL`,
+	}
+	taskQueryHas = map[string]string{
+		"deadline": `"Tasks".deadline > '0001-01-01'`,
+		"project":  `"Tasks".project_id IS NOT NULL`,
+		"label":    `EXISTS (SELECT 1 FROM task_labels tl WHERE tl.task_id = "Tasks".id)`,
+		"watcher":  `EXISTS (SELECT 1 FROM task_watchers tw WHERE tw.task_id = "Tasks".id)`,
+		"estimate": `"Tasks".estimate_minutes IS NOT NULL`,
+		"subtasks": `EXISTS (SELECT 1 FROM "Tasks" child WHERE child.parent_id = "Tasks".id)`,
+	}
+)
+
+// taskQueryCompiler mengubah AST query menjadi kondisi SQL berparameter
+type taskQueryCompiler struct {
+	accountID  uint
+	hasAccount bool
+	now        time.Time
+}
+
+// filterByQuery menerapkan query task ke query builder. Error sintaks atau
+// validasi (*query.Error) dicatat ke builder sehingga dikembalikan oleh
+// Find/Count.
+func filterByQuery(ctx context.Context, db *gorm.DB, input string) *gorm.DB {
+	cond, err := compileTaskQuery(ctx, input)
+	if err != nil {
+		db.AddError(err)
+		return db
+	}
+	if cond == nil {
+		return db
+	}
+	return db.Where(cond.SQL, cond.Vars...)
+}
+
+// compileTaskQuery mem-parse dan memvalidasi query task; nil berarti
+// query kosong
+func compileTaskQuery(ctx context.Context, input string) (*sqlCondition, error) {
+	node, err := query.Parse(input)
+	if err != nil || node == nil {
+		return nil, err
+	}
+
+	accountID, ok := utils.AccountIDFromContext(ctx)
+	c := &taskQueryCompiler{accountID: accountID, hasAccount: ok, now: time.Now().UTC()}
+	cond, err := c.compile(node)
+	if err != nil {
+		return nil, err
+	}
+	return &cond, nil
+}
+
+// ValidateTaskQuery memeriksa query task tanpa menjalankannya, misalnya
+// sebelum disimpan di saved view. Error selalu bertipe *query.Error.
+func ValidateTaskQuery(ctx context.Context, input string) error {
+	_, err := compileTaskQuery(ctx, input)
+	return err
+}
+
+func (c *taskQueryCompiler) compile(node query.Node) (sqlCondition, error) {
+	switch n := node.(type) {
+	case *query.And:
+		return c.compileAll(n.Nodes, "AND")
+	case *query.Or:
+		return c.compileAll(n.Nodes, "OR")
+	case *query.Not:
+		cond, err := c.compile(n.Node)
+		return cond.negate(), err
+	case *query.Text:
+		return compileText(n), nil
+	case *query.Term:
+		return c.compileTerm(n)
+	default:
+		return sqlCondition{}, query.Errorf(node.Pos(), "unsupported expression")
+	}
+}
+
+func (c *taskQueryCompiler) compileAll(nodes []query.Node, operator string) (sqlCondition, error) {
+	conditions := make([]sqlCondition, len(nodes))
+	for i, node := range nodes {
+		cond, err := c.compile(node)
+		if err != nil {
+			return sqlCondition{}, err
+		}
+		conditions[i] = cond
+	}
+	return joinConditions(conditions, operator), nil
+}
+
+func (c *taskQueryCompiler) compileTerm(term *query.Term) (sqlCondition, error) {
+	field, ok := taskQueryFields[strings.ToLower(term.Field)]
+	if !ok {
+		return sqlCondition{}, query.Errorf(term.FieldSpan, "unknown field %q, expected one of %s",
+			term.Field, strings.Join(taskQueryFieldNames(), ", "))
+	}
+	if !containsOp(field.ops, term.Op) {
+		return sqlCondition{}, query.Errorf(term.OpSpan, "operator %q is not supported for %s", term.Op, term.Field)
+	}
+
+	cond, err := field.compile(c, term)
+	if err != nil {
+		return sqlCondition{}, err
+	}
+	if term.Op == query.OpNotEqual && !isComparisonField(field) {
+		return cond.negate(), nil
+	}
+	return cond, nil
+}
+
+// compileText memakai pencarian yang sama dengan TaskListRequest.Search;
+// frasa bertanda kutip dicari sebagai frasa utuh
+func compileText(text *query.Text) sqlCondition {
+	search := text.Value
+	if text.Phrase {
+		search = `"` + strings.ReplaceAll(text.Value, `"`, "") + `"`
+	}
+	return condition(searchCondition, search, text.Value, "%"+escapeLike(text.Value)+"%")
+}
+
+func taskQueryFieldNames() []string {
+	names := make([]string, 0, len(taskQueryFields))
+	for name := range taskQueryFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func containsOp(ops []query.Op, op query.Op) bool {
+	for _, allowed := range ops {
+		if allowed == op {
+			return true
+		}
+	}
+	return false
+}
+
+// isComparisonField true jika field menangani != sendiri (angka dan
+// tanggal); field lain cukup dibalik setelah dikompilasi sebagai ":"
+func isComparisonField(field taskQueryField) bool {
+	return containsOp(field.ops, query.OpLess)
+}
+
+// values memecah nilai berkoma (status:todo,in_progress) menjadi daftar
+func values(term *query.Term) []string {
+	parts := strings.Split(term.Value, ",")
+	result := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
+
+func invalidValue(term *query.Term, format string, args ...interface{}) error {
+	return query.Errorf(term.ValueSpan, format, args...)
+}
+
+// anyOf menggabungkan kondisi untuk setiap nilai berkoma dengan OR
+func anyOf(term *query.Term, compile func(value string) (sqlCondition, error)) (sqlCondition, error) {
+	items := values(term)
+	if len(items) == 0 {
+		return sqlCondition{}, invalidValue(term, "missing value for %s", term.Field)
+	}
+
+	conditions := make([]sqlCondition, len(items))
+	for i, item := range items {
+		cond, err := compile(item)
+		if err != nil {
+			return sqlCondition{}, err
+		}
+		conditions[i] = cond
+	}
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+	return joinConditions(conditions, "OR"), nil
+}
+
+func compileStatus(c *taskQueryCompiler, term *query.Term) (sqlCondition, error) {
+	return anyOf(term, func(value string) (sqlCondition, error) {
+		switch status := strings.ToLower(value); status {
+		case models.TaskStatusTodo, models.TaskStatusInProgress, models.TaskStatusDone:
+			return condition(`"Tasks".status = ?`, status), nil
+		case "open":
+			return condition(taskQueryIs["open"]), nil
+		default:
+			return sqlCondition{}, invalidValue(term, "invalid status %q, expected todo, in_progress, done or open", value)
+		}
+	})
+}
+
+// compilePriority: P0 paling mendesak, jadi priority<P2 berarti P0 dan P1
+func compilePriority(c *taskQueryCompiler, term *query.Term) (sqlCondition, error) {
+	if term.Op == query.OpMatch || term.Op == query.OpEqual || term.Op == query.OpNotEqual {
+		cond, err := anyOf(term, func(value string) (sqlCondition, error) {
+			priority, err := models.ParsePriority(value)
+			if err != nil {
+				return sqlCondition{}, invalidValue(term, "%s", err.Error())
+			}
+			return condition(`"Tasks".priority = ?`, priority), nil
+		})
+		if err == nil && term.Op == query.OpNotEqual {
+			cond = cond.negate()
+		}
+		return cond, err
+	}
+
+	priority, err := models.ParsePriority(term.Value)
+	if err != nil {
+		return sqlCondition{}, invalidValue(term, "%s", err.Error())
+	}
+	return condition(`"Tasks".priority `+string(term.Op)+` ?`, priority), nil
+}
+
+func compileNumberField(column string) func(c *taskQueryCompiler, term *query.Term) (sqlCondition, error) {
+	return func(c *taskQueryCompiler, term *query.Term) (sqlCondition, error) {
+		id, err := strconv.ParseUint(term.Value, 10, 32)
+		if err != nil {
+			return sqlCondition{}, invalidValue(term, "invalid number %q", term.Value)
+		}
+		op := string(term.Op)
+		if term.Op == query.OpMatch {
+			op = "="
+		}
+		return condition(column+" "+op+" ?", id), nil
+	}
+}
+
+// accountCondition menerjemahkan me, id atau email menjadi kondisi pada
+// kolom id akun
+func (c *taskQueryCompiler) accountCondition(term *query.Term, value, column string) (sqlCondition, error) {
+	if strings.EqualFold(value, "me") {
+		if !c.hasAccount {
+			return sqlCondition{}, invalidValue(term, `"me" requires an authenticated user`)
+		}
+		return condition(column+" = ?", c.accountID), nil
+	}
+	if id, err := strconv.ParseUint(value, 10, 32); err == nil {
+		return condition(column+" = ?", id), nil
+	}
+	if strings.Contains(value, "@") {
+		return condition(column+" IN (SELECT id FROM accounts WHERE lower(email) = lower(?))", value), nil
+	}
+	return sqlCondition{}, invalidValue(term, "invalid account %q, expected me, an account id or an email", value)
+}
+
+func compileParticipant(table string) func(c *taskQueryCompiler, term *query.Term) (sqlCondition, error) {
+	return func(c *taskQueryCompiler, term *query.Term) (sqlCondition, error) {
+		exists := `EXISTS (SELECT 1 FROM ` + table + ` p WHERE p.task_id = "Tasks".id`
+		return anyOf(term, func(value string) (sqlCondition, error) {
+			if strings.EqualFold(value, "none") {
+				return condition("NOT " + exists + ")"), nil
+			}
+			cond, err := c.accountCondition(term, value, "p.account_id")
+			if err != nil {
+				return sqlCondition{}, err
+			}
+			return condition(exists+" AND "+cond.SQL+")", cond.Vars...), nil
+		})
+	}
+}
+
+func compileCreator(c *taskQueryCompiler, term *query.Term) (sqlCondition, error) {
+	return anyOf(term, func(value string) (sqlCondition, error) {
+		return c.accountCondition(term, value, `"Tasks".create_accounts_id`)
+	})
+}
+
+// compileLabel mencocokkan nama label (tanpa membedakan huruf besar) atau id
+func compileLabel(c *taskQueryCompiler, term *query.Term) (sqlCondition, error) {
+	return anyOf(term, func(value string) (sqlCondition, error) {
+		if strings.EqualFold(value, "none") {
+			return condition(`NOT EXISTS (SELECT 1 FROM task_labels tl WHERE tl.task_id = "Tasks".id)`), nil
+		}
+		if id, err := strconv.ParseUint(value, 10, 32); err == nil {
+			return condition(`EXISTS (SELECT 1 FROM task_labels tl WHERE tl.task_id = "Tasks".id AND tl.label_id = ?)`, id), nil
+		}
+		return condition(`EXISTS (SELECT 1 FROM task_labels tl JOIN labels l ON l.id = tl.label_id
+			WHERE tl.task_id = "Tasks".id AND lower(l.name) = lower(?))`, value), nil
+	})
+}
+
+// compileProject mencocokkan key project (misalnya WEB) atau id
+func compileProject(c *taskQueryCompiler, term *query.Term) (sqlCondition, error) {
+	return anyOf(term, func(value string) (sqlCondition, error) {
+		if strings.EqualFold(value, "none") {
+			return condition(`"Tasks".project_id IS NULL`), nil
+		}
+		if id, err := strconv.ParseUint(value, 10, 32); err == nil {
+			return condition(`"Tasks".project_id = ?`, id), nil
+		}
+		return condition(`"Tasks".project_id IN (SELECT id FROM projects
+			WHERE upper(key) = upper(?) AND workspace_id = "Tasks".workspace_id)`, value), nil
+	})
+}
+
+func compileParent(c *taskQueryCompiler, term *query.Term) (sqlCondition, error) {
+	return anyOf(term, func(value string) (sqlCondition, error) {
+		if strings.EqualFold(value, "none") {
+			return condition(`"Tasks".parent_id IS NULL`), nil
+		}
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return sqlCondition{}, invalidValue(term, "invalid task id %q", value)
+		}
+		return condition(`"Tasks".parent_id = ?`, id), nil
+	})
+}
+
+// relativeDate adalah offset dari hari ini, misalnya 7d, -2w, 3m
+var relativeDate = regexp.MustCompile(`^([+-]?\d+)([dwmy])$`)
+
+// parseDay mengubah nilai tanggal menjadi rentang satu hari (UTC):
+// YYYY-MM-DD, today, tomorrow, yesterday, atau offset relatif
+func (c *taskQueryCompiler) parseDay(value string) (time.Time, bool) {
+	today := time.Date(c.now.Year(), c.now.Month(), c.now.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch strings.ToLower(value) {
+	case "today":
+		return today, true
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true
+	}
+
+	if match := relativeDate.FindStringSubmatch(strings.ToLower(value)); match != nil {
+		n, err := strconv.Atoi(match[1])
+		if err != nil {
+			return time.Time{}, false
+		}
+		switch match[2] {
+		case "d":
+			return today.AddDate(0, 0, n), true
+		case "w":
+			return today.AddDate(0, 0, 7*n), true
+		case "m":
+			return today.AddDate(0, n, 0), true
+		default:
+			return today.AddDate(n, 0, 0), true
+		}
+	}
+
+	day, err := time.Parse("2006-01-02", value)
+	return day, err == nil
+}
+
+// compileDateField membandingkan kolom dengan satu hari penuh: due:today
+// berarti sepanjang hari ini, due<7d berarti sebelum hari ke-7 dari
+// sekarang. optional true untuk kolom yang memakai zero time sebagai
+// "tidak ada" (deadline), yang selalu dikecualikan dari perbandingan.
+func compileDateField(column string, optional bool) func(c *taskQueryCompiler, term *query.Term) (sqlCondition, error) {
+	return func(c *taskQueryCompiler, term *query.Term) (sqlCondition, error) {
+		if optional && strings.EqualFold(term.Value, "none") {
+			switch term.Op {
+			case query.OpMatch, query.OpEqual:
+				return condition(column + " <= '0001-01-01'"), nil
+			case query.OpNotEqual:
+				return condition(column + " > '0001-01-01'"), nil
+			}
+			return sqlCondition{}, query.Errorf(term.OpSpan, "operator %q is not supported with none", term.Op)
+		}
+
+		start, ok := c.parseDay(term.Value)
+		if !ok {
+			return sqlCondition{}, invalidValue(term, "invalid date %q, expected YYYY-MM-DD, today, tomorrow, yesterday or an offset such as 7d, -2w, 3m", term.Value)
+		}
+		end := start.AddDate(0, 0, 1)
+
+		var cond sqlCondition
+		switch term.Op {
+		case query.OpLess:
+			cond = condition(column+" < ?", start)
+		case query.OpLessEqual:
+			cond = condition(column+" < ?", end)
+		case query.OpGreater:
+			cond = condition(column+" >= ?", end)
+		case query.OpGreaterEqual:
+			cond = condition(column+" >= ?", start)
+		case query.OpNotEqual:
+			cond = condition(column+" < ? OR "+column+" >= ?", start, end)
+		default:
+			cond = condition(column+" >= ? AND "+column+" < ?", start, end)
+		}
+
+		if optional {
+			cond = condition(column+" > '0001-01-01' AND ("+cond.SQL+")", cond.Vars...)
+		}
+		return cond, nil
+	}
+}
+
+func compileIs(c *taskQueryCompiler, term *query.Term) (sqlCondition, error) {
+	return compileFlag(term, taskQueryIs)
+}
+
+func compileHas(c *taskQueryCompiler, term *query.Term) (sqlCondition, error) {
+	return compileFlag(term, taskQueryHas)
+}
+
+func compileFlag(term *query.Term, flags map[string]string) (sqlCondition, error) {
+	sql, ok := flags[strings.ToLower(term.Value)]
+	if !ok {
+		names := make([]string, 0, len(flags))
+		for name := range flags {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return sqlCondition{}, invalidValue(term, "invalid value %q for %s, expected one of %s",
+			term.Value, term.Field, strings.Join(names, ", "))
+	}
+	return condition(sql), nil
+}
+
+func compileContains(column string) func(c *taskQueryCompiler, term *query.Term) (sqlCondition, error) {
+	return func(c *taskQueryCompiler, term *query.Term) (sqlCondition, error) {
+		return condition(column+` ILIKE ?`, "%"+escapeLike(term.Value)+"%"), nil
+	}
+}
+
+// likeEscaper meng-escape wildcard LIKE supaya nilai dicari apa adanya
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
@@ -3,32 +3,239 @@ package repository
 import (
 	"backend/internal/dto"
 	"backend/internal/models"
+	"backend/internal/utils"
 	"context"
+	"errors"
 	"strconv"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// openBlockerExists bernilai true jika task masih punya blocker yang belum done
+const openBlockerExists = `EXISTS (
+	SELECT 1 FROM task_dependencies d
+	JOIN "Tasks" blocker ON blocker.id = d.blocked_by_id
+	WHERE d.task_id = "Tasks".id AND blocker.status IS DISTINCT FROM 'done'
+)`
+
+// preloadTaskRelations memuat relasi yang selalu ikut di response task.
+// Preload gorm berjalan per relasi (bukan per baris), jadi tidak N+1.
+func preloadTaskRelations(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("CreateUser").
+		Preload("UpdateUser").
+		Preload("Account").
+		Preload("Assignees", func(db *gorm.DB) *gorm.DB {
+			return db.Order("accounts.name asc")
+		}).
+		Preload("Watchers", func(db *gorm.DB) *gorm.DB {
+			return db.Order("accounts.name asc")
+		}).
+		Preload("Project").
+		Preload("Labels", func(db *gorm.DB) *gorm.DB {
+			return db.Order("labels.name asc")
+		})
+}
+
+// visibleTasks membatasi task yang boleh dilihat akun di context: task tanpa
+// project terlihat oleh semua akun, task di dalam project hanya oleh member-nya
+func visibleTasks(ctx context.Context) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		accountID, ok := utils.AccountIDFromContext(ctx)
+		if !ok {
+			return db
+		}
+		return db.Where(`"Tasks".project_id IS NULL OR "Tasks".project_id IN (
+			SELECT project_id FROM project_members WHERE accounts_id = ?
+		)`, accountID)
+	}
+}
+
+// filterByLabels membatasi task berdasarkan label: "all" berarti task harus
+// memiliki semua label, selain itu cukup salah satu (any)
+func filterByLabels(db *gorm.DB, labelIDs []uint, match string) *gorm.DB {
+	if len(labelIDs) == 0 {
+		return db
+	}
+
+	if match == dto.LabelMatchAll {
+		return db.Where(`(
+			SELECT COUNT(DISTINCT tl.label_id) FROM task_labels tl
+			WHERE tl.task_id = "Tasks".id AND tl.label_id IN ?
+		) = ?`, labelIDs, len(uniqueIDs(labelIDs)))
+	}
+
+	return db.Where(`EXISTS (
+		SELECT 1 FROM task_labels tl
+		WHERE tl.task_id = "Tasks".id AND tl.label_id IN ?
+	)`, labelIDs)
+}
+
+// filterByParticipants membatasi task yang di-assign ke atau di-watch oleh akun tertentu
+func filterByParticipants(db *gorm.DB, assigneeID, watcherID *uint) *gorm.DB {
+	if assigneeID != nil {
+		db = db.Where(`EXISTS (
+			SELECT 1 FROM task_assignees ta WHERE ta.task_id = "Tasks".id AND ta.account_id = ?
+		)`, *assigneeID)
+	}
+	if watcherID != nil {
+		db = db.Where(`EXISTS (
+			SELECT 1 FROM task_watchers tw WHERE tw.task_id = "Tasks".id AND tw.account_id = ?
+		)`, *watcherID)
+	}
+	return db
+}
+
+// saveTaskParticipants menyimpan assignee utama, Assignees dan Watchers task
+// yang baru dibuat ke tabel relasinya
+func saveTaskParticipants(tx *gorm.DB, task *models.Task) error {
+	assigneeIDs := []uint{task.AccountID}
+	for _, assignee := range task.Assignees {
+		assigneeIDs = append(assigneeIDs, assignee.ID)
+	}
+	if err := insertParticipants(tx, "task_assignees", task.ID, assigneeIDs); err != nil {
+		return err
+	}
+
+	watcherIDs := make([]uint, 0, len(task.Watchers))
+	for _, watcher := range task.Watchers {
+		watcherIDs = append(watcherIDs, watcher.ID)
+	}
+	return insertParticipants(tx, "task_watchers", task.ID, watcherIDs)
+}
+
+// saveInitialStatus mencatat status awal task yang baru dibuat
+func saveInitialStatus(tx *gorm.DB, task *models.Task) error {
+	return tx.Create(&models.TaskStatusChange{
+		WorkspaceID: task.WorkspaceID,
+		TaskID:      task.ID,
+		Status:      task.Status,
+		ChangedAt:   task.CreatedAt,
+		AccountID:   &task.CreateAccountID,
+	}).Error
+}
+
+// recordStatusChange mencatat task masuk ke status, hanya jika statusnya
+// memang berubah. Harus dipanggil sebelum kolom status di-update, di
+// transaksi yang sama.
+func recordStatusChange(tx *gorm.DB, taskID uint, status string, accountID *uint) error {
+	return tx.Exec(`INSERT INTO task_status_changes (workspace_id, task_id, status, changed_at, accounts_id)
+		SELECT workspace_id, id, ?, ?, ? FROM "Tasks"
+		WHERE id = ? AND status IS DISTINCT FROM ?`,
+		status, time.Now(), accountID, taskID, status).Error
+}
+
+// insertParticipants hanya menambahkan akun yang member workspace task.
+// Raw query tidak dibatasi WorkspaceScopePlugin, jadi batas workspace
+// ditegakkan di query ini sendiri.
+func insertParticipants(tx *gorm.DB, table string, taskID uint, accountIDs []uint) error {
+	if len(accountIDs) == 0 {
+		return nil
+	}
+	return tx.Exec(`INSERT INTO `+table+` (task_id, account_id)
+		SELECT t.id, m.accounts_id FROM "Tasks" t
+		JOIN workspace_members m ON m.workspace_id = t.workspace_id
+		WHERE t.id = ? AND m.accounts_id IN ?
+		ON CONFLICT DO NOTHING`, taskID, accountIDs).Error
+}
+
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// rankLockKey adalah key advisory lock postgres (bersama workspace id) untuk
+// menserialisasi perubahan rank dalam satu workspace
+const rankLockKey int32 = 270002
+
+// rankRebalanceLength adalah panjang rank maksimal sebelum kolom di-rebalance
+const rankRebalanceLength = 24
+
+var ErrInvalidNeighbor = errors.New("neighbor task is not in the target column")
+
+// ErrLastAssignee berarti task harus tetap punya minimal satu assignee
+var ErrLastAssignee = errors.New("task must keep at least one assignee")
+
+// ErrVersionConflict berarti task sudah diubah sejak version yang dibaca
+var ErrVersionConflict = errors.New("task has been modified by another request")
+
 type TaskRepository interface {
 	Create(ctx context.Context, task *models.Task) error
 	GetAll(ctx context.Context, req *dto.TaskListRequest) ([]models.Task, int64, error)
+	// GetPage adalah GetAll dengan keyset pagination (mode cursor)
+	GetPage(ctx context.Context, req *dto.TaskListRequest) ([]models.Task, *dto.TaskPageInfo, error)
+	Export(ctx context.Context, req *dto.TaskListRequest, fn func(row dto.TaskExportRow) error) error
+	// GetStats dan GetSeries menghitung agregat untuk /task/stats di SQL
+	GetStats(ctx context.Context, req *dto.TaskListRequest, now, weekStart, weekEnd time.Time) (*dto.TaskStatsResponse, error)
+	GetSeries(ctx context.Context, req *dto.TaskListRequest, bucket, timezone string, from, to time.Time) (map[string]dto.TaskSeriesPoint, error)
+	GetWithDeadline(ctx context.Context, req *dto.TaskListRequest, statuses []string, limit int) ([]models.Task, error)
 	GetByStatus(ctx context.Context, status string) ([]models.Task, error)
 	GetByID(ctx context.Context, id uint) (*models.Task, error)
-	Update(ctx context.Context, task *models.Task) error
+	Update(ctx context.Context, task *models.Task, fields map[string]interface{}) error
 	Delete(ctx context.Context, id uint) error
 	GetByFilter(ctx context.Context, req dto.TaskFilterRequest) ([]models.Task, error)
+	GetSubtasks(ctx context.Context, parentID uint) ([]models.Task, error)
+	GetProgress(ctx context.Context, ids []uint) (map[uint]dto.TaskProgress, error)
+	GetTimeSpent(ctx context.Context, ids []uint) (map[uint]int64, error)
+	GetHighlights(ctx context.Context, ids []uint, search string) (map[uint]dto.TaskHighlight, error)
+	IsAncestor(ctx context.Context, ancestorID, taskID uint) (bool, error)
+	AttachLabels(ctx context.Context, taskID uint, labelIDs []uint) error
+	DetachLabels(ctx context.Context, taskID uint, labelIDs []uint) error
+	AddAssignees(ctx context.Context, taskID uint, accountIDs []uint) error
+	RemoveAssignee(ctx context.Context, taskID, accountID uint) error
+	AddWatchers(ctx context.Context, taskID uint, accountIDs []uint) error
+	RemoveWatcher(ctx context.Context, taskID, accountID uint) error
+	NextRank(ctx context.Context, workspaceID uint, status string) (string, error)
+	Move(ctx context.Context, task *models.Task, status string, beforeID, afterID *uint, userID uint) error
+	RebalanceRanks(ctx context.Context, workspaceID uint, status string) error
+	GetBoard(ctx context.Context, req dto.BoardRequest) ([]models.Task, error)
+	GetIDsByFilter(ctx context.Context, req dto.TaskFilterRequest, limit int) ([]uint, error)
+	LockByIDs(ctx context.Context, ids []uint) ([]models.Task, error)
+	Reassign(ctx context.Context, taskID, accountID, userID uint) error
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type taskRepository struct {
-	db *gorm.DB
+	*BaseRepository
 }
 
 func NewTaskRepository(db *gorm.DB) TaskRepository {
-	return &taskRepository{db: db}
+	return &taskRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
 }
 
 func (r *taskRepository) Create(ctx context.Context, task *models.Task) error {
-	return r.db.WithContext(ctx).Create(task).Error
+	if task.IsDone() && task.CompletedAt == nil {
+		now := time.Now()
+		task.CompletedAt = &now
+	}
+	// workspace diisi lebih awal karena rank dihitung per workspace
+	if task.WorkspaceID == 0 {
+		task.WorkspaceID, _ = utils.WorkspaceIDFromContext(ctx)
+	}
+	return r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := assignRank(tx, task); err != nil {
+			return err
+		}
+		if err := tx.Omit("Assignees", "Watchers").Create(task).Error; err != nil {
+			return err
+		}
+		if err := saveInitialStatus(tx, task); err != nil {
+			return err
+		}
+		return saveTaskParticipants(tx, task)
+	})
 }
 
 func (r *taskRepository) GetAll(ctx context.Context, req *dto.TaskListRequest) ([]models.Task, int64, error) {
@@ -38,22 +245,14 @@ func (r *taskRepository) GetAll(ctx context.Context, req *dto.TaskListRequest) (
 	offset := (pages - 1) * limits
 	var tasks []models.Task
 
-	queryBuilder := r.db.WithContext(ctx).Model(&models.Task{})
-
-	if req.Search != nil {
-		queryBuilder = queryBuilder.Where("title ILIKE ? OR description ILIKE ?",
-			"%"+*req.Search+"%",
-			"%"+*req.Search+"%",
-		)
-	}
-
-	if req.Status != nil {
-		queryBuilder = queryBuilder.Where("status = ?", *req.Status)
+	order, err := listOrder(req)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	if req.StartDate != nil && req.EndDate != nil {
-		queryBuilder = queryBuilder.Where("deadline >= ? AND deadline <= ?", *req.StartDate, *req.EndDate)
-	}
+	queryBuilder := r.conn(ctx).
+		Model(&models.Task{}).
+		Scopes(visibleTasks(ctx), listTasks(ctx, req))
 
 	prosesCount := queryBuilder.Count(&count_)
 	if prosesCount.Error != nil {
@@ -61,12 +260,10 @@ func (r *taskRepository) GetAll(ctx context.Context, req *dto.TaskListRequest) (
 	}
 
 	proses := queryBuilder.
-		Preload("CreateUser").
-		Preload("UpdateUser").
-		Preload("Account").
+		Scopes(preloadTaskRelations).
 		Limit(limits).
 		Offset(offset).
-		Order(req.Order).
+		Order(order).
 		Find(&tasks)
 
 	if proses.Error != nil {
@@ -76,12 +273,139 @@ func (r *taskRepository) GetAll(ctx context.Context, req *dto.TaskListRequest) (
 	return tasks, count_, nil
 }
 
+// listTasks menerapkan filter TaskListRequest, dipakai list dan export
+func listTasks(ctx context.Context, req *dto.TaskListRequest) func(db *gorm.DB) *gorm.DB {
+	return func(queryBuilder *gorm.DB) *gorm.DB {
+		if req.Query != "" {
+			queryBuilder = filterByQuery(ctx, queryBuilder, req.Query)
+		}
+
+		if req.ProjectID != nil {
+			queryBuilder = queryBuilder.Where("project_id = ?", *req.ProjectID)
+		}
+
+		if search := searchText(req); search != "" {
+			queryBuilder = queryBuilder.Scopes(searchTasks(search))
+		}
+
+		if req.Status != nil {
+			queryBuilder = queryBuilder.Where("status = ?", *req.Status)
+		}
+
+		if len(req.Priorities) > 0 {
+			queryBuilder = queryBuilder.Where("priority IN ?", req.Priorities)
+		}
+
+		queryBuilder = filterByLabels(queryBuilder, req.LabelIDs, req.LabelMatch)
+		queryBuilder = filterByParticipants(queryBuilder, req.AssigneeID, req.WatcherID)
+
+		if req.ParentID != nil {
+			queryBuilder = queryBuilder.Where("parent_id = ?", *req.ParentID)
+		} else if req.RootOnly {
+			queryBuilder = queryBuilder.Where("parent_id IS NULL")
+		}
+
+		// ready = belum done dan semua blocker sudah done
+		if req.Ready != nil {
+			if *req.Ready {
+				queryBuilder = queryBuilder.
+					Where("status IS DISTINCT FROM ?", models.TaskStatusDone).
+					Where("NOT " + openBlockerExists)
+			} else {
+				queryBuilder = queryBuilder.Where(openBlockerExists)
+			}
+		}
+
+		// overdue_at diisi oleh worker reminder
+		if req.Overdue != nil {
+			if *req.Overdue {
+				queryBuilder = queryBuilder.Where("overdue_at IS NOT NULL")
+			} else {
+				queryBuilder = queryBuilder.Where("overdue_at IS NULL")
+			}
+		}
+
+		if req.StartDate != nil && req.EndDate != nil {
+			queryBuilder = queryBuilder.Where("deadline >= ? AND deadline <= ?", *req.StartDate, *req.EndDate)
+		}
+
+		return queryBuilder
+	}
+}
+
+// exportColumns memilih kolom task beserta relasinya dalam bentuk datar
+// (subquery, bukan join, supaya filter list tidak ambigu)
+const exportColumns = `"Tasks".id, "Tasks".title, "Tasks".description, "Tasks".status,
+	"Tasks".priority, "Tasks".deadline, "Tasks".estimate_minutes, "Tasks".overdue_at,
+	"Tasks".project_id, "Tasks".parent_id, "Tasks".version,
+	(SELECT p.name FROM projects p WHERE p.id = "Tasks".project_id) AS project_name,
+	(SELECT string_agg(a.email, ',' ORDER BY a.email) FROM task_assignees ta
+		JOIN accounts a ON a.id = ta.account_id WHERE ta.task_id = "Tasks".id) AS assignees,
+	(SELECT string_agg(l.name, ',' ORDER BY l.name) FROM task_labels tl
+		JOIN labels l ON l.id = tl.label_id WHERE tl.task_id = "Tasks".id) AS labels`
+
+// Export menjalankan query list tanpa pagination dan memanggil fn untuk
+// setiap baris langsung dari cursor database, jadi memori tetap kecil
+// walaupun jumlah task besar. Berhenti di error pertama dari fn.
+func (r *taskRepository) Export(ctx context.Context, req *dto.TaskListRequest, fn func(row dto.TaskExportRow) error) error {
+	order, err := listOrder(req)
+	if err != nil {
+		return err
+	}
+
+	db := r.conn(ctx)
+	rows, err := db.
+		Model(&models.Task{}).
+		Select(exportColumns).
+		Scopes(visibleTasks(ctx), listTasks(ctx, req)).
+		Order(order).
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row dto.TaskExportRow
+		if err := db.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// GetWithDeadline mengambil task yang punya deadline untuk kalender,
+// deadline terbaru lebih dulu. statuses kosong berarti semua status.
+func (r *taskRepository) GetWithDeadline(ctx context.Context, req *dto.TaskListRequest, statuses []string, limit int) ([]models.Task, error) {
+	var tasks []models.Task
+	queryBuilder := r.conn(ctx).
+		Scopes(visibleTasks(ctx), listTasks(ctx, req)).
+		Preload("Labels", func(db *gorm.DB) *gorm.DB {
+			return db.Order("labels.name asc")
+		}).
+		Where("deadline > ?", time.Time{})
+
+	if len(statuses) > 0 {
+		queryBuilder = queryBuilder.Where("status IN ?", statuses)
+	}
+
+	err := queryBuilder.
+		Order("deadline desc, id desc").
+		Limit(limit).
+		Find(&tasks).Error
+	return tasks, err
+}
+
 func (r *taskRepository) GetByID(ctx context.Context, id uint) (*models.Task, error) {
 	var task models.Task
-	err := r.db.WithContext(ctx).
-		Preload("CreateUser").
-		Preload("UpdateUser").
-		Preload("Account").
+	err := r.conn(ctx).
+		Scopes(visibleTasks(ctx), preloadTaskRelations).
+		Preload("ChecklistItems", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position asc, id asc")
+		}).
 		First(&task, id).Error
 	if err != nil {
 		return nil, err
@@ -89,20 +413,64 @@ func (r *taskRepository) GetByID(ctx context.Context, id uint) (*models.Task, er
 	return &task, nil
 }
 
-func (r *taskRepository) Update(ctx context.Context, task *models.Task) error {
-	return r.db.WithContext(ctx).Save(task).Error
+// Update hanya menyimpan kolom di fields dengan UPDATE ... WHERE version = ?
+// lalu menaikkan version task. ErrVersionConflict jika task sudah diubah
+// request lain sejak dibaca.
+func (r *taskRepository) Update(ctx context.Context, task *models.Task, fields map[string]interface{}) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	updates := make(map[string]interface{}, len(fields)+1)
+	for column, value := range fields {
+		updates[column] = value
+	}
+	updates["version"] = gorm.Expr("version + 1")
+	// fields hanya berisi kolom yang berubah, jadi status di sini selalu
+	// status baru
+	if status, ok := fields["status"]; ok {
+		if status == models.TaskStatusDone {
+			updates["completed_at"] = time.Now()
+		} else {
+			updates["completed_at"] = nil
+		}
+	}
+
+	err := r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if status, ok := fields["status"].(string); ok {
+			if err := recordStatusChange(tx, task.ID, status, task.UpdateAccountID); err != nil {
+				return err
+			}
+		}
+
+		result := tx.
+			Model(&models.Task{}).
+			Where("id = ? AND version = ?", task.ID, task.Version).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrVersionConflict
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	task.Version++
+	return nil
 }
 
 func (r *taskRepository) Delete(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Delete(&models.Task{}, id).Error
+	return r.conn(ctx).Delete(&models.Task{}, id).Error
 }
 
 func (r *taskRepository) GetByStatus(ctx context.Context, status string) ([]models.Task, error) {
 	var tasks []models.Task
-	err := r.db.WithContext(ctx).
-		Preload("CreateUser").
-		Preload("UpdateUser").
-		Preload("Account").
+	err := r.conn(ctx).
+		Scopes(visibleTasks(ctx), preloadTaskRelations).
 		Where("status = ?", status).
 		Find(&tasks).Error
 	return tasks, err
@@ -110,24 +478,508 @@ func (r *taskRepository) GetByStatus(ctx context.Context, status string) ([]mode
 
 func (r *taskRepository) GetByFilter(ctx context.Context, req dto.TaskFilterRequest) ([]models.Task, error) {
 	var tasks []models.Task
+	err := r.conn(ctx).
+		Scopes(visibleTasks(ctx), preloadTaskRelations, filterTasks(ctx, req)).
+		Find(&tasks).Error
+	return tasks, err
+}
 
-	queryBuilder := r.db.WithContext(ctx).
-		Preload("CreateUser").
-		Preload("UpdateUser").
-		Preload("Account")
+// GetIDsByFilter mengambil id task yang cocok dengan filter, maksimal limit
+func (r *taskRepository) GetIDsByFilter(ctx context.Context, req dto.TaskFilterRequest, limit int) ([]uint, error) {
+	var ids []uint
+	err := r.conn(ctx).
+		Model(&models.Task{}).
+		Scopes(visibleTasks(ctx), filterTasks(ctx, req)).
+		Order("id asc").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// filterTasks menerapkan TaskFilterRequest ke query task
+func filterTasks(ctx context.Context, req dto.TaskFilterRequest) func(db *gorm.DB) *gorm.DB {
+	return func(queryBuilder *gorm.DB) *gorm.DB {
+		if req.Query != "" {
+			queryBuilder = filterByQuery(ctx, queryBuilder, req.Query)
+		}
+
+		if req.ProjectID != nil {
+			queryBuilder = queryBuilder.Where("project_id = ?", *req.ProjectID)
+		}
+
+		if req.Status != nil {
+			queryBuilder = queryBuilder.Where("status = ?", *req.Status)
+		}
+
+		queryBuilder = filterByLabels(queryBuilder, req.LabelIDs, req.LabelMatch)
+		queryBuilder = filterByParticipants(queryBuilder, req.AssigneeID, req.WatcherID)
+
+		if req.StartDate != nil && req.EndDate != nil {
+			queryBuilder = queryBuilder.Where("deadline >= ? AND deadline <= ?", *req.StartDate, *req.EndDate)
+		} else if req.StartDate != nil {
+			queryBuilder = queryBuilder.Where("deadline >= ?", *req.StartDate)
+		} else if req.EndDate != nil {
+			queryBuilder = queryBuilder.Where("deadline <= ?", *req.EndDate)
+		}
+
+		return queryBuilder
+	}
+}
+
+func (r *taskRepository) GetSubtasks(ctx context.Context, parentID uint) ([]models.Task, error) {
+	var tasks []models.Task
+	err := r.conn(ctx).
+		Scopes(visibleTasks(ctx), preloadTaskRelations).
+		Where("parent_id = ?", parentID).
+		Order("id asc").
+		Find(&tasks).Error
+	return tasks, err
+}
+
+// GetProgress menghitung jumlah subtask dan checklist item (selesai / total) untuk
+// setiap task dalam ids dengan dua query agregat, tanpa N+1
+func (r *taskRepository) GetProgress(ctx context.Context, ids []uint) (map[uint]dto.TaskProgress, error) {
+	progress := make(map[uint]dto.TaskProgress, len(ids))
+	if len(ids) == 0 {
+		return progress, nil
+	}
+
+	type childCount struct {
+		OwnerID uint
+		Total   int64
+		Done    int64
+	}
+
+	var subtasks []childCount
+	err := r.conn(ctx).
+		Model(&models.Task{}).
+		Select("parent_id AS owner_id, COUNT(*) AS total, COUNT(*) FILTER (WHERE status = ?) AS done", models.TaskStatusDone).
+		Where("parent_id IN ?", ids).
+		Group("parent_id").
+		Scan(&subtasks).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var checklist []childCount
+	err = r.conn(ctx).
+		Model(&models.ChecklistItem{}).
+		Select("task_id AS owner_id, COUNT(*) AS total, COUNT(*) FILTER (WHERE is_done) AS done").
+		Where("task_id IN ?", ids).
+		Group("task_id").
+		Scan(&checklist).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range subtasks {
+		p := progress[row.OwnerID]
+		p.SubtasksTotal = row.Total
+		p.SubtasksDone = row.Done
+		progress[row.OwnerID] = p
+	}
+	for _, row := range checklist {
+		p := progress[row.OwnerID]
+		p.ChecklistTotal = row.Total
+		p.ChecklistDone = row.Done
+		progress[row.OwnerID] = p
+	}
+
+	for id, p := range progress {
+		p.TotalCount = p.SubtasksTotal + p.ChecklistTotal
+		p.CompletedCount = p.SubtasksDone + p.ChecklistDone
+		if p.TotalCount > 0 {
+			p.Percent = int(p.CompletedCount * 100 / p.TotalCount)
+		}
+		progress[id] = p
+	}
+
+	return progress, nil
+}
+
+// GetTimeSpent menjumlahkan waktu kerja (detik) setiap task dalam ids,
+// termasuk timer yang masih berjalan
+func (r *taskRepository) GetTimeSpent(ctx context.Context, ids []uint) (map[uint]int64, error) {
+	spent := make(map[uint]int64, len(ids))
+	if len(ids) == 0 {
+		return spent, nil
+	}
+
+	var totals []struct {
+		TaskID  uint
+		Seconds int64
+	}
+	err := r.conn(ctx).
+		Model(&models.WorkLog{}).
+		Select("task_id, SUM("+workLogSeconds+")::bigint AS seconds").
+		Where("task_id IN ?", ids).
+		Group("task_id").
+		Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, total := range totals {
+		spent[total.TaskID] = total.Seconds
+	}
+	return spent, nil
+}
+
+// IsAncestor mengecek apakah ancestorID berada di rantai parent milik taskID
+// (termasuk taskID itu sendiri). Dipakai untuk mencegah siklus parent/child.
+func (r *taskRepository) IsAncestor(ctx context.Context, ancestorID, taskID uint) (bool, error) {
+	var count int64
+	err := r.conn(ctx).Raw(`
+		WITH RECURSIVE chain AS (
+			SELECT id, parent_id FROM "Tasks" WHERE id = ?
+			UNION
+			SELECT t.id, t.parent_id FROM "Tasks" t JOIN chain c ON t.id = c.parent_id
+		)
+		SELECT COUNT(*) FROM chain WHERE id = ?`, taskID, ancestorID).
+		Scan(&count).Error
+	return count > 0, err
+}
+
+// AttachLabels hanya memasang label dari workspace yang sama dengan task,
+// seperti insertParticipants
+func (r *taskRepository) AttachLabels(ctx context.Context, taskID uint, labelIDs []uint) error {
+	if len(labelIDs) == 0 {
+		return nil
+	}
+	return r.conn(ctx).Exec(`
+		INSERT INTO task_labels (task_id, label_id)
+		SELECT t.id, l.id FROM "Tasks" t
+		JOIN labels l ON l.workspace_id = t.workspace_id
+		WHERE t.id = ? AND l.id IN ?
+		ON CONFLICT DO NOTHING`, taskID, labelIDs).Error
+}
+
+func (r *taskRepository) DetachLabels(ctx context.Context, taskID uint, labelIDs []uint) error {
+	if len(labelIDs) == 0 {
+		return nil
+	}
+	return r.conn(ctx).
+		Exec("DELETE FROM task_labels WHERE task_id = ? AND label_id IN ?", taskID, labelIDs).Error
+}
+
+func (r *taskRepository) AddAssignees(ctx context.Context, taskID uint, accountIDs []uint) error {
+	return insertParticipants(r.conn(ctx), "task_assignees", taskID, accountIDs)
+}
+
+// RemoveAssignee melepas assignee dari task. Jika yang dilepas adalah
+// assignee utama (accounts_id), assignee lain dipromosikan menggantikannya.
+func (r *taskRepository) RemoveAssignee(ctx context.Context, taskID, accountID uint) error {
+	return r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		var task models.Task
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&task, taskID).Error; err != nil {
+			return err
+		}
+
+		if task.AccountID == accountID {
+			var replacement uint
+			err := tx.Raw(`SELECT account_id FROM task_assignees
+				WHERE task_id = ? AND account_id <> ? ORDER BY account_id LIMIT 1`, taskID, accountID).
+				Scan(&replacement).Error
+			if err != nil {
+				return err
+			}
+			if replacement == 0 {
+				return ErrLastAssignee
+			}
+			err = tx.Model(&task).Updates(map[string]interface{}{
+				"accounts_id": replacement,
+				"version":     gorm.Expr("version + 1"),
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		result := tx.Exec("DELETE FROM task_assignees WHERE task_id = ? AND account_id = ?", taskID, accountID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func (r *taskRepository) AddWatchers(ctx context.Context, taskID uint, accountIDs []uint) error {
+	return insertParticipants(r.conn(ctx), "task_watchers", taskID, accountIDs)
+}
+
+func (r *taskRepository) RemoveWatcher(ctx context.Context, taskID, accountID uint) error {
+	result := r.conn(ctx).
+		Exec("DELETE FROM task_watchers WHERE task_id = ? AND account_id = ?", taskID, accountID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// lockRanks mengambil advisory lock rank untuk workspace. Workspace selalu
+// dikirim eksplisit karena migrasi dan worker berjalan tanpa workspace di
+// context.
+func lockRanks(tx *gorm.DB, workspaceID uint) error {
+	return tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", rankLockKey, int32(workspaceID)).Error
+}
+
+// rankColumn adalah task di kolom status milik workspace
+func rankColumn(tx *gorm.DB, workspaceID uint, status string) *gorm.DB {
+	return tx.Model(&models.Task{}).Where("workspace_id = ? AND status = ?", workspaceID, status)
+}
+
+// lastRank mengembalikan rank terbesar di kolom status, kosong jika kolom kosong
+func lastRank(tx *gorm.DB, workspaceID uint, status string) (string, error) {
+	var rank *string
+	err := rankColumn(tx, workspaceID, status).
+		Select("MAX(rank)").
+		Scan(&rank).Error
+	if err != nil || rank == nil {
+		return "", err
+	}
+	return *rank, nil
+}
+
+// assignRank menaruh task baru di akhir kolom status-nya jika belum punya rank
+func assignRank(tx *gorm.DB, task *models.Task) error {
+	if task.Rank != "" {
+		return nil
+	}
+	if err := lockRanks(tx, task.WorkspaceID); err != nil {
+		return err
+	}
+
+	last, err := lastRank(tx, task.WorkspaceID, task.Status)
+	if err != nil {
+		return err
+	}
+	if len(last) >= rankRebalanceLength {
+		if err := rebalanceRanks(tx, task.WorkspaceID, task.Status); err != nil {
+			return err
+		}
+		if last, err = lastRank(tx, task.WorkspaceID, task.Status); err != nil {
+			return err
+		}
+	}
+
+	task.Rank = utils.RankBetween(last, "")
+	return nil
+}
+
+// rebalanceRanks memberi ulang rank berjarak merata untuk seluruh kolom
+// status dengan tetap mempertahankan urutannya. Task tanpa rank ditaruh
+// di akhir kolom.
+func rebalanceRanks(tx *gorm.DB, workspaceID uint, status string) error {
+	var ids []uint
+	err := rankColumn(tx, workspaceID, status).
+		Order("rank asc nulls last, id asc").
+		Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return err
+	}
+
+	ranks := utils.EvenRanks(len(ids))
+	for start := 0; start < len(ids); start += 500 {
+		end := start + 500
+		if end > len(ids) {
+			end = len(ids)
+		}
 
-	if req.Status != nil {
-		queryBuilder = queryBuilder.Where("status = ?", *req.Status)
+		values := make([]string, 0, end-start)
+		args := make([]interface{}, 0, (end-start)*2)
+		for i := start; i < end; i++ {
+			values = append(values, "(?::bigint, ?)")
+			args = append(args, ids[i], ranks[i])
+		}
+
+		err := tx.Exec(`UPDATE "Tasks" SET rank = v.rank
+			FROM (VALUES `+strings.Join(values, ", ")+`) AS v(id, rank)
+			WHERE "Tasks".id = v.id`, args...).Error
+		if err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	if req.StartDate != nil && req.EndDate != nil {
-		queryBuilder = queryBuilder.Where("deadline >= ? AND deadline <= ?", *req.StartDate, *req.EndDate)
-	} else if req.StartDate != nil {
-		queryBuilder = queryBuilder.Where("deadline >= ?", *req.StartDate)
-	} else if req.EndDate != nil {
-		queryBuilder = queryBuilder.Where("deadline <= ?", *req.EndDate)
+// NextRank mengembalikan rank untuk menaruh task di akhir kolom status
+func (r *taskRepository) NextRank(ctx context.Context, workspaceID uint, status string) (string, error) {
+	last, err := lastRank(r.conn(ctx), workspaceID, status)
+	if err != nil {
+		return "", err
 	}
+	return utils.RankBetween(last, ""), nil
+}
 
-	err := queryBuilder.Find(&tasks).Error
+// Move memindahkan task ke kolom status di antara dua tetangga. Tetangga
+// dibaca ulang di dalam lock, jadi jika BeforeID diisi task ditaruh tepat
+// setelahnya walaupun urutan di client sudah basi. Kolom di-rebalance jika
+// rank sudah terlalu panjang atau ada rank kembar.
+func (r *taskRepository) Move(ctx context.Context, task *models.Task, status string, beforeID, afterID *uint, userID uint) error {
+	return r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockRanks(tx, task.WorkspaceID); err != nil {
+			return err
+		}
+
+		rank, err := rankBetweenNeighbors(tx, task.WorkspaceID, task.ID, status, beforeID, afterID)
+		if errors.Is(err, errRankCollision) || (err == nil && len(rank) > rankRebalanceLength) {
+			if err := rebalanceRanks(tx, task.WorkspaceID, status); err != nil {
+				return err
+			}
+			rank, err = rankBetweenNeighbors(tx, task.WorkspaceID, task.ID, status, beforeID, afterID)
+		}
+		if err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{
+			"status":             status,
+			"rank":               rank,
+			"update_accounts_id": userID,
+			"version":            gorm.Expr("version + 1"),
+		}
+		if status == models.TaskStatusDone {
+			updates["overdue_at"] = nil
+			// pindah urutan di dalam kolom done tidak mengubah waktu selesai
+			updates["completed_at"] = gorm.Expr("CASE WHEN status = ? THEN completed_at ELSE ? END", models.TaskStatusDone, time.Now())
+		} else {
+			updates["completed_at"] = nil
+		}
+		if err := recordStatusChange(tx, task.ID, status, &userID); err != nil {
+			return err
+		}
+		return tx.Model(&models.Task{}).Where("id = ?", task.ID).Updates(updates).Error
+	})
+}
+
+// LockByIDs mengambil task yang terlihat beserta relasinya dan mengunci
+// barisnya (SELECT ... FOR UPDATE) sampai transaksi di ctx selesai
+func (r *taskRepository) LockByIDs(ctx context.Context, ids []uint) ([]models.Task, error) {
+	var tasks []models.Task
+	err := r.conn(ctx).
+		Scopes(visibleTasks(ctx), preloadTaskRelations).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(`"Tasks".id IN ?`, ids).
+		Order("id asc").
+		Find(&tasks).Error
+	return tasks, err
+}
+
+// Reassign mengganti semua assignee task dengan satu akun sebagai assignee utama
+func (r *taskRepository) Reassign(ctx context.Context, taskID, accountID, userID uint) error {
+	return r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.Task{}).
+			Where("id = ?", taskID).
+			Updates(map[string]interface{}{
+				"accounts_id":        accountID,
+				"update_accounts_id": userID,
+				"version":            gorm.Expr("version + 1"),
+			}).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM task_assignees WHERE task_id = ?", taskID).Error; err != nil {
+			return err
+		}
+		return insertParticipants(tx, "task_assignees", taskID, []uint{accountID})
+	})
+}
+
+var errRankCollision = errors.New("neighbor ranks collide")
+
+func rankBetweenNeighbors(tx *gorm.DB, workspaceID, taskID uint, status string, beforeID, afterID *uint) (string, error) {
+	column := func() *gorm.DB {
+		return rankColumn(tx, workspaceID, status).Where("id <> ? AND rank IS NOT NULL", taskID)
+	}
+	neighborRank := func(id uint) (string, error) {
+		var ranks []string
+		if err := column().Where("id = ?", id).Pluck("rank", &ranks).Error; err != nil {
+			return "", err
+		}
+		if len(ranks) == 0 {
+			return "", ErrInvalidNeighbor
+		}
+		return ranks[0], nil
+	}
+
+	var lower, upper string
+	var err error
+	switch {
+	case beforeID != nil:
+		if lower, err = neighborRank(*beforeID); err != nil {
+			return "", err
+		}
+		var next *string
+		if err := column().Select("MIN(rank)").Where("rank > ?", lower).Scan(&next).Error; err != nil {
+			return "", err
+		}
+		if next != nil {
+			upper = *next
+		}
+	case afterID != nil:
+		if upper, err = neighborRank(*afterID); err != nil {
+			return "", err
+		}
+		var previous *string
+		if err := column().Select("MAX(rank)").Where("rank < ?", upper).Scan(&previous).Error; err != nil {
+			return "", err
+		}
+		if previous != nil {
+			lower = *previous
+		}
+	default:
+		var last *string
+		if err := column().Select("MAX(rank)").Scan(&last).Error; err != nil {
+			return "", err
+		}
+		if last != nil {
+			lower = *last
+		}
+	}
+
+	// rank kembar (misalnya dua task dibuat bersamaan) tidak menyisakan ruang
+	if upper != "" && lower >= upper {
+		return "", errRankCollision
+	}
+	if beforeID != nil && afterID != nil {
+		if _, err := neighborRank(*afterID); err != nil {
+			return "", err
+		}
+	}
+
+	return utils.RankBetween(lower, upper), nil
+}
+
+func (r *taskRepository) RebalanceRanks(ctx context.Context, workspaceID uint, status string) error {
+	return r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockRanks(tx, workspaceID); err != nil {
+			return err
+		}
+		return rebalanceRanks(tx, workspaceID, status)
+	})
+}
+
+// GetBoard mengambil task untuk board, terurut per kolom status lalu rank
+func (r *taskRepository) GetBoard(ctx context.Context, req dto.BoardRequest) ([]models.Task, error) {
+	var tasks []models.Task
+
+	queryBuilder := r.conn(ctx).
+		Scopes(visibleTasks(ctx), preloadTaskRelations)
+
+	if req.ProjectID != nil {
+		queryBuilder = queryBuilder.Where("project_id = ?", *req.ProjectID)
+	}
+	queryBuilder = filterByLabels(queryBuilder, req.LabelIDs, req.LabelMatch)
+	queryBuilder = filterByParticipants(queryBuilder, req.AssigneeID, nil)
+
+	err := queryBuilder.
+		Order("status asc, rank asc nulls last, id asc").
+		Find(&tasks).Error
 	return tasks, err
 }
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"context"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// TaskSearchConfig adalah konfigurasi text search postgres untuk kolom
+// search_vector. Query harus memakai konfigurasi yang sama supaya stem kata
+// cocok dengan yang tersimpan di index.
+const TaskSearchConfig = "english"
+
+// Penanda awal dan akhir kata yang cocok di hasil GetHighlights. Sengaja
+// bukan HTML supaya service bisa meng-escape teks task terlebih dulu.
+const (
+	SearchHighlightStart = "\x02"
+	SearchHighlightStop  = "\x03"
+)
+
+// TaskSearchMigrations membuat kolom tsvector (title berbobot A, description
+// B) beserta index GIN-nya, dan index trigram untuk pencarian typo di title.
+// Aman dijalankan berulang kali.
+var TaskSearchMigrations = []string{
+	`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+	fmt.Sprintf(`ALTER TABLE "Tasks" ADD COLUMN IF NOT EXISTS search_vector tsvector
+		GENERATED ALWAYS AS (
+			setweight(to_tsvector('%[1]s', coalesce(title, '')), 'A') ||
+			setweight(to_tsvector('%[1]s', coalesce(description, '')), 'B')
+		) STORED`, TaskSearchConfig),
+	`CREATE INDEX IF NOT EXISTS idx_tasks_search_vector ON "Tasks" USING GIN (search_vector)`,
+	`CREATE INDEX IF NOT EXISTS idx_tasks_title_trgm ON "Tasks" USING GIN (title gin_trgm_ops)`,
+}
+
+// searchQuery mengubah input user dengan sintaks websearch ("frasa",
+// -kata, or) menjadi tsquery
+var searchQuery = fmt.Sprintf(`websearch_to_tsquery('%s', ?)`, TaskSearchConfig)
+
+// searchText mengembalikan teks pencarian yang sudah di-trim, kosong
+// berarti tidak ada pencarian
+func searchText(req *dto.TaskListRequest) string {
+	if req.Search == nil {
+		return ""
+	}
+	return strings.TrimSpace(*req.Search)
+}
+
+// searchCondition mencocokkan task lewat full-text search, dengan fallback
+// trigram (<% memakai pg_trgm.word_similarity_threshold) supaya typo di
+// title tetap ketemu, dan ILIKE untuk potongan kata di title. Ketiganya
+// memakai index. Parameter: teks websearch, teks asli, pola LIKE.
+var searchCondition = `"Tasks".search_vector @@ ` + searchQuery + `
+	OR ? <% "Tasks".title
+	OR "Tasks".title ILIKE ?`
+
+func searchTasks(search string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("("+searchCondition+")", search, search, "%"+escapeLike(search)+"%")
+	}
+}
+
+// searchOrderKeys mengurutkan hasil pencarian dari yang paling relevan:
+// rank full-text (dinormalisasi panjang dokumen), lalu kemiripan trigram
+// title
+func searchOrderKeys(search string) []orderKey {
+	return []orderKey{
+		{Field: "relevance", Expr: `ts_rank_cd("Tasks".search_vector, ` + searchQuery + `, 1)`, Vars: []interface{}{search}, Desc: true},
+		{Field: "similarity", Expr: `word_similarity(?, "Tasks".title)`, Vars: []interface{}{search}, Desc: true},
+		{Field: "id", Expr: taskOrderColumns["id"], Desc: true},
+	}
+}
+
+// headlineOptions untuk ts_headline: title ditampilkan utuh, description
+// dipotong menjadi beberapa fragmen di sekitar kata yang cocok
+var (
+	titleHeadlineOptions = fmt.Sprintf(`StartSel="%s", StopSel="%s", HighlightAll=true`,
+		SearchHighlightStart, SearchHighlightStop)
+	descriptionHeadlineOptions = fmt.Sprintf(`StartSel="%s", StopSel="%s", MaxWords=30, MinWords=10, MaxFragments=2, FragmentDelimiter=" … "`,
+		SearchHighlightStart, SearchHighlightStop)
+)
+
+// GetHighlights membuat cuplikan title dan description untuk task di ids
+// dengan kata yang cocok ditandai SearchHighlightStart/Stop. ts_headline
+// mahal, jadi hanya dijalankan untuk satu halaman hasil.
+func (r *taskRepository) GetHighlights(ctx context.Context, ids []uint, search string) (map[uint]dto.TaskHighlight, error) {
+	highlights := make(map[uint]dto.TaskHighlight, len(ids))
+	if len(ids) == 0 || search == "" {
+		return highlights, nil
+	}
+
+	var rows []struct {
+		ID          uint
+		Title       string
+		Description string
+	}
+	headline := fmt.Sprintf(`ts_headline('%s', %%s, `+searchQuery+`, ?)`, TaskSearchConfig)
+	err := r.conn(ctx).
+		Model(&models.Task{}).
+		Select(`id, `+fmt.Sprintf(headline, "title")+` AS title,
+			CASE WHEN coalesce(description, '') = '' THEN ''
+			ELSE `+fmt.Sprintf(headline, "description")+` END AS description`,
+			search, titleHeadlineOptions, search, descriptionHeadlineOptions).
+		Where("id IN ?", ids).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		highlights[row.ID] = dto.TaskHighlight{Title: row.Title, Description: row.Description}
+	}
+	return highlights, nil
+}
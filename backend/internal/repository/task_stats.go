@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// statsTasks adalah task yang dihitung di stats: terlihat oleh akun di
+// context dan cocok dengan filter list
+func (r *taskRepository) statsTasks(ctx context.Context, req *dto.TaskListRequest) *gorm.DB {
+	return r.conn(ctx).
+		Model(&models.Task{}).
+		Scopes(visibleTasks(ctx), listTasks(ctx, req))
+}
+
+// GetStats menghitung ringkasan task per status, priority dan assignee.
+// Overdue dihitung dari deadline terhadap now, due this week dari deadline
+// di [weekStart, weekEnd); keduanya hanya untuk task yang belum done.
+func (r *taskRepository) GetStats(ctx context.Context, req *dto.TaskListRequest, now, weekStart, weekEnd time.Time) (*dto.TaskStatsResponse, error) {
+	stats := &dto.TaskStatsResponse{}
+
+	err := r.statsTasks(ctx, req).
+		Select(`COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE "Tasks".status = ?) AS done,
+			COUNT(*) FILTER (WHERE "Tasks".status IS DISTINCT FROM ?
+				AND "Tasks".deadline > '0001-01-01' AND "Tasks".deadline < ?) AS overdue,
+			COUNT(*) FILTER (WHERE "Tasks".status IS DISTINCT FROM ?
+				AND "Tasks".deadline >= ? AND "Tasks".deadline < ?) AS due_this_week`,
+			models.TaskStatusDone,
+			models.TaskStatusDone, now,
+			models.TaskStatusDone, weekStart, weekEnd).
+		Scan(&stats.TaskStatsCounts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.statsTasks(ctx, req).
+		Select(`"Tasks".status AS status, COUNT(*) AS count`).
+		Group(`"Tasks".status`).
+		Order(`"Tasks".status`).
+		Scan(&stats.ByStatus).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.statsTasks(ctx, req).
+		Select(`"Tasks".priority AS priority, COUNT(*) AS count`).
+		Group(`"Tasks".priority`).
+		Order(`"Tasks".priority`).
+		Scan(&stats.ByPriority).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.conn(ctx).
+		Table("task_assignees ta").
+		Select("a.id AS account_id, a.name AS name, COUNT(*) AS count").
+		Joins("JOIN accounts a ON a.id = ta.account_id").
+		Where("ta.task_id IN (?)", r.statsTasks(ctx, req).Select(`"Tasks".id`)).
+		Group("a.id, a.name").
+		Order("count DESC, a.name ASC").
+		Scan(&stats.ByAssignee).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// GetSeries menghitung task yang dibuat dan yang selesai per bucket (day
+// atau week, minggu mulai Senin) di timezone yang diberikan, untuk waktu
+// di [from, to), dengan key tanggal awal bucket. Bucket tanpa task tidak
+// ada di map.
+func (r *taskRepository) GetSeries(ctx context.Context, req *dto.TaskListRequest, bucket, timezone string, from, to time.Time) (map[string]dto.TaskSeriesPoint, error) {
+	type bucketCount struct {
+		Bucket time.Time
+		Count  int64
+	}
+
+	countBy := func(column string) ([]bucketCount, error) {
+		column = `"Tasks".` + column
+		var counts []bucketCount
+		err := r.statsTasks(ctx, req).
+			Select("date_trunc(?, "+column+" AT TIME ZONE ?) AS bucket, COUNT(*) AS count", bucket, timezone).
+			Where(column+" >= ? AND "+column+" < ?", from, to).
+			Group("bucket").
+			Scan(&counts).Error
+		return counts, err
+	}
+
+	created, err := countBy("created_at")
+	if err != nil {
+		return nil, err
+	}
+	completed, err := countBy("completed_at")
+	if err != nil {
+		return nil, err
+	}
+
+	points := make(map[string]dto.TaskSeriesPoint, len(created))
+	for _, row := range created {
+		date := row.Bucket.Format("2006-01-02")
+		p := points[date]
+		p.Created = row.Count
+		points[date] = p
+	}
+	for _, row := range completed {
+		date := row.Bucket.Format("2006-01-02")
+		p := points[date]
+		p.Completed = row.Count
+		points[date] = p
+	}
+	return points, nil
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type TemplateRepository interface {
+	Create(ctx context.Context, template *models.TaskTemplate) error
+	GetAll(ctx context.Context, search *string) ([]models.TaskTemplate, error)
+	GetByID(ctx context.Context, id uint) (*models.TaskTemplate, error)
+	GetByName(ctx context.Context, name string) (*models.TaskTemplate, error)
+	Update(ctx context.Context, template *models.TaskTemplate, replaceLabels, replaceSubtasks bool) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type templateRepository struct {
+	*BaseRepository
+}
+
+func NewTemplateRepository(db *gorm.DB) TemplateRepository {
+	return &templateRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+func preloadTemplateRelations(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Labels", func(db *gorm.DB) *gorm.DB {
+			return db.Order("labels.name asc")
+		}).
+		Preload("Subtasks", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position asc, id asc")
+		})
+}
+
+// Create menyimpan template beserta label dan subtask-nya
+func (r *templateRepository) Create(ctx context.Context, template *models.TaskTemplate) error {
+	return r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Labels", "Subtasks").Create(template).Error; err != nil {
+			return err
+		}
+		if err := saveTemplateLabels(tx, template); err != nil {
+			return err
+		}
+		return saveTemplateSubtasks(tx, template)
+	})
+}
+
+func (r *templateRepository) GetAll(ctx context.Context, search *string) ([]models.TaskTemplate, error) {
+	var templates []models.TaskTemplate
+	queryBuilder := r.conn(ctx).Scopes(preloadTemplateRelations).Order("name asc")
+	if search != nil {
+		queryBuilder = queryBuilder.Where("name ILIKE ?", "%"+*search+"%")
+	}
+	err := queryBuilder.Find(&templates).Error
+	return templates, err
+}
+
+func (r *templateRepository) GetByID(ctx context.Context, id uint) (*models.TaskTemplate, error) {
+	var template models.TaskTemplate
+	err := r.conn(ctx).Scopes(preloadTemplateRelations).First(&template, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// GetByName mencari template tanpa membedakan huruf besar/kecil
+func (r *templateRepository) GetByName(ctx context.Context, name string) (*models.TaskTemplate, error) {
+	var template models.TaskTemplate
+	err := r.conn(ctx).Where("LOWER(name) = LOWER(?)", name).First(&template).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+// Update menyimpan field template; label dan subtask hanya diganti jika diminta
+func (r *templateRepository) Update(ctx context.Context, template *models.TaskTemplate, replaceLabels, replaceSubtasks bool) error {
+	return r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Labels", "Subtasks").Save(template).Error; err != nil {
+			return err
+		}
+
+		if replaceLabels {
+			if err := tx.Exec("DELETE FROM task_template_labels WHERE task_template_id = ?", template.ID).Error; err != nil {
+				return err
+			}
+			if err := saveTemplateLabels(tx, template); err != nil {
+				return err
+			}
+		}
+
+		if replaceSubtasks {
+			if err := tx.Where("template_id = ?", template.ID).Delete(&models.TaskTemplateSubtask{}).Error; err != nil {
+				return err
+			}
+			if err := saveTemplateSubtasks(tx, template); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *templateRepository) Delete(ctx context.Context, id uint) error {
+	return r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM task_template_labels WHERE task_template_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("template_id = ?", id).Delete(&models.TaskTemplateSubtask{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.TaskTemplate{}, id).Error
+	})
+}
+
+func saveTemplateLabels(tx *gorm.DB, template *models.TaskTemplate) error {
+	for _, label := range template.Labels {
+		err := tx.Exec(`INSERT INTO task_template_labels (task_template_id, label_id)
+			VALUES (?, ?) ON CONFLICT DO NOTHING`, template.ID, label.ID).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func saveTemplateSubtasks(tx *gorm.DB, template *models.TaskTemplate) error {
+	if len(template.Subtasks) == 0 {
+		return nil
+	}
+	for i := range template.Subtasks {
+		template.Subtasks[i].ID = 0
+		template.Subtasks[i].TemplateID = template.ID
+		template.Subtasks[i].Position = i
+	}
+	return tx.Create(&template.Subtasks).Error
+}
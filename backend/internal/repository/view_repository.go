@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type ViewRepository interface {
+	Create(ctx context.Context, view *models.SavedView) error
+	// GetAll mengembalikan view yang boleh dilihat akun, opsional hanya
+	// yang dibagikan ke projectID
+	GetAll(ctx context.Context, accountID uint, projectID *uint) ([]models.SavedView, error)
+	GetByID(ctx context.Context, id, accountID uint) (*models.SavedView, error)
+	Update(ctx context.Context, view *models.SavedView) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type viewRepository struct {
+	*BaseRepository
+}
+
+func NewViewRepository(db *gorm.DB) ViewRepository {
+	return &viewRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// visibleViews membatasi view ke milik akun sendiri, view workspace, dan
+// view project yang akun-nya menjadi member
+func visibleViews(accountID uint) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(`(saved_views.owner_accounts_id = ?
+			OR saved_views.visibility = ?
+			OR (saved_views.visibility = ? AND saved_views.project_id IN (
+				SELECT project_id FROM project_members WHERE accounts_id = ?
+			)))`, accountID, models.ViewVisibilityWorkspace, models.ViewVisibilityProject, accountID)
+	}
+}
+
+func (r *viewRepository) Create(ctx context.Context, view *models.SavedView) error {
+	return r.conn(ctx).Omit("Owner", "Project").Create(view).Error
+}
+
+func (r *viewRepository) GetAll(ctx context.Context, accountID uint, projectID *uint) ([]models.SavedView, error) {
+	var views []models.SavedView
+	queryBuilder := r.conn(ctx).Scopes(visibleViews(accountID)).Preload("Owner").Order("name asc, id asc")
+	if projectID != nil {
+		queryBuilder = queryBuilder.Where("visibility = ? AND project_id = ?", models.ViewVisibilityProject, *projectID)
+	}
+	err := queryBuilder.Find(&views).Error
+	return views, err
+}
+
+func (r *viewRepository) GetByID(ctx context.Context, id, accountID uint) (*models.SavedView, error) {
+	var view models.SavedView
+	err := r.conn(ctx).Scopes(visibleViews(accountID)).Preload("Owner").First(&view, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &view, nil
+}
+
+func (r *viewRepository) Update(ctx context.Context, view *models.SavedView) error {
+	return r.conn(ctx).Omit("Owner", "Project").Save(view).Error
+}
+
+func (r *viewRepository) Delete(ctx context.Context, id uint) error {
+	return r.conn(ctx).Delete(&models.SavedView{}, id).Error
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrTimerRunning berarti akun sudah punya timer yang berjalan
+	ErrTimerRunning = errors.New("another timer is already running")
+	// ErrTimerNotRunning berarti tidak ada timer berjalan yang bisa dihentikan
+	ErrTimerNotRunning = errors.New("no running timer for this task")
+)
+
+// workLogSeconds menghitung durasi work log; timer yang masih berjalan
+// dihitung sampai sekarang
+const workLogSeconds = `CASE WHEN work_logs.ended_at IS NULL
+	THEN EXTRACT(EPOCH FROM (NOW() - work_logs.started_at))::bigint
+	ELSE work_logs.duration_seconds END`
+
+type WorkLogRepository interface {
+	Start(ctx context.Context, log *models.WorkLog) error
+	Stop(ctx context.Context, log *models.WorkLog, endedAt time.Time) error
+	Create(ctx context.Context, log *models.WorkLog) error
+	GetByID(ctx context.Context, id uint) (*models.WorkLog, error)
+	GetRunning(ctx context.Context, accountID uint) (*models.WorkLog, error)
+	GetByTaskID(ctx context.Context, taskID uint) ([]models.WorkLog, error)
+	Delete(ctx context.Context, id uint) error
+	GetTimesheet(ctx context.Context, accountID uint, from, to time.Time) ([]dto.TimesheetRow, error)
+}
+
+type workLogRepository struct {
+	*BaseRepository
+}
+
+func NewWorkLogRepository(db *gorm.DB) WorkLogRepository {
+	return &workLogRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// Start menyimpan timer baru. Insert yang bentrok dengan partial unique
+// index timer berjalan diabaikan, jadi dua start bersamaan tidak bisa
+// menghasilkan dua timer.
+func (r *workLogRepository) Start(ctx context.Context, log *models.WorkLog) error {
+	result := r.conn(ctx).
+		Omit("Task", "Account").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(log)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTimerRunning
+	}
+	return nil
+}
+
+// Stop menghentikan timer jika masih berjalan dan menghitung durasinya
+func (r *workLogRepository) Stop(ctx context.Context, log *models.WorkLog, endedAt time.Time) error {
+	duration := int64(endedAt.Sub(log.StartedAt).Seconds())
+	if duration < 0 {
+		duration = 0
+	}
+
+	result := r.conn(ctx).
+		Model(&models.WorkLog{}).
+		Where("id = ? AND ended_at IS NULL", log.ID).
+		Updates(map[string]interface{}{
+			"ended_at":         endedAt,
+			"duration_seconds": duration,
+			"note":             log.Note,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTimerNotRunning
+	}
+
+	log.EndedAt = &endedAt
+	log.DurationSeconds = duration
+	return nil
+}
+
+func (r *workLogRepository) Create(ctx context.Context, log *models.WorkLog) error {
+	return r.conn(ctx).Omit("Task", "Account").Create(log).Error
+}
+
+func (r *workLogRepository) GetByID(ctx context.Context, id uint) (*models.WorkLog, error) {
+	var log models.WorkLog
+	err := r.conn(ctx).Preload("Account").First(&log, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &log, nil
+}
+
+// GetRunning mengembalikan timer akun yang sedang berjalan, nil jika tidak ada
+func (r *workLogRepository) GetRunning(ctx context.Context, accountID uint) (*models.WorkLog, error) {
+	var log models.WorkLog
+	err := r.conn(ctx).
+		Preload("Task").
+		Where("accounts_id = ? AND ended_at IS NULL", accountID).
+		First(&log).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &log, nil
+}
+
+func (r *workLogRepository) GetByTaskID(ctx context.Context, taskID uint) ([]models.WorkLog, error) {
+	var logs []models.WorkLog
+	err := r.conn(ctx).
+		Preload("Account").
+		Where("task_id = ?", taskID).
+		Order("started_at desc, id desc").
+		Find(&logs).Error
+	return logs, err
+}
+
+func (r *workLogRepository) Delete(ctx context.Context, id uint) error {
+	return r.conn(ctx).Delete(&models.WorkLog{}, id).Error
+}
+
+// GetTimesheet menjumlahkan waktu kerja akun per hari (UTC) dan per task
+// untuk work log yang dimulai di rentang [from, to)
+func (r *workLogRepository) GetTimesheet(ctx context.Context, accountID uint, from, to time.Time) ([]dto.TimesheetRow, error) {
+	var rows []dto.TimesheetRow
+	err := r.conn(ctx).
+		Model(&models.WorkLog{}).
+		Select(`(work_logs.started_at AT TIME ZONE 'UTC')::date AS day, work_logs.task_id,
+			t.title, t.project_id, SUM(`+workLogSeconds+`)::bigint AS seconds`).
+		Joins(`JOIN "Tasks" t ON t.id = work_logs.task_id`).
+		Where("work_logs.accounts_id = ? AND work_logs.started_at >= ? AND work_logs.started_at < ?", accountID, from, to).
+		Group("day, work_logs.task_id, t.title, t.project_id").
+		Order("day asc, work_logs.task_id asc").
+		Scan(&rows).Error
+	return rows, err
+}
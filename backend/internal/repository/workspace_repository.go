@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WorkspaceRepository interface {
+	Create(ctx context.Context, workspace *models.Workspace) error
+	GetByID(ctx context.Context, id uint) (*models.Workspace, error)
+	GetBySlug(ctx context.Context, slug string) (*models.Workspace, error)
+	GetAllForAccount(ctx context.Context, accountID uint) ([]models.Workspace, error)
+	GetDefaultForAccount(ctx context.Context, accountID uint) (*models.Workspace, error)
+	Update(ctx context.Context, workspace *models.Workspace) error
+	GetMembers(ctx context.Context, workspaceID uint) ([]models.WorkspaceMember, error)
+	GetMember(ctx context.Context, workspaceID, accountID uint) (*models.WorkspaceMember, error)
+	SaveMember(ctx context.Context, member *models.WorkspaceMember) error
+	RemoveMember(ctx context.Context, workspaceID, accountID uint) error
+}
+
+type workspaceRepository struct {
+	*BaseRepository
+}
+
+func NewWorkspaceRepository(db *gorm.DB) WorkspaceRepository {
+	return &workspaceRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// Create menyimpan workspace beserta owner sebagai member pertama dalam satu transaksi
+func (r *workspaceRepository) Create(ctx context.Context, workspace *models.Workspace) error {
+	return r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Owner").Create(workspace).Error; err != nil {
+			return err
+		}
+
+		owner := &models.WorkspaceMember{
+			WorkspaceID: workspace.ID,
+			AccountID:   workspace.OwnerID,
+			Role:        models.WorkspaceRoleOwner,
+		}
+		return tx.Create(owner).Error
+	})
+}
+
+func (r *workspaceRepository) GetByID(ctx context.Context, id uint) (*models.Workspace, error) {
+	var workspace models.Workspace
+	err := r.conn(ctx).
+		Preload("Owner").
+		First(&workspace, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &workspace, nil
+}
+
+func (r *workspaceRepository) GetBySlug(ctx context.Context, slug string) (*models.Workspace, error) {
+	var workspace models.Workspace
+	err := r.conn(ctx).Where("slug = ?", slug).First(&workspace).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &workspace, nil
+}
+
+func (r *workspaceRepository) GetAllForAccount(ctx context.Context, accountID uint) ([]models.Workspace, error) {
+	var workspaces []models.Workspace
+	err := r.conn(ctx).
+		Preload("Owner").
+		Where("id IN (SELECT workspace_id FROM workspace_members WHERE accounts_id = ?)", accountID).
+		Order("name asc").
+		Find(&workspaces).Error
+	return workspaces, err
+}
+
+// GetDefaultForAccount mengembalikan workspace pertama yang diikuti akun, nil jika tidak ada
+func (r *workspaceRepository) GetDefaultForAccount(ctx context.Context, accountID uint) (*models.Workspace, error) {
+	var workspace models.Workspace
+	err := r.conn(ctx).
+		Joins("JOIN workspace_members ON workspace_members.workspace_id = workspaces.id").
+		Where("workspace_members.accounts_id = ?", accountID).
+		Order("workspace_members.created_at asc, workspaces.id asc").
+		First(&workspace).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &workspace, nil
+}
+
+func (r *workspaceRepository) Update(ctx context.Context, workspace *models.Workspace) error {
+	return r.conn(ctx).Omit("Owner").Save(workspace).Error
+}
+
+func (r *workspaceRepository) GetMembers(ctx context.Context, workspaceID uint) ([]models.WorkspaceMember, error) {
+	var members []models.WorkspaceMember
+	err := r.conn(ctx).
+		Preload("Account").
+		Where("workspace_id = ?", workspaceID).
+		Order("created_at asc").
+		Find(&members).Error
+	return members, err
+}
+
+// GetMember mengembalikan nil, nil jika akun bukan member workspace
+func (r *workspaceRepository) GetMember(ctx context.Context, workspaceID, accountID uint) (*models.WorkspaceMember, error) {
+	var member models.WorkspaceMember
+	err := r.conn(ctx).
+		Where("workspace_id = ? AND accounts_id = ?", workspaceID, accountID).
+		First(&member).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+// SaveMember menambah member baru atau mengubah role member yang sudah ada
+func (r *workspaceRepository) SaveMember(ctx context.Context, member *models.WorkspaceMember) error {
+	return r.conn(ctx).
+		Omit("Workspace", "Account").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "workspace_id"}, {Name: "accounts_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"role"}),
+		}).
+		Create(member).Error
+}
+
+// RemoveMember mengeluarkan akun dari workspace beserta semua project di
+// dalamnya, dan mencabut app password serta token kalender akun untuk
+// workspace tersebut
+func (r *workspaceRepository) RemoveMember(ctx context.Context, workspaceID, accountID uint) error {
+	return r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`
+			DELETE FROM project_members
+			WHERE accounts_id = ? AND project_id IN (SELECT id FROM projects WHERE workspace_id = ?)`,
+			accountID, workspaceID).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Exec("DELETE FROM app_passwords WHERE workspace_id = ? AND accounts_id = ?", workspaceID, accountID).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Exec("DELETE FROM calendar_tokens WHERE workspace_id = ? AND accounts_id = ?", workspaceID, accountID).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.
+			Where("workspace_id = ? AND accounts_id = ?", workspaceID, accountID).
+			Delete(&models.WorkspaceMember{}).Error
+	})
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"backend/internal/utils"
+	"errors"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+const workspaceScopedSetting = "workspace:scoped"
+
+var ErrCrossWorkspace = errors.New("record belongs to another workspace")
+
+var workspaceScopedType = reflect.TypeOf((*models.WorkspaceScoped)(nil)).Elem()
+
+// WorkspaceScopePlugin membatasi setiap query terhadap model WorkspaceScoped ke
+// workspace yang tersimpan di context (lihat utils.WithWorkspaceID). Pembatasan
+// dilakukan lewat callback gorm, jadi repository tidak perlu (dan tidak bisa lupa)
+// menambahkan filter workspace_id sendiri.
+//
+// Query Raw/Exec tanpa Model tidak ikut dibatasi, jadi hanya boleh dipakai
+// untuk id yang sudah lolos query yang dibatasi.
+type WorkspaceScopePlugin struct{}
+
+func (WorkspaceScopePlugin) Name() string {
+	return "workspace_scope"
+}
+
+func (p WorkspaceScopePlugin) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+
+	if err := callback.Create().Before("gorm:create").Register("workspace:create", p.assignWorkspace); err != nil {
+		return err
+	}
+	if err := callback.Query().Before("gorm:query").Register("workspace:query", p.restrictWorkspace); err != nil {
+		return err
+	}
+	if err := callback.Row().Before("gorm:row").Register("workspace:row", p.restrictWorkspace); err != nil {
+		return err
+	}
+	if err := callback.Update().Before("gorm:update").Register("workspace:update", p.restrictWorkspace); err != nil {
+		return err
+	}
+	return callback.Delete().Before("gorm:delete").Register("workspace:delete", p.restrictWorkspace)
+}
+
+// assignWorkspace mengisi workspace_id record baru dari context dan menolak
+// record yang secara eksplisit diarahkan ke workspace lain
+func (p WorkspaceScopePlugin) assignWorkspace(db *gorm.DB) {
+	field, workspaceID, ok := p.scopedField(db)
+	if !ok {
+		return
+	}
+
+	assign := func(rv reflect.Value) {
+		value, isZero := field.ValueOf(db.Statement.Context, rv)
+		if isZero {
+			db.AddError(field.Set(db.Statement.Context, rv, workspaceID))
+			return
+		}
+		if value.(uint) != workspaceID {
+			db.AddError(ErrCrossWorkspace)
+		}
+	}
+
+	switch db.Statement.ReflectValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < db.Statement.ReflectValue.Len(); i++ {
+			assign(reflect.Indirect(db.Statement.ReflectValue.Index(i)))
+		}
+	case reflect.Struct:
+		assign(db.Statement.ReflectValue)
+	}
+}
+
+func (p WorkspaceScopePlugin) restrictWorkspace(db *gorm.DB) {
+	field, workspaceID, ok := p.scopedField(db)
+	if !ok {
+		return
+	}
+
+	// statement yang sama bisa dieksekusi lebih dari sekali (mis. Count lalu Find)
+	if _, scoped := db.Statement.Settings.LoadOrStore(workspaceScopedSetting, true); scoped {
+		return
+	}
+
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{
+			Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName},
+			Value:  workspaceID,
+		},
+	}})
+}
+
+func (p WorkspaceScopePlugin) scopedField(db *gorm.DB) (*schema.Field, uint, bool) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return nil, 0, false
+	}
+
+	modelType := reflect.PointerTo(db.Statement.Schema.ModelType)
+	if !modelType.Implements(workspaceScopedType) {
+		return nil, 0, false
+	}
+
+	workspaceID, ok := utils.WorkspaceIDFromContext(db.Statement.Context)
+	if !ok {
+		return nil, 0, false
+	}
+
+	field := db.Statement.Schema.LookUpField("WorkspaceID")
+	if field == nil {
+		return nil, 0, false
+	}
+
+	return field, workspaceID, true
+}
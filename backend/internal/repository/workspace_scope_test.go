@@ -0,0 +1,316 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"backend/internal/utils"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	workspaceA uint = 1
+	workspaceB uint = 2
+)
+
+// scopedModel membuat baris model WorkspaceScoped untuk workspace tertentu
+type scopedModel struct {
+	name   string
+	newRow func(workspaceID uint, n int) models.WorkspaceScoped
+}
+
+var scopedModels = []scopedModel{
+	{"task", func(workspaceID uint, n int) models.WorkspaceScoped {
+		return &models.Task{
+			WorkspaceID:     workspaceID,
+			CreateAccountID: 1,
+			AccountID:       1,
+			Title:           fmt.Sprintf("task %d-%d", workspaceID, n),
+			Status:          models.TaskStatusTodo,
+		}
+	}},
+	{"label", func(workspaceID uint, n int) models.WorkspaceScoped {
+		return &models.Label{
+			WorkspaceID:     workspaceID,
+			Name:            fmt.Sprintf("label %d-%d", workspaceID, n),
+			Color:           "#000000",
+			CreateAccountID: 1,
+		}
+	}},
+	{"project", func(workspaceID uint, n int) models.WorkspaceScoped {
+		return &models.Project{
+			WorkspaceID: workspaceID,
+			Name:        fmt.Sprintf("project %d-%d", workspaceID, n),
+			Key:         fmt.Sprintf("P%d%d", workspaceID, n),
+			OwnerID:     1,
+		}
+	}},
+}
+
+// newScopedDB membuka sqlite in-memory dengan WorkspaceScopePlugin dan dua
+// baris per model di workspace A dan B. Seed memakai context tanpa
+// workspace, jadi tidak dibatasi plugin.
+func newScopedDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// setiap koneksi sqlite :memory: adalah database terpisah
+	sqlDB.SetMaxOpenConns(1)
+
+	if err := db.Use(WorkspaceScopePlugin{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Migrator().CreateTable(&models.Task{}, &models.Label{}, &models.Project{}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, model := range scopedModels {
+		for _, workspaceID := range []uint{workspaceA, workspaceB} {
+			for n := 1; n <= 2; n++ {
+				if err := db.Omit("Assignees", "Watchers").Create(model.newRow(workspaceID, n)).Error; err != nil {
+					t.Fatalf("seed %s: %v", model.name, err)
+				}
+			}
+		}
+	}
+	return db
+}
+
+func workspaceContext(workspaceID uint) context.Context {
+	return utils.WithWorkspaceID(context.Background(), workspaceID)
+}
+
+// rowsOf membaca semua baris model tanpa pembatasan workspace
+func rowsOf(t *testing.T, db *gorm.DB, model scopedModel) []models.WorkspaceScoped {
+	t.Helper()
+	return findRows(t, db.WithContext(context.Background()), model)
+}
+
+func findRows(t *testing.T, db *gorm.DB, model scopedModel) []models.WorkspaceScoped {
+	t.Helper()
+
+	rowType := reflect.TypeOf(model.newRow(0, 0)).Elem()
+	rows := reflect.New(reflect.SliceOf(rowType))
+	if err := db.Find(rows.Interface()).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	var result []models.WorkspaceScoped
+	for i := 0; i < rows.Elem().Len(); i++ {
+		result = append(result, rows.Elem().Index(i).Addr().Interface().(models.WorkspaceScoped))
+	}
+	return result
+}
+
+func idsIn(rows []models.WorkspaceScoped, workspaceID uint) []uint {
+	var ids []uint
+	for _, row := range rows {
+		if row.GetWorkspaceID() == workspaceID {
+			ids = append(ids, uint(reflect.ValueOf(row).Elem().FieldByName("ID").Uint()))
+		}
+	}
+	return ids
+}
+
+func TestWorkspaceScopeFind(t *testing.T) {
+	db := newScopedDB(t)
+	scoped := db.WithContext(workspaceContext(workspaceA))
+
+	for _, model := range scopedModels {
+		t.Run(model.name, func(t *testing.T) {
+			rows := findRows(t, scoped, model)
+			if len(rows) != 2 {
+				t.Fatalf("expected 2 rows of workspace A, got %d", len(rows))
+			}
+			for _, row := range rows {
+				if row.GetWorkspaceID() != workspaceA {
+					t.Fatalf("found row of workspace %d", row.GetWorkspaceID())
+				}
+			}
+
+			// filter workspace eksplisit tidak bisa membuka workspace lain
+			explicit := findRows(t, scoped.Where("workspace_id = ?", workspaceB), model)
+			if len(explicit) != 0 {
+				t.Fatalf("expected no rows of workspace B, got %d", len(explicit))
+			}
+
+			for _, id := range idsIn(rowsOf(t, db, model), workspaceB) {
+				row := model.newRow(0, 0)
+				err := scoped.First(row, id).Error
+				if !errors.Is(err, gorm.ErrRecordNotFound) {
+					t.Fatalf("First(%d) of workspace B: expected ErrRecordNotFound, got %v", id, err)
+				}
+			}
+		})
+	}
+}
+
+func TestWorkspaceScopeUpdate(t *testing.T) {
+	db := newScopedDB(t)
+	scoped := db.WithContext(workspaceContext(workspaceA))
+	stamp := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, model := range scopedModels {
+		t.Run(model.name, func(t *testing.T) {
+			foreignIDs := idsIn(rowsOf(t, db, model), workspaceB)
+
+			result := scoped.Model(model.newRow(0, 0)).
+				Where("id IN ?", foreignIDs).
+				Update("created_at", stamp)
+			if result.Error != nil {
+				t.Fatal(result.Error)
+			}
+			if result.RowsAffected != 0 {
+				t.Fatalf("updated %d rows of workspace B by id", result.RowsAffected)
+			}
+
+			result = scoped.Model(model.newRow(0, 0)).
+				Where("1 = 1").
+				Update("created_at", stamp)
+			if result.Error != nil {
+				t.Fatal(result.Error)
+			}
+			if result.RowsAffected != 2 {
+				t.Fatalf("expected 2 rows of workspace A updated, got %d", result.RowsAffected)
+			}
+
+			for _, row := range rowsOf(t, db, model) {
+				createdAt := reflect.ValueOf(row).Elem().FieldByName("CreatedAt").Interface().(time.Time)
+				if changed := createdAt.Equal(stamp); changed != (row.GetWorkspaceID() == workspaceA) {
+					t.Fatalf("row of workspace %d: changed = %t", row.GetWorkspaceID(), changed)
+				}
+			}
+		})
+	}
+}
+
+func TestWorkspaceScopeDelete(t *testing.T) {
+	db := newScopedDB(t)
+	scoped := db.WithContext(workspaceContext(workspaceA))
+
+	for _, model := range scopedModels {
+		t.Run(model.name, func(t *testing.T) {
+			foreignIDs := idsIn(rowsOf(t, db, model), workspaceB)
+
+			for _, id := range foreignIDs {
+				result := scoped.Delete(model.newRow(0, 0), id)
+				if result.Error != nil {
+					t.Fatal(result.Error)
+				}
+				if result.RowsAffected != 0 {
+					t.Fatalf("deleted row %d of workspace B", id)
+				}
+			}
+
+			result := scoped.Where("1 = 1").Delete(model.newRow(0, 0))
+			if result.Error != nil {
+				t.Fatal(result.Error)
+			}
+			if result.RowsAffected != 2 {
+				t.Fatalf("expected 2 rows of workspace A deleted, got %d", result.RowsAffected)
+			}
+
+			remaining := rowsOf(t, db, model)
+			if len(remaining) != 2 || len(idsIn(remaining, workspaceB)) != 2 {
+				t.Fatalf("expected only the 2 rows of workspace B to remain, got %d", len(remaining))
+			}
+		})
+	}
+}
+
+func TestWorkspaceScopeCreate(t *testing.T) {
+	db := newScopedDB(t)
+	scoped := db.WithContext(workspaceContext(workspaceA))
+
+	for _, model := range scopedModels {
+		t.Run(model.name, func(t *testing.T) {
+			before := len(rowsOf(t, db, model))
+
+			err := scoped.Omit("Assignees", "Watchers").Create(model.newRow(workspaceB, 3)).Error
+			if !errors.Is(err, ErrCrossWorkspace) {
+				t.Fatalf("expected ErrCrossWorkspace, got %v", err)
+			}
+			if after := len(rowsOf(t, db, model)); after != before {
+				t.Fatalf("row inserted despite ErrCrossWorkspace (%d -> %d)", before, after)
+			}
+
+			// tanpa WorkspaceID, record diisi workspace dari context
+			row := model.newRow(0, 4)
+			if err := scoped.Omit("Assignees", "Watchers").Create(row).Error; err != nil {
+				t.Fatal(err)
+			}
+			if row.GetWorkspaceID() != workspaceA {
+				t.Fatalf("expected workspace %d assigned, got %d", workspaceA, row.GetWorkspaceID())
+			}
+		})
+	}
+}
+
+// raw INSERT ... SELECT tidak melewati plugin, jadi repository sendiri yang
+// harus menolak label dan akun dari workspace lain
+func TestWorkspaceScopeRawInserts(t *testing.T) {
+	db := newScopedDB(t)
+	if err := db.Migrator().CreateTable(&models.WorkspaceMember{}); err != nil {
+		t.Fatal(err)
+	}
+	for table, column := range map[string]string{"task_labels": "label_id", "task_assignees": "account_id", "task_watchers": "account_id"} {
+		err := db.Exec("CREATE TABLE " + table + " (task_id integer, " + column + " integer, PRIMARY KEY (task_id, " + column + "))").Error
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	members := []models.WorkspaceMember{
+		{WorkspaceID: workspaceA, AccountID: 10, Role: models.WorkspaceRoleMember},
+		{WorkspaceID: workspaceB, AccountID: 20, Role: models.WorkspaceRoleMember},
+	}
+	if err := db.Omit("Workspace", "Account").Create(&members).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := workspaceContext(workspaceA)
+	repo := NewTaskRepository(db)
+	taskID := idsIn(rowsOf(t, db, scopedModels[0]), workspaceA)[0]
+	labels := rowsOf(t, db, scopedModels[1])
+	ownLabel, foreignLabel := idsIn(labels, workspaceA)[0], idsIn(labels, workspaceB)[0]
+
+	if err := repo.AttachLabels(ctx, taskID, []uint{ownLabel, foreignLabel}); err != nil {
+		t.Fatal(err)
+	}
+	var labelIDs []uint
+	if err := db.Table("task_labels").Where("task_id = ?", taskID).Pluck("label_id", &labelIDs).Error; err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(labelIDs, []uint{ownLabel}) {
+		t.Fatalf("expected only label %d attached, got %v", ownLabel, labelIDs)
+	}
+
+	if err := repo.AddAssignees(ctx, taskID, []uint{10, 20}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.AddWatchers(ctx, taskID, []uint{10, 20}); err != nil {
+		t.Fatal(err)
+	}
+	for _, table := range []string{"task_assignees", "task_watchers"} {
+		var accountIDs []uint
+		if err := db.Table(table).Where("task_id = ?", taskID).Pluck("account_id", &accountIDs).Error; err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(accountIDs, []uint{10}) {
+			t.Fatalf("%s: expected only member 10, got %v", table, accountIDs)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"backend/internal/utils"
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	appPasswordLength = 32
+	// appPasswordTouchInterval membatasi update last_used_at, karena client
+	// seperti CalDAV mengirim banyak request berturut-turut
+	appPasswordTouchInterval = 5 * time.Minute
+)
+
+var (
+	ErrAppPasswordInvalid  = errors.New("invalid username or app password")
+	ErrAppPasswordNotFound = errors.New("app password not found")
+)
+
+type AppPasswordService interface {
+	CreateAppPassword(ctx context.Context, req dto.CreateAppPasswordRequest, userID uint) (*dto.AppPasswordResponse, error)
+	GetAppPasswords(ctx context.Context, userID uint) ([]models.AppPassword, error)
+	DeleteAppPassword(ctx context.Context, id, userID uint) error
+	// Authenticate mencocokkan email akun dan app password, dipanggil tanpa
+	// workspace di context
+	Authenticate(ctx context.Context, email, password string) (*models.AppPassword, error)
+}
+
+type appPasswordService struct {
+	appPasswordRepo repository.AppPasswordRepository
+}
+
+func NewAppPasswordService(appPasswordRepo repository.AppPasswordRepository) AppPasswordService {
+	return &appPasswordService{
+		appPasswordRepo: appPasswordRepo,
+	}
+}
+
+func (s *appPasswordService) CreateAppPassword(ctx context.Context, req dto.CreateAppPasswordRequest, userID uint) (*dto.AppPasswordResponse, error) {
+	password, err := utils.GenerateRandomString(appPasswordLength)
+	if err != nil {
+		return nil, err
+	}
+
+	appPassword := &models.AppPassword{
+		AccountID:    userID,
+		Name:         strings.TrimSpace(req.Name),
+		PasswordHash: utils.GenerateHash(password),
+	}
+	if err := s.appPasswordRepo.Create(ctx, appPassword); err != nil {
+		return nil, err
+	}
+
+	return &dto.AppPasswordResponse{AppPassword: *appPassword, Password: password}, nil
+}
+
+func (s *appPasswordService) GetAppPasswords(ctx context.Context, userID uint) ([]models.AppPassword, error) {
+	return s.appPasswordRepo.GetByAccount(ctx, userID)
+}
+
+func (s *appPasswordService) DeleteAppPassword(ctx context.Context, id, userID uint) error {
+	deleted, err := s.appPasswordRepo.Delete(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrAppPasswordNotFound
+	}
+	return nil
+}
+
+func (s *appPasswordService) Authenticate(ctx context.Context, email, password string) (*models.AppPassword, error) {
+	if email == "" || password == "" {
+		return nil, ErrAppPasswordInvalid
+	}
+
+	appPassword, err := s.appPasswordRepo.GetByHash(ctx, utils.GenerateHash(password))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrAppPasswordInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+	if appPassword.Account == nil || !appPassword.Account.IsActive ||
+		!strings.EqualFold(appPassword.Account.Email, email) {
+		return nil, ErrAppPasswordInvalid
+	}
+
+	now := time.Now()
+	if appPassword.LastUsedAt == nil || now.Sub(*appPassword.LastUsedAt) > appPasswordTouchInterval {
+		if err := s.appPasswordRepo.Touch(ctx, appPassword.ID, now); err != nil {
+			log.Printf("Failed to update app password %d: %v", appPassword.ID, err)
+		}
+	}
+
+	return appPassword, nil
+}
@@ -15,18 +15,21 @@ type AuthService interface {
 	Login(ctx context.Context, req dto.LoginRequest) (*dto.AuthResponse, error)
 	Register(ctx context.Context, req dto.RegisterRequest) (*dto.AuthResponse, error)
 	ChangePassword(ctx context.Context, accountID uint, req dto.ChangePasswordRequest) error
+	SwitchWorkspace(ctx context.Context, accountID uint, req dto.SwitchWorkspaceRequest) (*dto.AuthResponse, error)
 	ValidateAccount(ctx context.Context, email, password string) (*models.Account, error)
 }
 
 type authService struct {
-	accountRepo repository.AccountRepository
-	jwtService  JWTService
+	accountRepo   repository.AccountRepository
+	workspaceRepo repository.WorkspaceRepository
+	jwtService    JWTService
 }
 
-func NewAuthService(accountRepo repository.AccountRepository, jwtService JWTService) AuthService {
+func NewAuthService(accountRepo repository.AccountRepository, workspaceRepo repository.WorkspaceRepository, jwtService JWTService) AuthService {
 	return &authService{
-		accountRepo: accountRepo,
-		jwtService:  jwtService,
+		accountRepo:   accountRepo,
+		workspaceRepo: workspaceRepo,
+		jwtService:    jwtService,
 	}
 }
 
@@ -52,21 +55,16 @@ func (s *authService) Login(ctx context.Context, req dto.LoginRequest) (*dto.Aut
 		return nil, errors.New("invalid email or password")
 	}
 
-	// Generate JWT token
-	token := s.jwtService.GenerateToken(fmt.Sprintf("%d", account.ID), account.Email)
+	// Tentukan workspace aktif untuk token
+	workspace, err := s.resolveWorkspace(ctx, account, req.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
 
 	// Update last login
 	s.accountRepo.UpdateLastLogin(ctx, account.ID)
 
-	// Create response
-	authResponse := &dto.AuthResponse{
-		Account:     s.toAccountResponse(account),
-		AccessToken: token,
-		TokenType:   "Bearer",
-		ExpiresAt:   time.Now().Add(24 * time.Hour).Format(time.RFC3339),
-	}
-
-	return authResponse, nil
+	return s.buildAuthResponse(account, workspace), nil
 }
 
 func (s *authService) Register(ctx context.Context, req dto.RegisterRequest) (*dto.AuthResponse, error) {
@@ -98,18 +96,78 @@ func (s *authService) Register(ctx context.Context, req dto.RegisterRequest) (*d
 		return nil, err
 	}
 
-	// Generate JWT token
-	token := s.jwtService.GenerateToken(fmt.Sprintf("%d", account.ID), account.Email)
+	// Setiap akun baru mendapat workspace pribadi
+	workspace, err := s.createPersonalWorkspace(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildAuthResponse(account, workspace), nil
+}
+
+func (s *authService) SwitchWorkspace(ctx context.Context, accountID uint, req dto.SwitchWorkspaceRequest) (*dto.AuthResponse, error) {
+	account, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, errors.New("account not found")
+	}
 
-	// Create response
-	authResponse := &dto.AuthResponse{
+	workspace, err := s.resolveWorkspace(ctx, account, &req.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildAuthResponse(account, workspace), nil
+}
+
+// resolveWorkspace memakai workspace yang diminta jika akun adalah member-nya,
+// atau workspace default akun jika tidak ada yang diminta
+func (s *authService) resolveWorkspace(ctx context.Context, account *models.Account, workspaceID *uint) (*models.Workspace, error) {
+	if workspaceID != nil {
+		member, err := s.workspaceRepo.GetMember(ctx, *workspaceID, account.ID)
+		if err != nil {
+			return nil, fmt.Errorf("database error: %v", err)
+		}
+		if member == nil {
+			return nil, ErrWorkspaceNotFound
+		}
+		return s.workspaceRepo.GetByID(ctx, *workspaceID)
+	}
+
+	workspace, err := s.workspaceRepo.GetDefaultForAccount(ctx, account.ID)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %v", err)
+	}
+	if workspace == nil {
+		return s.createPersonalWorkspace(ctx, account)
+	}
+
+	return workspace, nil
+}
+
+func (s *authService) createPersonalWorkspace(ctx context.Context, account *models.Account) (*models.Workspace, error) {
+	workspace := &models.Workspace{
+		Name:    fmt.Sprintf("%s's Workspace", account.Name),
+		Slug:    generateWorkspaceSlug(account.Name),
+		OwnerID: account.ID,
+	}
+
+	if err := s.workspaceRepo.Create(ctx, workspace); err != nil {
+		return nil, err
+	}
+
+	return workspace, nil
+}
+
+func (s *authService) buildAuthResponse(account *models.Account, workspace *models.Workspace) *dto.AuthResponse {
+	token := s.jwtService.GenerateToken(fmt.Sprintf("%d", account.ID), account.Email, workspace.ID)
+
+	return &dto.AuthResponse{
 		Account:     s.toAccountResponse(account),
+		Workspace:   workspace,
 		AccessToken: token,
 		TokenType:   "Bearer",
 		ExpiresAt:   time.Now().Add(24 * time.Hour).Format(time.RFC3339),
 	}
-
-	return authResponse, nil
 }
 
 func (s *authService) ChangePassword(ctx context.Context, accountID uint, req dto.ChangePasswordRequest) error {
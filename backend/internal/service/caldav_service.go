@@ -0,0 +1,395 @@
+package service
+
+import (
+	"backend/internal/dto"
+	"backend/internal/ical"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// caldavPersonalCollection berisi task tanpa project
+	caldavPersonalCollection = "tasks"
+	caldavProjectPrefix      = "project-"
+	caldavObjectSuffix       = ".ics"
+)
+
+var (
+	ErrCalDAVNotFound = errors.New("caldav resource not found")
+	// ErrCalDAVInvalidObject berarti body PUT bukan iCalendar yang valid
+	ErrCalDAVInvalidObject = errors.New("invalid calendar object")
+	// ErrCalDAVUnsupportedComponent berarti body PUT tidak berisi VTODO
+	ErrCalDAVUnsupportedComponent = errors.New("only VTODO components are supported")
+	// ErrCalDAVUIDConflict berarti UID sudah dipakai resource lain
+	ErrCalDAVUIDConflict = errors.New("UID already used by another resource")
+)
+
+type CalDAVService interface {
+	GetCollections(ctx context.Context, userID uint) ([]dto.CalDAVCollection, error)
+	GetCollection(ctx context.Context, name string, userID uint) (*dto.CalDAVCollection, error)
+	GetObjects(ctx context.Context, collection *dto.CalDAVCollection) ([]dto.CalDAVObject, error)
+	GetObject(ctx context.Context, collection *dto.CalDAVCollection, name string) (*dto.CalDAVObject, error)
+	// PutObject membuat atau mengubah task dari VTODO di body; bool true
+	// berarti task baru dibuat
+	PutObject(ctx context.Context, collection *dto.CalDAVCollection, name string, body io.Reader, precondition dto.CalDAVPrecondition, userID uint) (*dto.CalDAVObject, bool, error)
+	DeleteObject(ctx context.Context, collection *dto.CalDAVCollection, name string, precondition dto.CalDAVPrecondition) error
+	// WriteObject menulis satu object sebagai VCALENDAR berisi VTODO
+	WriteObject(w io.Writer, object *dto.CalDAVObject) error
+}
+
+type caldavService struct {
+	caldavRepo  repository.CalDAVRepository
+	taskRepo    repository.TaskRepository
+	projectRepo repository.ProjectRepository
+	taskService TaskService
+}
+
+func NewCalDAVService(caldavRepo repository.CalDAVRepository, taskRepo repository.TaskRepository, projectRepo repository.ProjectRepository, taskService TaskService) CalDAVService {
+	return &caldavService{
+		caldavRepo:  caldavRepo,
+		taskRepo:    taskRepo,
+		projectRepo: projectRepo,
+		taskService: taskService,
+	}
+}
+
+func (s *caldavService) GetCollections(ctx context.Context, userID uint) ([]dto.CalDAVCollection, error) {
+	projects, err := s.projectRepo.GetAllForAccount(ctx, userID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	collections := make([]dto.CalDAVCollection, 0, len(projects)+1)
+	personal, err := s.newCollection(ctx, caldavPersonalCollection, "Tasks", nil)
+	if err != nil {
+		return nil, err
+	}
+	collections = append(collections, *personal)
+
+	for i := range projects {
+		collection, err := s.newCollection(ctx, projectCollectionName(projects[i].ID), projects[i].Name, &projects[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		collections = append(collections, *collection)
+	}
+	return collections, nil
+}
+
+func (s *caldavService) GetCollection(ctx context.Context, name string, userID uint) (*dto.CalDAVCollection, error) {
+	if name == caldavPersonalCollection {
+		return s.newCollection(ctx, name, "Tasks", nil)
+	}
+
+	id, err := strconv.ParseUint(strings.TrimPrefix(name, caldavProjectPrefix), 10, 32)
+	if !strings.HasPrefix(name, caldavProjectPrefix) || err != nil {
+		return nil, ErrCalDAVNotFound
+	}
+	projectID := uint(id)
+
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCalDAVNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	member, err := s.projectRepo.GetMember(ctx, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, ErrCalDAVNotFound
+	}
+
+	return s.newCollection(ctx, name, project.Name, &projectID)
+}
+
+func (s *caldavService) newCollection(ctx context.Context, name, displayName string, projectID *uint) (*dto.CalDAVCollection, error) {
+	count, lastModified, err := s.caldavRepo.GetState(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.CalDAVCollection{
+		Name:        name,
+		DisplayName: displayName,
+		ProjectID:   projectID,
+		CTag:        fmt.Sprintf("%d-%d", count, lastModified.UnixNano()),
+	}, nil
+}
+
+func (s *caldavService) GetObjects(ctx context.Context, collection *dto.CalDAVCollection) ([]dto.CalDAVObject, error) {
+	tasks, err := s.caldavRepo.GetTasks(ctx, collection.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	taskIDs := make([]uint, len(tasks))
+	for i, task := range tasks {
+		taskIDs[i] = task.ID
+	}
+	mapped, err := s.caldavRepo.GetObjects(ctx, taskIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]dto.CalDAVObject, len(tasks))
+	for i, task := range tasks {
+		objects[i] = newCalDAVObject(task, mapped)
+	}
+	return objects, nil
+}
+
+// GetObject mencari task lewat nama yang didaftarkan client, atau
+// "<id>.ics" untuk task yang dibuat dari aplikasi
+func (s *caldavService) GetObject(ctx context.Context, collection *dto.CalDAVCollection, name string) (*dto.CalDAVObject, error) {
+	object, err := s.caldavRepo.GetObjectByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var taskID uint
+	if object != nil {
+		taskID = object.TaskID
+	} else {
+		id, err := strconv.ParseUint(strings.TrimSuffix(name, caldavObjectSuffix), 10, 32)
+		if !strings.HasSuffix(name, caldavObjectSuffix) || err != nil {
+			return nil, ErrCalDAVNotFound
+		}
+		taskID = uint(id)
+	}
+
+	task, err := s.taskRepo.GetByID(ctx, taskID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCalDAVNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !sameProject(task.ProjectID, collection.ProjectID) {
+		return nil, ErrCalDAVNotFound
+	}
+
+	mapped, err := s.caldavRepo.GetObjects(ctx, []uint{task.ID})
+	if err != nil {
+		return nil, err
+	}
+	result := newCalDAVObject(*task, mapped)
+	// task yang punya nama dari client hanya bisa diakses lewat nama itu
+	if result.Name != name {
+		return nil, ErrCalDAVNotFound
+	}
+	return &result, nil
+}
+
+func (s *caldavService) PutObject(ctx context.Context, collection *dto.CalDAVCollection, name string, body io.Reader, precondition dto.CalDAVPrecondition, userID uint) (*dto.CalDAVObject, bool, error) {
+	todo, err := parseTodo(body)
+	if err != nil {
+		return nil, false, err
+	}
+
+	existing, err := s.GetObject(ctx, collection, name)
+	if err != nil && !errors.Is(err, ErrCalDAVNotFound) {
+		return nil, false, err
+	}
+
+	if existing != nil {
+		if precondition.IfNoneMatch {
+			return nil, false, ErrVersionMismatch
+		}
+		if todo.UID != "" && todo.UID != existing.UID {
+			return nil, false, ErrCalDAVUIDConflict
+		}
+
+		req := dto.UpdateTaskRequest{
+			Description: &todo.Description,
+			Status:      &todo.Status,
+			Deadline:    &todo.Deadline,
+			Priority:    todo.Priority,
+			IfMatch:     precondition.IfMatch,
+		}
+		if todo.Title != "" {
+			req.Title = &todo.Title
+		}
+		if _, err := s.taskService.UpdateTask(ctx, existing.Task.ID, req, userID); err != nil {
+			return nil, false, err
+		}
+
+		object, err := s.GetObject(ctx, collection, name)
+		return object, false, err
+	}
+
+	// If-Match untuk resource yang belum ada selalu gagal
+	if precondition.IfMatch != nil {
+		return nil, false, ErrVersionMismatch
+	}
+	// nama "<id>.ics" dipakai task yang dibuat dari aplikasi
+	if _, err := strconv.ParseUint(strings.TrimSuffix(name, caldavObjectSuffix), 10, 32); err == nil {
+		return nil, false, fmt.Errorf("%w: resource name %q is reserved", ErrCalDAVInvalidObject, name)
+	}
+	if todo.UID == "" || todo.Title == "" {
+		return nil, false, fmt.Errorf("%w: UID and SUMMARY are required", ErrCalDAVInvalidObject)
+	}
+	used, err := s.caldavRepo.GetObjectByUID(ctx, todo.UID)
+	if err != nil {
+		return nil, false, err
+	}
+	if used != nil {
+		return nil, false, ErrCalDAVUIDConflict
+	}
+
+	err = s.taskRepo.Transaction(ctx, func(ctx context.Context) error {
+		task, err := s.taskService.CreateTask(ctx, dto.CreateTaskRequest{
+			Title:       todo.Title,
+			Description: todo.Description,
+			Status:      todo.Status,
+			Priority:    todo.Priority,
+			Deadline:    todo.Deadline,
+			AccountID:   userID,
+			ProjectID:   collection.ProjectID,
+		}, userID)
+		if err != nil {
+			return err
+		}
+
+		return s.caldavRepo.CreateObject(ctx, &models.CalDAVObject{
+			TaskID: task.ID,
+			Name:   name,
+			UID:    todo.UID,
+		})
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	object, err := s.GetObject(ctx, collection, name)
+	return object, true, err
+}
+
+func (s *caldavService) DeleteObject(ctx context.Context, collection *dto.CalDAVCollection, name string, precondition dto.CalDAVPrecondition) error {
+	object, err := s.GetObject(ctx, collection, name)
+	if err != nil {
+		return err
+	}
+	if precondition.IfMatch != nil && !containsVersion(precondition.IfMatch, object.Task.Version) {
+		return ErrVersionMismatch
+	}
+	return s.taskService.DeleteTask(ctx, object.Task.ID)
+}
+
+func (s *caldavService) WriteObject(w io.Writer, object *dto.CalDAVObject) error {
+	cw := ical.NewWriter(w)
+	cw.Begin("VCALENDAR")
+	cw.Property("VERSION", "2.0")
+	cw.Property("PRODID", calendarProductID)
+	writeTaskTodo(cw, &object.Task, object.UID)
+	cw.End("VCALENDAR")
+	return cw.Err()
+}
+
+// newCalDAVObject memakai nama dan UID dari client jika ada
+func newCalDAVObject(task models.Task, mapped map[uint]models.CalDAVObject) dto.CalDAVObject {
+	if object, ok := mapped[task.ID]; ok {
+		return dto.CalDAVObject{Name: object.Name, UID: object.UID, Task: task}
+	}
+	return dto.CalDAVObject{
+		Name: fmt.Sprintf("%d%s", task.ID, caldavObjectSuffix),
+		UID:  taskUID(task.ID),
+		Task: task,
+	}
+}
+
+func projectCollectionName(projectID uint) string {
+	return fmt.Sprintf("%s%d", caldavProjectPrefix, projectID)
+}
+
+func sameProject(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// caldavTodo adalah field VTODO yang dipetakan ke task
+type caldavTodo struct {
+	UID         string
+	Title       string
+	Description string
+	Status      string
+	Deadline    time.Time
+	Priority    *models.Priority
+}
+
+func parseTodo(body io.Reader) (*caldavTodo, error) {
+	calendar, err := ical.Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCalDAVInvalidObject, err)
+	}
+	if calendar.Name != "VCALENDAR" {
+		return nil, fmt.Errorf("%w: expected VCALENDAR", ErrCalDAVInvalidObject)
+	}
+	component := calendar.Find("VTODO")
+	if component == nil {
+		return nil, ErrCalDAVUnsupportedComponent
+	}
+
+	todo := &caldavTodo{Status: taskStatusFromTodo(component)}
+	if prop := component.Prop("UID"); prop != nil {
+		todo.UID = strings.TrimSpace(prop.Value)
+	}
+	if prop := component.Prop("SUMMARY"); prop != nil {
+		todo.Title = strings.TrimSpace(prop.Text())
+	}
+	if prop := component.Prop("DESCRIPTION"); prop != nil {
+		todo.Description = prop.Text()
+	}
+	if prop := component.Prop("DUE"); prop != nil {
+		due, err := prop.Time()
+		if err != nil {
+			return nil, fmt.Errorf("%w: DUE: %v", ErrCalDAVInvalidObject, err)
+		}
+		todo.Deadline = due.UTC()
+	}
+	// PRIORITY 0 berarti tidak ditentukan, priority task tidak diubah
+	if prop := component.Prop("PRIORITY"); prop != nil {
+		value, err := strconv.Atoi(strings.TrimSpace(prop.Value))
+		if err == nil && value >= 1 && value <= 9 {
+			priority := models.Priority((value - 1) / 2)
+			todo.Priority = &priority
+		}
+	}
+	return todo, nil
+}
+
+// taskStatusFromTodo memetakan STATUS VTODO ke status task. Tanpa STATUS,
+// COMPLETED atau PERCENT-COMPLETE 100 berarti selesai. CANCELLED dianggap
+// done karena task tidak punya status batal.
+func taskStatusFromTodo(component *ical.Component) string {
+	if prop := component.Prop("STATUS"); prop != nil {
+		switch strings.ToUpper(strings.TrimSpace(prop.Value)) {
+		case "IN-PROCESS":
+			return models.TaskStatusInProgress
+		case "COMPLETED", "CANCELLED":
+			return models.TaskStatusDone
+		default:
+			return models.TaskStatusTodo
+		}
+	}
+
+	if component.Prop("COMPLETED") != nil {
+		return models.TaskStatusDone
+	}
+	if prop := component.Prop("PERCENT-COMPLETE"); prop != nil && strings.TrimSpace(prop.Value) == "100" {
+		return models.TaskStatusDone
+	}
+	return models.TaskStatusTodo
+}
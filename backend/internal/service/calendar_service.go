@@ -0,0 +1,247 @@
+package service
+
+import (
+	"backend/internal/dto"
+	"backend/internal/ical"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"backend/internal/utils"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	calendarTokenLength = 40
+	// maxCalendarTasks membatasi jumlah task di satu feed
+	maxCalendarTasks  = 2000
+	calendarProductID = "-//Task Manager//Tasks//EN"
+)
+
+var ErrCalendarTokenInvalid = errors.New("invalid calendar token")
+
+type CalendarService interface {
+	// CreateToken membuat token feed baru dan mencabut token lama.
+	// Token asli hanya dikembalikan di sini.
+	CreateToken(ctx context.Context, userID uint) (string, *models.CalendarToken, error)
+	GetToken(ctx context.Context, userID uint) (*models.CalendarToken, error)
+	RevokeToken(ctx context.Context, userID uint) error
+	// WriteFeed menulis kalender milik pemilik token ke w
+	WriteFeed(ctx context.Context, token string, req dto.CalendarFeedRequest, w io.Writer) error
+}
+
+type calendarService struct {
+	calendarRepo  repository.CalendarRepository
+	taskRepo      repository.TaskRepository
+	workspaceRepo repository.WorkspaceRepository
+}
+
+func NewCalendarService(calendarRepo repository.CalendarRepository, taskRepo repository.TaskRepository, workspaceRepo repository.WorkspaceRepository) CalendarService {
+	return &calendarService{
+		calendarRepo:  calendarRepo,
+		taskRepo:      taskRepo,
+		workspaceRepo: workspaceRepo,
+	}
+}
+
+func (s *calendarService) CreateToken(ctx context.Context, userID uint) (string, *models.CalendarToken, error) {
+	token, err := utils.GenerateRandomString(calendarTokenLength)
+	if err != nil {
+		return "", nil, err
+	}
+
+	calendarToken := &models.CalendarToken{
+		AccountID: userID,
+		TokenHash: utils.GenerateHash(token),
+	}
+	if err := s.calendarRepo.Replace(ctx, calendarToken); err != nil {
+		return "", nil, err
+	}
+
+	return token, calendarToken, nil
+}
+
+func (s *calendarService) GetToken(ctx context.Context, userID uint) (*models.CalendarToken, error) {
+	token, err := s.calendarRepo.GetByAccount(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return token, nil
+}
+
+func (s *calendarService) RevokeToken(ctx context.Context, userID uint) error {
+	return s.calendarRepo.DeleteByAccount(ctx, userID)
+}
+
+func (s *calendarService) WriteFeed(ctx context.Context, token string, req dto.CalendarFeedRequest, w io.Writer) error {
+	calendarToken, err := s.calendarRepo.GetByHash(ctx, utils.GenerateHash(token))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrCalendarTokenInvalid
+	}
+	if err != nil {
+		return err
+	}
+
+	// token hanya berlaku selama pemiliknya masih member workspace
+	member, err := s.workspaceRepo.GetMember(ctx, calendarToken.WorkspaceID, calendarToken.AccountID)
+	if err != nil {
+		return err
+	}
+	if member == nil {
+		return ErrCalendarTokenInvalid
+	}
+
+	// feed berjalan atas nama pemilik token, jadi visibilitas task sama
+	// dengan saat pemilik membuka aplikasi
+	ctx = utils.WithWorkspaceID(utils.WithAccountID(ctx, calendarToken.AccountID), calendarToken.WorkspaceID)
+
+	filter := dto.TaskListRequest{ProjectID: req.ProjectID}
+	if req.AssignedToMe {
+		filter.AssigneeID = &calendarToken.AccountID
+	}
+	tasks, err := s.taskRepo.GetWithDeadline(ctx, &filter, req.Status, maxCalendarTasks)
+	if err != nil {
+		return err
+	}
+
+	if err := s.calendarRepo.Touch(ctx, calendarToken.ID, time.Now()); err != nil {
+		log.Printf("Failed to update calendar token %d: %v", calendarToken.ID, err)
+	}
+
+	component := req.Component
+	if component == "" {
+		component = dto.CalendarComponentEvent
+	}
+	return writeTaskCalendar(w, "Tasks", tasks, component)
+}
+
+// writeTaskCalendar menulis satu VCALENDAR berisi task sebagai VTODO
+// dan/atau VEVENT
+func writeTaskCalendar(w io.Writer, name string, tasks []models.Task, component string) error {
+	cw := ical.NewWriter(w)
+	cw.Begin("VCALENDAR")
+	cw.Property("VERSION", "2.0")
+	cw.Property("PRODID", calendarProductID)
+	cw.Property("CALSCALE", "GREGORIAN")
+	cw.Text("X-WR-CALNAME", name)
+	cw.Text("NAME", name)
+	cw.Property("REFRESH-INTERVAL;VALUE=DURATION", "PT15M")
+	cw.Property("X-PUBLISHED-TTL", "PT15M")
+
+	for i := range tasks {
+		if component == dto.CalendarComponentTodo || component == dto.CalendarComponentBoth {
+			writeTaskTodo(cw, &tasks[i], taskUID(tasks[i].ID))
+		}
+		if component == dto.CalendarComponentEvent || component == dto.CalendarComponentBoth {
+			writeTaskEvent(cw, &tasks[i])
+		}
+	}
+
+	cw.End("VCALENDAR")
+	return cw.Err()
+}
+
+// writeTaskTodo menulis task sebagai VTODO; uid biasanya taskUID, kecuali
+// task dibuat lewat CalDAV dengan UID dari client
+func writeTaskTodo(cw *ical.Writer, task *models.Task, uid string) {
+	cw.Begin("VTODO")
+	cw.Property("UID", uid)
+	writeTaskStamps(cw, task)
+	cw.Text("SUMMARY", task.Title)
+	if task.Description != "" {
+		cw.Text("DESCRIPTION", task.Description)
+	}
+	if !task.Deadline.IsZero() {
+		cw.Time("DUE", task.Deadline)
+	}
+	cw.Property("STATUS", todoStatus(task.Status))
+	if task.IsDone() {
+		cw.Property("PERCENT-COMPLETE", "100")
+	}
+	cw.Property("PRIORITY", fmt.Sprint(calendarPriority(task.Priority)))
+	writeTaskCategories(cw, task)
+	cw.End("VTODO")
+}
+
+// writeTaskEvent menulis deadline sebagai event sesaat (tanpa DTEND) yang
+// tidak memblokir jadwal
+func writeTaskEvent(cw *ical.Writer, task *models.Task) {
+	cw.Begin("VEVENT")
+	cw.Property("UID", taskEventUID(task.ID))
+	writeTaskStamps(cw, task)
+	cw.Time("DTSTART", task.Deadline)
+	cw.Text("SUMMARY", task.Title)
+	if task.Description != "" {
+		cw.Text("DESCRIPTION", task.Description)
+	}
+	cw.Property("TRANSP", "TRANSPARENT")
+	writeTaskCategories(cw, task)
+	cw.End("VEVENT")
+}
+
+// writeTaskStamps menulis waktu perubahan; tanpa METHOD, DTSTAMP sama
+// dengan LAST-MODIFIED (RFC 5545 3.8.7.2) dan SEQUENCE mengikuti version
+func writeTaskStamps(cw *ical.Writer, task *models.Task) {
+	cw.Time("DTSTAMP", task.UpdatedAt)
+	cw.Time("CREATED", task.CreatedAt)
+	cw.Time("LAST-MODIFIED", task.UpdatedAt)
+	if task.Version > 0 {
+		cw.Property("SEQUENCE", fmt.Sprint(task.Version-1))
+	}
+}
+
+func writeTaskCategories(cw *ical.Writer, task *models.Task) {
+	if len(task.Labels) == 0 {
+		return
+	}
+	names := make([]string, len(task.Labels))
+	for i, label := range task.Labels {
+		names[i] = label.Name
+	}
+	cw.TextList("CATEGORIES", names)
+}
+
+// calendarUIDDomain membuat UID unik secara global; harus tetap sama
+// supaya client tidak menganggap semua task sebagai entri baru
+func calendarUIDDomain() string {
+	if domain := os.Getenv("CALENDAR_UID_DOMAIN"); domain != "" {
+		return domain
+	}
+	return "tasks.local"
+}
+
+func taskUID(id uint) string {
+	return fmt.Sprintf("task-%d@%s", id, calendarUIDDomain())
+}
+
+// taskEventUID berbeda dari UID VTODO karena satu UID hanya boleh dipakai
+// satu jenis komponen
+func taskEventUID(id uint) string {
+	return fmt.Sprintf("task-%d-deadline@%s", id, calendarUIDDomain())
+}
+
+// todoStatus memetakan status task ke STATUS VTODO
+func todoStatus(status string) string {
+	switch status {
+	case models.TaskStatusInProgress:
+		return "IN-PROCESS"
+	case models.TaskStatusDone:
+		return "COMPLETED"
+	default:
+		return "NEEDS-ACTION"
+	}
+}
+
+// calendarPriority memetakan P0..P4 ke PRIORITY iCalendar (1 tertinggi, 9 terendah)
+func calendarPriority(priority models.Priority) int {
+	return int(priority)*2 + 1
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"errors"
+	"strings"
+)
+
+type ChecklistService interface {
+	GetItems(ctx context.Context, taskID uint) ([]models.ChecklistItem, error)
+	AddItem(ctx context.Context, taskID uint, req dto.CreateChecklistItemRequest) (*models.ChecklistItem, error)
+	UpdateItem(ctx context.Context, taskID, itemID uint, req dto.UpdateChecklistItemRequest) (*models.ChecklistItem, error)
+	DeleteItem(ctx context.Context, taskID, itemID uint) error
+}
+
+type checklistService struct {
+	taskRepo      repository.TaskRepository
+	checklistRepo repository.ChecklistRepository
+}
+
+func NewChecklistService(taskRepo repository.TaskRepository, checklistRepo repository.ChecklistRepository) ChecklistService {
+	return &checklistService{
+		taskRepo:      taskRepo,
+		checklistRepo: checklistRepo,
+	}
+}
+
+func (s *checklistService) GetItems(ctx context.Context, taskID uint) ([]models.ChecklistItem, error) {
+	if _, err := s.taskRepo.GetByID(ctx, taskID); err != nil {
+		return nil, err
+	}
+	return s.checklistRepo.GetByTaskID(ctx, taskID)
+}
+
+func (s *checklistService) AddItem(ctx context.Context, taskID uint, req dto.CreateChecklistItemRequest) (*models.ChecklistItem, error) {
+	if _, err := s.taskRepo.GetByID(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return nil, errors.New("title is required")
+	}
+
+	item := &models.ChecklistItem{
+		TaskID: taskID,
+		Title:  title,
+	}
+
+	if req.Position != nil {
+		item.Position = *req.Position
+	} else {
+		position, err := s.checklistRepo.NextPosition(ctx, taskID)
+		if err != nil {
+			return nil, err
+		}
+		item.Position = position
+	}
+
+	if err := s.checklistRepo.Create(ctx, item); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (s *checklistService) UpdateItem(ctx context.Context, taskID, itemID uint, req dto.UpdateChecklistItemRequest) (*models.ChecklistItem, error) {
+	item, err := s.checklistRepo.GetByID(ctx, taskID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != nil {
+		title := strings.TrimSpace(*req.Title)
+		if title == "" {
+			return nil, errors.New("title is required")
+		}
+		item.Title = title
+	}
+	if req.IsDone != nil {
+		item.IsDone = *req.IsDone
+	}
+	if req.Position != nil {
+		item.Position = *req.Position
+	}
+
+	if err := s.checklistRepo.Update(ctx, item); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (s *checklistService) DeleteItem(ctx context.Context, taskID, itemID uint) error {
+	if _, err := s.checklistRepo.GetByID(ctx, taskID, itemID); err != nil {
+		return err
+	}
+	return s.checklistRepo.Delete(ctx, taskID, itemID)
+}
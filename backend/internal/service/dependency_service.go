@@ -0,0 +1,70 @@
+package service
+
+import (
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"errors"
+)
+
+var (
+	ErrDependencyCycle  = repository.ErrDependencyCycle
+	ErrDependencyExists = repository.ErrDependencyExists
+	ErrSelfDependency   = errors.New("task cannot depend on itself")
+)
+
+type DependencyService interface {
+	AddDependency(ctx context.Context, taskID, blockedByID, userID uint) (*models.TaskDependency, error)
+	RemoveDependency(ctx context.Context, taskID, blockedByID uint) error
+}
+
+type dependencyService struct {
+	taskRepo       repository.TaskRepository
+	dependencyRepo repository.DependencyRepository
+}
+
+func NewDependencyService(taskRepo repository.TaskRepository, dependencyRepo repository.DependencyRepository) DependencyService {
+	return &dependencyService{
+		taskRepo:       taskRepo,
+		dependencyRepo: dependencyRepo,
+	}
+}
+
+func (s *dependencyService) AddDependency(ctx context.Context, taskID, blockedByID, userID uint) (*models.TaskDependency, error) {
+	if taskID == blockedByID {
+		return nil, ErrSelfDependency
+	}
+
+	task, err := s.taskRepo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.taskRepo.GetByID(ctx, blockedByID); err != nil {
+		return nil, err
+	}
+
+	dependency := &models.TaskDependency{
+		TaskID:          taskID,
+		BlockedByID:     blockedByID,
+		CreateAccountID: userID,
+	}
+
+	if err := s.dependencyRepo.Add(ctx, task.WorkspaceID, dependency); err != nil {
+		return nil, err
+	}
+
+	return dependency, nil
+}
+
+// RemoveDependency hanya boleh untuk dependency antar task yang terlihat
+// oleh akun di context, sama seperti AddDependency
+func (s *dependencyService) RemoveDependency(ctx context.Context, taskID, blockedByID uint) error {
+	if _, err := s.taskRepo.GetByID(ctx, taskID); err != nil {
+		return err
+	}
+	if _, err := s.taskRepo.GetByID(ctx, blockedByID); err != nil {
+		return err
+	}
+
+	return s.dependencyRepo.Remove(ctx, taskID, blockedByID)
+}
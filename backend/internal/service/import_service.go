@@ -0,0 +1,620 @@
+package service
+
+import (
+	"backend/internal/dto"
+	"backend/internal/importer"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"backend/internal/utils"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	maxImportRows = 5000
+	// maxImportJobErrors membatasi jumlah error yang disimpan di job
+	maxImportJobErrors = 100
+	// importProgressEvery adalah jumlah baris antar penyimpanan progress job
+	importProgressEvery = 25
+	importJobListLimit  = 50
+)
+
+var (
+	ErrImportInvalidFile    = errors.New("invalid import file")
+	ErrInvalidImportMapping = errors.New("invalid import mapping")
+	ErrImportEmpty          = errors.New("import file has no valid rows")
+	ErrImportTooLarge       = fmt.Errorf("import is limited to %d rows", maxImportRows)
+	ErrImportInvalidRows    = errors.New("import file has invalid rows")
+	ErrImportJobNotFound    = errors.New("import job not found")
+)
+
+// importStatuses adalah nama status umum di Trello/Jira/CSV, dinormalisasi
+// dengan normalizeImportKey, beserta status task yang sesuai
+var importStatuses = map[string]string{
+	"todo":                   models.TaskStatusTodo,
+	"open":                   models.TaskStatusTodo,
+	"new":                    models.TaskStatusTodo,
+	"backlog":                models.TaskStatusTodo,
+	"selectedfordevelopment": models.TaskStatusTodo,
+	"inprogress":             models.TaskStatusInProgress,
+	"doing":                  models.TaskStatusInProgress,
+	"started":                models.TaskStatusInProgress,
+	"inreview":               models.TaskStatusInProgress,
+	"review":                 models.TaskStatusInProgress,
+	"done":                   models.TaskStatusDone,
+	"closed":                 models.TaskStatusDone,
+	"resolved":               models.TaskStatusDone,
+	"complete":               models.TaskStatusDone,
+	"completed":              models.TaskStatusDone,
+}
+
+type ImportService interface {
+	Preview(ctx context.Context, r io.Reader, opts dto.ImportOptions, userID uint) (*dto.ImportPreviewResponse, error)
+	// StartImport membuat job untuk baris yang valid. Jika ada baris yang
+	// tidak valid dan SkipInvalid tidak diset, hasil preview dikembalikan
+	// bersama ErrImportInvalidRows.
+	StartImport(ctx context.Context, r io.Reader, opts dto.ImportOptions, userID uint) (*dto.ImportJobResponse, *dto.ImportPreviewResponse, error)
+	GetJob(ctx context.Context, id uint, userID uint) (*dto.ImportJobResponse, error)
+	GetJobs(ctx context.Context, userID uint) ([]dto.ImportJobResponse, error)
+	// ProcessNext menjalankan satu job pending, false jika tidak ada
+	ProcessNext(ctx context.Context) (bool, error)
+}
+
+type importService struct {
+	jobRepo       repository.ImportJobRepository
+	labelRepo     repository.LabelRepository
+	projectRepo   repository.ProjectRepository
+	workspaceRepo repository.WorkspaceRepository
+	taskService   TaskService
+}
+
+func NewImportService(
+	jobRepo repository.ImportJobRepository,
+	labelRepo repository.LabelRepository,
+	projectRepo repository.ProjectRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	taskService TaskService,
+) ImportService {
+	return &importService{
+		jobRepo:       jobRepo,
+		labelRepo:     labelRepo,
+		projectRepo:   projectRepo,
+		workspaceRepo: workspaceRepo,
+		taskService:   taskService,
+	}
+}
+
+func (s *importService) Preview(ctx context.Context, r io.Reader, opts dto.ImportOptions, userID uint) (*dto.ImportPreviewResponse, error) {
+	preview, _, err := s.prepare(ctx, r, opts, userID)
+	return preview, err
+}
+
+func (s *importService) StartImport(ctx context.Context, r io.Reader, opts dto.ImportOptions, userID uint) (*dto.ImportJobResponse, *dto.ImportPreviewResponse, error) {
+	preview, tasks, err := s.prepare(ctx, r, opts, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if preview.Invalid > 0 && !opts.SkipInvalid {
+		return nil, preview, ErrImportInvalidRows
+	}
+	if preview.Valid == 0 {
+		return nil, preview, ErrImportEmpty
+	}
+
+	job := &models.ImportJob{
+		CreateAccountID: userID,
+		Format:          opts.Format,
+		ProjectID:       opts.ProjectID,
+		Status:          models.ImportJobPending,
+		Tasks:           tasks,
+		Total:           len(tasks),
+		Errors:          []models.ImportJobError{},
+	}
+	if err := s.jobRepo.Create(ctx, job); err != nil {
+		return nil, nil, err
+	}
+
+	return toImportJobResponse(job), preview, nil
+}
+
+func (s *importService) GetJob(ctx context.Context, id uint, userID uint) (*dto.ImportJobResponse, error) {
+	job, err := s.jobRepo.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrImportJobNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if job.CreateAccountID != userID {
+		return nil, ErrImportJobNotFound
+	}
+	return toImportJobResponse(job), nil
+}
+
+func (s *importService) GetJobs(ctx context.Context, userID uint) ([]dto.ImportJobResponse, error) {
+	jobs, err := s.jobRepo.GetByAccount(ctx, userID, importJobListLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.ImportJobResponse, len(jobs))
+	for i := range jobs {
+		responses[i] = *toImportJobResponse(&jobs[i])
+	}
+	return responses, nil
+}
+
+func (s *importService) ProcessNext(ctx context.Context) (bool, error) {
+	job, err := s.jobRepo.ClaimNext(ctx)
+	if err != nil || job == nil {
+		return false, err
+	}
+
+	s.run(ctx, job)
+	return true, nil
+}
+
+// run membuat task untuk setiap baris job atas nama pembuat job. Baris
+// diurutkan parent lebih dulu, jadi parent subtask sudah dibuat saat
+// subtask diproses. Kegagalan satu baris tidak menghentikan job.
+func (s *importService) run(ctx context.Context, job *models.ImportJob) {
+	taskCtx := utils.WithWorkspaceID(utils.WithAccountID(ctx, job.CreateAccountID), job.WorkspaceID)
+
+	labelIDs, err := s.ensureLabels(taskCtx, job)
+	if err != nil {
+		job.Errors = append(job.Errors, models.ImportJobError{Message: err.Error()})
+		s.finish(ctx, job, models.ImportJobFailed)
+		return
+	}
+
+	created := make(map[string]uint)
+	for _, task := range job.Tasks {
+		id, err := s.importTask(taskCtx, job, task, labelIDs, created)
+		job.Processed++
+		if err != nil {
+			job.Failed++
+			if len(job.Errors) < maxImportJobErrors {
+				job.Errors = append(job.Errors, models.ImportJobError{Line: task.Line, Message: err.Error()})
+			}
+		} else {
+			job.Created++
+			if task.ExternalID != "" {
+				created[task.ExternalID] = id
+			}
+		}
+
+		if job.Processed%importProgressEvery == 0 {
+			if err := s.jobRepo.UpdateProgress(ctx, job); err != nil {
+				log.Printf("Failed to save import job %d progress: %v", job.ID, err)
+			}
+		}
+	}
+
+	s.finish(ctx, job, models.ImportJobCompleted)
+}
+
+func (s *importService) finish(ctx context.Context, job *models.ImportJob, status string) {
+	now := time.Now()
+	job.Status = status
+	job.FinishedAt = &now
+	if err := s.jobRepo.UpdateProgress(ctx, job); err != nil {
+		log.Printf("Failed to finish import job %d: %v", job.ID, err)
+	}
+}
+
+func (s *importService) importTask(ctx context.Context, job *models.ImportJob, task models.ImportJobTask, labelIDs map[string]uint, created map[string]uint) (uint, error) {
+	priority := task.Priority
+	req := dto.CreateTaskRequest{
+		Title:           task.Title,
+		Description:     task.Description,
+		Status:          task.Status,
+		Priority:        &priority,
+		EstimateMinutes: task.EstimateMinutes,
+		AssigneeIDs:     task.AssigneeIDs,
+		ProjectID:       job.ProjectID,
+	}
+	if task.Deadline != nil {
+		req.Deadline = *task.Deadline
+	}
+	if task.ParentExternalID != "" {
+		parentID, ok := created[task.ParentExternalID]
+		if !ok {
+			return 0, errors.New("parent task was not imported")
+		}
+		req.ParentID = &parentID
+	}
+	for _, name := range task.Labels {
+		req.LabelIDs = append(req.LabelIDs, labelIDs[strings.ToLower(name)])
+	}
+
+	response, err := s.taskService.CreateTask(ctx, req, job.CreateAccountID)
+	if err != nil {
+		return 0, err
+	}
+	return response.ID, nil
+}
+
+// ensureLabels membuat label yang belum ada lalu mengembalikan id semua
+// label, dengan key nama label huruf kecil
+func (s *importService) ensureLabels(ctx context.Context, job *models.ImportJob) (map[string]uint, error) {
+	labels, err := s.labelRepo.GetAll(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]uint, len(labels))
+	for _, label := range labels {
+		ids[strings.ToLower(label.Name)] = label.ID
+	}
+
+	for _, task := range job.Tasks {
+		for _, name := range task.Labels {
+			key := strings.ToLower(name)
+			if _, ok := ids[key]; ok {
+				continue
+			}
+			label := &models.Label{
+				Name:            name,
+				Color:           defaultLabelColor,
+				CreateAccountID: job.CreateAccountID,
+			}
+			if err := s.labelRepo.Create(ctx, label); err != nil {
+				return nil, fmt.Errorf("create label %q: %w", name, err)
+			}
+			ids[key] = label.ID
+		}
+	}
+
+	return ids, nil
+}
+
+// prepare membaca file dengan adapter format-nya lalu memetakan dan
+// memvalidasi setiap baris tanpa menulis apa pun ke database. Selain
+// preview (urut sesuai file), dikembalikan task valid yang sudah diurutkan
+// parent lebih dulu.
+func (s *importService) prepare(ctx context.Context, r io.Reader, opts dto.ImportOptions, userID uint) (*dto.ImportPreviewResponse, []models.ImportJobTask, error) {
+	adapter, err := importer.NewAdapter(opts.Format, opts.Columns)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidImportMapping, err)
+	}
+	statuses, err := importStatusMap(opts.StatusMap)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	records, err := adapter.Parse(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrImportInvalidFile, err)
+	}
+	if len(records) == 0 {
+		return nil, nil, ErrImportEmpty
+	}
+	if len(records) > maxImportRows {
+		return nil, nil, ErrImportTooLarge
+	}
+
+	if err := s.ensureImportProject(ctx, opts.ProjectID, userID); err != nil {
+		return nil, nil, err
+	}
+
+	mapper, err := s.newImportMapper(ctx, opts, statuses)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows := make([]dto.ImportRowResult, len(records))
+	for i, record := range records {
+		rows[i] = mapper.mapRecord(ctx, record, userID)
+	}
+	depths := validateImportParents(rows, records)
+
+	preview := &dto.ImportPreviewResponse{
+		Format:    opts.Format,
+		Total:     len(rows),
+		NewLabels: mapper.newLabels,
+		Rows:      rows,
+	}
+	var tasks []models.ImportJobTask
+	for _, row := range rows {
+		if row.Task != nil {
+			preview.Valid++
+			tasks = append(tasks, *row.Task)
+		} else {
+			preview.Invalid++
+		}
+	}
+
+	// parent selalu dibuat sebelum subtask-nya
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return depths[tasks[i].Line] < depths[tasks[j].Line]
+	})
+	return preview, tasks, nil
+}
+
+func (s *importService) ensureImportProject(ctx context.Context, projectID *uint, userID uint) error {
+	if projectID == nil {
+		return nil
+	}
+
+	project, err := s.projectRepo.GetByID(ctx, *projectID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrProjectNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	member, err := s.projectRepo.GetMember(ctx, project.ID, userID)
+	if err != nil {
+		return err
+	}
+	if member == nil {
+		return ErrProjectNotFound
+	}
+	if project.IsArchived {
+		return ErrProjectArchived
+	}
+	return nil
+}
+
+// importMapper memetakan Record ke ImportJobTask dengan data workspace
+// (member, label, member project) yang dimuat sekali per import
+type importMapper struct {
+	projectRepo    repository.ProjectRepository
+	projectID      *uint
+	statuses       map[string]string
+	accountMap     map[string]string
+	members        map[string]uint
+	projectMembers map[uint]bool
+	labels         map[string]string
+	newLabels      []string
+}
+
+func (s *importService) newImportMapper(ctx context.Context, opts dto.ImportOptions, statuses map[string]string) (*importMapper, error) {
+	mapper := &importMapper{
+		projectRepo:    s.projectRepo,
+		projectID:      opts.ProjectID,
+		statuses:       statuses,
+		accountMap:     make(map[string]string, len(opts.AccountMap)),
+		members:        make(map[string]uint),
+		projectMembers: make(map[uint]bool),
+		labels:         make(map[string]string),
+		newLabels:      []string{},
+	}
+	for source, email := range opts.AccountMap {
+		mapper.accountMap[strings.ToLower(strings.TrimSpace(source))] = email
+	}
+
+	if workspaceID, ok := utils.WorkspaceIDFromContext(ctx); ok {
+		members, err := s.workspaceRepo.GetMembers(ctx, workspaceID)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			if member.Account != nil {
+				mapper.members[strings.ToLower(member.Account.Email)] = member.AccountID
+			}
+		}
+	}
+
+	labels, err := s.labelRepo.GetAll(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, label := range labels {
+		mapper.labels[strings.ToLower(label.Name)] = label.Name
+	}
+
+	return mapper, nil
+}
+
+func (m *importMapper) mapRecord(ctx context.Context, record importer.Record, userID uint) dto.ImportRowResult {
+	row := dto.ImportRowResult{Line: record.Line, Errors: record.Errors}
+	task := &models.ImportJobTask{
+		Line:             record.Line,
+		ExternalID:       record.ExternalID,
+		ParentExternalID: record.ParentID,
+		Title:            strings.TrimSpace(record.Title),
+		Description:      record.Description,
+		Priority:         models.DefaultPriority,
+		Deadline:         record.Deadline,
+		EstimateMinutes:  positiveOrNil(record.EstimateMinutes),
+	}
+
+	if task.Title == "" {
+		row.Errors = append(row.Errors, "title is required")
+	}
+	if record.Priority != nil {
+		task.Priority = *record.Priority
+	}
+
+	status, ok := m.status(record.Status)
+	if !ok {
+		row.Errors = append(row.Errors, fmt.Sprintf("unknown status %q, map it with status_map", record.Status))
+	}
+	task.Status = status
+
+	for _, assignee := range record.Assignees {
+		accountID, err := m.account(ctx, assignee)
+		if err != nil {
+			row.Errors = append(row.Errors, err.Error())
+			continue
+		}
+		task.AssigneeIDs = append(task.AssigneeIDs, accountID)
+	}
+	if len(record.Assignees) == 0 {
+		task.AssigneeIDs = []uint{userID}
+	}
+	task.AssigneeIDs = uniqueIDs(task.AssigneeIDs)
+
+	seen := make(map[string]bool)
+	for _, name := range record.Labels {
+		key := strings.ToLower(name)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		existing, ok := m.labels[key]
+		if !ok {
+			m.labels[key] = name
+			m.newLabels = append(m.newLabels, name)
+			existing = name
+		}
+		task.Labels = append(task.Labels, existing)
+	}
+
+	if len(row.Errors) == 0 {
+		row.Task = task
+	}
+	return row
+}
+
+// status memetakan status sumber, mendahulukan status_map dari request.
+// Status kosong dianggap todo.
+func (m *importMapper) status(value string) (string, bool) {
+	key := normalizeImportKey(value)
+	if key == "" {
+		return models.TaskStatusTodo, true
+	}
+	if status, ok := m.statuses[key]; ok {
+		return status, true
+	}
+	status, ok := importStatuses[key]
+	return status, ok
+}
+
+// account mencari member workspace dengan email assignee, setelah
+// dipetakan lewat account_map jika ada
+func (m *importMapper) account(ctx context.Context, assignee string) (uint, error) {
+	email := assignee
+	if mapped, ok := m.accountMap[strings.ToLower(assignee)]; ok {
+		email = mapped
+	}
+
+	accountID, ok := m.members[strings.ToLower(strings.TrimSpace(email))]
+	if !ok {
+		return 0, fmt.Errorf("assignee %q is not a workspace member", assignee)
+	}
+
+	if m.projectID != nil {
+		member, checked := m.projectMembers[accountID]
+		if !checked {
+			found, err := m.projectRepo.GetMember(ctx, *m.projectID, accountID)
+			if err != nil {
+				return 0, err
+			}
+			member = found != nil
+			m.projectMembers[accountID] = member
+		}
+		if !member {
+			return 0, fmt.Errorf("assignee %q is not a project member", assignee)
+		}
+	}
+
+	return accountID, nil
+}
+
+// validateImportParents memastikan parent setiap baris ada di file yang
+// sama dan valid, lalu mengembalikan kedalaman setiap baris (0 untuk task
+// tanpa parent) dengan key nomor baris. Baris yang parent-nya tidak valid
+// ikut ditandai tidak valid. rows dan records harus berurutan sama.
+func validateImportParents(rows []dto.ImportRowResult, records []importer.Record) map[int]int {
+	byExternalID := make(map[string]int, len(records))
+	for i, record := range records {
+		if record.ExternalID == "" {
+			continue
+		}
+		if _, duplicate := byExternalID[record.ExternalID]; duplicate {
+			rows[i].Errors = append(rows[i].Errors, fmt.Sprintf("duplicate id %q", record.ExternalID))
+			rows[i].Task = nil
+			continue
+		}
+		byExternalID[record.ExternalID] = i
+	}
+
+	depths := make(map[int]int, len(rows))
+	// ulangi sampai stabil karena baris yang baru ditandai tidak valid bisa
+	// menjadi parent baris lain
+	for changed := true; changed; {
+		changed = false
+		for i := range rows {
+			if rows[i].Task == nil {
+				continue
+			}
+
+			depth, reason := importDepth(rows, records, byExternalID, i)
+			if reason != "" {
+				rows[i].Errors = append(rows[i].Errors, reason)
+				rows[i].Task = nil
+				changed = true
+				continue
+			}
+			depths[rows[i].Line] = depth
+		}
+	}
+	return depths
+}
+
+// importDepth menelusuri rantai parent baris i. Alasan tidak kosong jika
+// parent tidak ditemukan, tidak valid, atau membentuk siklus.
+func importDepth(rows []dto.ImportRowResult, records []importer.Record, byExternalID map[string]int, i int) (int, string) {
+	visited := map[int]bool{i: true}
+	depth := 0
+	for current := i; records[current].ParentID != ""; depth++ {
+		parentID := records[current].ParentID
+		parent, ok := byExternalID[parentID]
+		if !ok {
+			return 0, fmt.Sprintf("parent %q not found in file", parentID)
+		}
+		if visited[parent] {
+			return 0, "parent chain forms a cycle"
+		}
+		if rows[parent].Task == nil {
+			return 0, fmt.Sprintf("parent %q is invalid", parentID)
+		}
+		visited[parent] = true
+		current = parent
+	}
+	return depth, ""
+}
+
+// importStatusMap memvalidasi status_map dari request dan menormalisasi key-nya
+func importStatusMap(statusMap map[string]string) (map[string]string, error) {
+	statuses := make(map[string]string, len(statusMap))
+	for source, status := range statusMap {
+		if !slices.Contains(boardStatuses, status) {
+			return nil, fmt.Errorf("%w: unknown status %q for %q", ErrInvalidImportMapping, status, source)
+		}
+		statuses[normalizeImportKey(source)] = status
+	}
+	return statuses, nil
+}
+
+// normalizeImportKey menyamakan "In Progress", "in-progress" dan "IN_PROGRESS"
+func normalizeImportKey(value string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '_', '-', '\t':
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(value)))
+}
+
+func toImportJobResponse(job *models.ImportJob) *dto.ImportJobResponse {
+	progress := 100
+	if job.Total > 0 {
+		progress = job.Processed * 100 / job.Total
+	}
+	return &dto.ImportJobResponse{ImportJob: *job, Progress: progress}
+}
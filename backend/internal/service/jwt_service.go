@@ -11,7 +11,7 @@ import (
 
 // JWTService interface
 type JWTService interface {
-	GenerateToken(userID string, email string) string
+	GenerateToken(userID string, email string, workspaceID uint) string
 	ValidateToken(token string) (*jwt.Token, error)
 	ExtractTokenMetadata(token string) (map[string]interface{}, error)
 }
@@ -39,16 +39,18 @@ func getSecretKey() string {
 
 // JWTClaim custom claims
 type JWTClaim struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
+	UserID      string `json:"user_id"`
+	Email       string `json:"email"`
+	WorkspaceID uint   `json:"workspace_id"`
 	jwt.RegisteredClaims
 }
 
 // GenerateToken membuat token JWT baru
-func (j *jwtService) GenerateToken(userID string, email string) string {
+func (j *jwtService) GenerateToken(userID string, email string, workspaceID uint) string {
 	claims := &JWTClaim{
-		UserID: userID,
-		Email:  email,
+		UserID:      userID,
+		Email:       email,
+		WorkspaceID: workspaceID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)), // 24 jam
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -107,10 +109,11 @@ func (j *jwtService) ExtractTokenMetadata(tokenString string) (map[string]interf
 	}
 
 	metadata := map[string]interface{}{
-		"user_id": claims.UserID,
-		"email":   claims.Email,
-		"issuer":  claims.Issuer,
-		"exp":     claims.ExpiresAt.Time.Unix(),
+		"user_id":      claims.UserID,
+		"email":        claims.Email,
+		"workspace_id": claims.WorkspaceID,
+		"issuer":       claims.Issuer,
+		"exp":          claims.ExpiresAt.Time.Unix(),
 	}
 
 	return metadata, nil
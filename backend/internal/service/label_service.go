@@ -0,0 +1,114 @@
+package service
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"errors"
+	"strings"
+)
+
+const defaultLabelColor = "#6B7280"
+
+var (
+	ErrLabelExists   = errors.New("label name already exists")
+	ErrLabelNotFound = errors.New("one or more labels not found")
+)
+
+type LabelService interface {
+	CreateLabel(ctx context.Context, req dto.CreateLabelRequest, userID uint) (*models.Label, error)
+	GetAllLabels(ctx context.Context, search *string) ([]models.Label, error)
+	GetLabelByID(ctx context.Context, id uint) (*models.Label, error)
+	UpdateLabel(ctx context.Context, id uint, req dto.UpdateLabelRequest) (*models.Label, error)
+	DeleteLabel(ctx context.Context, id uint) error
+}
+
+type labelService struct {
+	labelRepo repository.LabelRepository
+}
+
+func NewLabelService(labelRepo repository.LabelRepository) LabelService {
+	return &labelService{
+		labelRepo: labelRepo,
+	}
+}
+
+func (s *labelService) CreateLabel(ctx context.Context, req dto.CreateLabelRequest, userID uint) (*models.Label, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+
+	if err := s.ensureUniqueName(ctx, name, 0); err != nil {
+		return nil, err
+	}
+
+	label := &models.Label{
+		Name:            name,
+		Color:           req.Color,
+		CreateAccountID: userID,
+	}
+	if label.Color == "" {
+		label.Color = defaultLabelColor
+	}
+
+	if err := s.labelRepo.Create(ctx, label); err != nil {
+		return nil, err
+	}
+
+	return label, nil
+}
+
+func (s *labelService) GetAllLabels(ctx context.Context, search *string) ([]models.Label, error) {
+	return s.labelRepo.GetAll(ctx, search)
+}
+
+func (s *labelService) GetLabelByID(ctx context.Context, id uint) (*models.Label, error) {
+	return s.labelRepo.GetByID(ctx, id)
+}
+
+func (s *labelService) UpdateLabel(ctx context.Context, id uint, req dto.UpdateLabelRequest) (*models.Label, error) {
+	label, err := s.labelRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, errors.New("name is required")
+		}
+		if err := s.ensureUniqueName(ctx, name, label.ID); err != nil {
+			return nil, err
+		}
+		label.Name = name
+	}
+	if req.Color != nil {
+		label.Color = *req.Color
+	}
+
+	if err := s.labelRepo.Update(ctx, label); err != nil {
+		return nil, err
+	}
+
+	return label, nil
+}
+
+func (s *labelService) DeleteLabel(ctx context.Context, id uint) error {
+	if _, err := s.labelRepo.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return s.labelRepo.Delete(ctx, id)
+}
+
+func (s *labelService) ensureUniqueName(ctx context.Context, name string, exceptID uint) error {
+	existing, err := s.labelRepo.GetByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != exceptID {
+		return ErrLabelExists
+	}
+	return nil
+}
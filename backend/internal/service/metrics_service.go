@@ -0,0 +1,179 @@
+package service
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"backend/internal/utils"
+	"context"
+	"fmt"
+	"time"
+)
+
+// maxMetricsDays membatasi rentang metrics dalam satu request
+const maxMetricsDays = 366
+
+var ErrInvalidMetricsRange = fmt.Errorf("metrics range must have from before to and at most %d days", maxMetricsDays)
+
+type MetricsService interface {
+	GetLeadTime(ctx context.Context, req dto.MetricsRequest) (*dto.DurationMetricResponse, error)
+	GetCycleTime(ctx context.Context, req dto.MetricsRequest) (*dto.DurationMetricResponse, error)
+	GetTimeInStatus(ctx context.Context, req dto.MetricsRequest) (*dto.TimeInStatusResponse, error)
+	GetCumulativeFlow(ctx context.Context, req dto.MetricsRequest) (*dto.CumulativeFlowResponse, error)
+	GetThroughput(ctx context.Context, req dto.MetricsRequest) (*dto.ThroughputResponse, error)
+}
+
+type metricsService struct {
+	metricsRepo repository.MetricsRepository
+}
+
+func NewMetricsService(metricsRepo repository.MetricsRepository) MetricsService {
+	return &metricsService{
+		metricsRepo: metricsRepo,
+	}
+}
+
+// metricsWindow adalah rentang tanggal request: first dan last adalah awal
+// hari pertama dan terakhir (inklusif) di location
+type metricsWindow struct {
+	location    *time.Location
+	first, last time.Time
+}
+
+// end adalah awal hari setelah last, batas atas eksklusif untuk query
+func (w metricsWindow) end() time.Time {
+	return w.last.AddDate(0, 0, 1)
+}
+
+func (w metricsWindow) toRange() dto.MetricsRange {
+	return dto.MetricsRange{
+		From:     w.first.Format(dateLayout),
+		To:       w.last.Format(dateLayout),
+		Timezone: w.location.String(),
+	}
+}
+
+// resolveWindow mengisi default rentang (defaultDays hari sampai hari ini)
+// dan memvalidasinya
+func resolveWindow(req dto.MetricsRequest, defaultDays int) (metricsWindow, error) {
+	location, err := statsLocation(req.Timezone)
+	if err != nil {
+		return metricsWindow{}, err
+	}
+
+	last := utils.BeginningOfDay(time.Now().In(location))
+	if req.To != nil {
+		last = dateIn(*req.To, location)
+	}
+	first := last.AddDate(0, 0, 1-defaultDays)
+	if req.From != nil {
+		first = dateIn(*req.From, location)
+	}
+	if first.After(last) || first.AddDate(0, 0, maxMetricsDays).Before(last.AddDate(0, 0, 1)) {
+		return metricsWindow{}, ErrInvalidMetricsRange
+	}
+	return metricsWindow{location: location, first: first, last: last}, nil
+}
+
+func (s *metricsService) GetLeadTime(ctx context.Context, req dto.MetricsRequest) (*dto.DurationMetricResponse, error) {
+	window, err := resolveWindow(req, 30)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.metricsRepo.GetLeadTime(ctx, &req, window.first, window.end())
+	if err != nil {
+		return nil, err
+	}
+	return &dto.DurationMetricResponse{MetricsRange: window.toRange(), DurationStats: *stats}, nil
+}
+
+func (s *metricsService) GetCycleTime(ctx context.Context, req dto.MetricsRequest) (*dto.DurationMetricResponse, error) {
+	window, err := resolveWindow(req, 30)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.metricsRepo.GetCycleTime(ctx, &req, window.first, window.end())
+	if err != nil {
+		return nil, err
+	}
+	return &dto.DurationMetricResponse{MetricsRange: window.toRange(), DurationStats: *stats}, nil
+}
+
+// GetTimeInStatus mengembalikan setiap status yang dilewati task (todo dan
+// in_progress) secara berurutan, 0 jika tidak ada data
+func (s *metricsService) GetTimeInStatus(ctx context.Context, req dto.MetricsRequest) (*dto.TimeInStatusResponse, error) {
+	window, err := resolveWindow(req, 30)
+	if err != nil {
+		return nil, err
+	}
+
+	durations, err := s.metricsRepo.GetTimeInStatus(ctx, &req, window.first, window.end())
+	if err != nil {
+		return nil, err
+	}
+
+	byStatus := make(map[string]dto.DurationStats, len(durations))
+	for _, row := range durations {
+		byStatus[row.Status] = row.DurationStats
+	}
+	response := &dto.TimeInStatusResponse{MetricsRange: window.toRange()}
+	for _, status := range []string{models.TaskStatusTodo, models.TaskStatusInProgress} {
+		response.Statuses = append(response.Statuses, dto.StatusDuration{Status: status, DurationStats: byStatus[status]})
+	}
+	return response, nil
+}
+
+func (s *metricsService) GetCumulativeFlow(ctx context.Context, req dto.MetricsRequest) (*dto.CumulativeFlowResponse, error) {
+	window, err := resolveWindow(req, 30)
+	if err != nil {
+		return nil, err
+	}
+
+	flow, err := s.metricsRepo.GetCumulativeFlow(ctx, &req, window.first, window.last, window.location.String())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.CumulativeFlowResponse{
+		MetricsRange: window.toRange(),
+		Statuses:     boardStatuses,
+		Days:         []dto.FlowDay{},
+	}
+	for day := window.first; !day.After(window.last); day = day.AddDate(0, 0, 1) {
+		date := day.Format(dateLayout)
+		counts := make(map[string]int64, len(boardStatuses))
+		for _, status := range boardStatuses {
+			counts[status] = flow[date][status]
+		}
+		response.Days = append(response.Days, dto.FlowDay{Date: date, Counts: counts})
+	}
+	return response, nil
+}
+
+// GetThroughput memakai minggu penuh (Senin-Minggu) yang mencakup rentang
+// request, default 12 minggu terakhir
+func (s *metricsService) GetThroughput(ctx context.Context, req dto.MetricsRequest) (*dto.ThroughputResponse, error) {
+	window, err := resolveWindow(req, 12*7)
+	if err != nil {
+		return nil, err
+	}
+	window.first = beginningOfWeek(window.first)
+	if req.From == nil {
+		window.first = beginningOfWeek(window.last).AddDate(0, 0, -7*11)
+	}
+	window.last = beginningOfWeek(window.last).AddDate(0, 0, 6)
+
+	throughput, err := s.metricsRepo.GetThroughput(ctx, &req, window.first, window.end(), window.location.String())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.ThroughputResponse{MetricsRange: window.toRange(), Weeks: []dto.ThroughputWeek{}}
+	for week := window.first; week.Before(window.end()); week = week.AddDate(0, 0, 7) {
+		date := week.Format(dateLayout)
+		response.Weeks = append(response.Weeks, dto.ThroughputWeek{Week: date, Completed: throughput[date]})
+	}
+	return response, nil
+}
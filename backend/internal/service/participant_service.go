@@ -0,0 +1,80 @@
+package service
+
+import (
+	"backend/internal/repository"
+	"context"
+)
+
+var ErrLastAssignee = repository.ErrLastAssignee
+
+// ParticipantService mengelola assignee dan watcher sebuah task
+type ParticipantService interface {
+	AddAssignee(ctx context.Context, taskID, accountID uint) error
+	RemoveAssignee(ctx context.Context, taskID, accountID uint) error
+	AddWatcher(ctx context.Context, taskID, accountID uint) error
+	RemoveWatcher(ctx context.Context, taskID, accountID uint) error
+}
+
+type participantService struct {
+	taskRepo      repository.TaskRepository
+	projectRepo   repository.ProjectRepository
+	workspaceRepo repository.WorkspaceRepository
+}
+
+func NewParticipantService(
+	taskRepo repository.TaskRepository,
+	projectRepo repository.ProjectRepository,
+	workspaceRepo repository.WorkspaceRepository,
+) ParticipantService {
+	return &participantService{
+		taskRepo:      taskRepo,
+		projectRepo:   projectRepo,
+		workspaceRepo: workspaceRepo,
+	}
+}
+
+func (s *participantService) AddAssignee(ctx context.Context, taskID, accountID uint) error {
+	if err := s.ensureCanParticipate(ctx, taskID, accountID); err != nil {
+		return err
+	}
+	return s.taskRepo.AddAssignees(ctx, taskID, []uint{accountID})
+}
+
+func (s *participantService) RemoveAssignee(ctx context.Context, taskID, accountID uint) error {
+	if _, err := s.taskRepo.GetByID(ctx, taskID); err != nil {
+		return err
+	}
+	return s.taskRepo.RemoveAssignee(ctx, taskID, accountID)
+}
+
+func (s *participantService) AddWatcher(ctx context.Context, taskID, accountID uint) error {
+	if err := s.ensureCanParticipate(ctx, taskID, accountID); err != nil {
+		return err
+	}
+	return s.taskRepo.AddWatchers(ctx, taskID, []uint{accountID})
+}
+
+func (s *participantService) RemoveWatcher(ctx context.Context, taskID, accountID uint) error {
+	if _, err := s.taskRepo.GetByID(ctx, taskID); err != nil {
+		return err
+	}
+	return s.taskRepo.RemoveWatcher(ctx, taskID, accountID)
+}
+
+// ensureCanParticipate memastikan task terlihat oleh user dan akun yang
+// ditambahkan adalah member workspace serta member project task tersebut
+func (s *participantService) ensureCanParticipate(ctx context.Context, taskID, accountID uint) error {
+	task, err := s.taskRepo.GetByID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	if err := ensureWorkspaceMembers(ctx, s.workspaceRepo, accountID); err != nil {
+		return err
+	}
+
+	if task.ProjectID != nil {
+		return ensureProjectMembers(ctx, s.projectRepo, *task.ProjectID, accountID)
+	}
+	return nil
+}
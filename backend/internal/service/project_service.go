@@ -0,0 +1,230 @@
+package service
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"backend/internal/utils"
+	"context"
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrProjectNotFound    = errors.New("project not found")
+	ErrProjectForbidden   = errors.New("only the project owner can do this")
+	ErrProjectKeyExists   = errors.New("project key already exists")
+	ErrProjectArchived    = errors.New("project is archived")
+	ErrProjectHasTasks    = errors.New("project still has tasks, archive it instead")
+	ErrProjectOwnerMember = errors.New("the project owner cannot be removed or demoted")
+	ErrNotProjectMember   = errors.New("account is not a member of the project")
+)
+
+type ProjectService interface {
+	CreateProject(ctx context.Context, req dto.CreateProjectRequest, userID uint) (*models.Project, error)
+	GetProjects(ctx context.Context, req dto.ProjectListRequest, userID uint) ([]models.Project, error)
+	GetProjectByID(ctx context.Context, id, userID uint) (*models.Project, error)
+	UpdateProject(ctx context.Context, id uint, req dto.UpdateProjectRequest, userID uint) (*models.Project, error)
+	DeleteProject(ctx context.Context, id, userID uint) error
+	GetMembers(ctx context.Context, id, userID uint) ([]models.ProjectMember, error)
+	AddMember(ctx context.Context, id uint, req dto.AddProjectMemberRequest, userID uint) (*models.ProjectMember, error)
+	RemoveMember(ctx context.Context, id, accountID, userID uint) error
+}
+
+type projectService struct {
+	projectRepo   repository.ProjectRepository
+	accountRepo   repository.AccountRepository
+	workspaceRepo repository.WorkspaceRepository
+}
+
+func NewProjectService(projectRepo repository.ProjectRepository, accountRepo repository.AccountRepository, workspaceRepo repository.WorkspaceRepository) ProjectService {
+	return &projectService{
+		projectRepo:   projectRepo,
+		accountRepo:   accountRepo,
+		workspaceRepo: workspaceRepo,
+	}
+}
+
+func (s *projectService) CreateProject(ctx context.Context, req dto.CreateProjectRequest, userID uint) (*models.Project, error) {
+	key := strings.ToUpper(strings.TrimSpace(req.Key))
+
+	existing, err := s.projectRepo.GetByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrProjectKeyExists
+	}
+
+	project := &models.Project{
+		Name:        strings.TrimSpace(req.Name),
+		Key:         key,
+		Description: req.Description,
+		OwnerID:     userID,
+	}
+
+	if err := s.projectRepo.Create(ctx, project); err != nil {
+		return nil, err
+	}
+
+	return s.projectRepo.GetByID(ctx, project.ID)
+}
+
+func (s *projectService) GetProjects(ctx context.Context, req dto.ProjectListRequest, userID uint) ([]models.Project, error) {
+	return s.projectRepo.GetAllForAccount(ctx, userID, req.IncludeArchived)
+}
+
+// GetProjectByID hanya mengembalikan project jika user adalah member-nya.
+// Non-member mendapat ErrProjectNotFound supaya keberadaan project tidak bocor.
+func (s *projectService) GetProjectByID(ctx context.Context, id, userID uint) (*models.Project, error) {
+	project, err := s.projectRepo.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrProjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.projectRepo.GetMember(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, ErrProjectNotFound
+	}
+
+	return project, nil
+}
+
+func (s *projectService) UpdateProject(ctx context.Context, id uint, req dto.UpdateProjectRequest, userID uint) (*models.Project, error) {
+	project, err := s.getOwnedProject(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, errors.New("name is required")
+		}
+		project.Name = name
+	}
+	if req.Description != nil {
+		project.Description = *req.Description
+	}
+	if req.IsArchived != nil {
+		project.IsArchived = *req.IsArchived
+	}
+
+	if err := s.projectRepo.Update(ctx, project); err != nil {
+		return nil, err
+	}
+
+	return s.projectRepo.GetByID(ctx, id)
+}
+
+func (s *projectService) DeleteProject(ctx context.Context, id, userID uint) error {
+	if _, err := s.getOwnedProject(ctx, id, userID); err != nil {
+		return err
+	}
+
+	count, err := s.projectRepo.CountTasks(ctx, id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrProjectHasTasks
+	}
+
+	return s.projectRepo.Delete(ctx, id)
+}
+
+func (s *projectService) GetMembers(ctx context.Context, id, userID uint) ([]models.ProjectMember, error) {
+	if _, err := s.GetProjectByID(ctx, id, userID); err != nil {
+		return nil, err
+	}
+	return s.projectRepo.GetMembers(ctx, id)
+}
+
+func (s *projectService) AddMember(ctx context.Context, id uint, req dto.AddProjectMemberRequest, userID uint) (*models.ProjectMember, error) {
+	project, err := s.getOwnedProject(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.accountRepo.GetByID(ctx, req.AccountID); err != nil {
+		return nil, errors.New("account not found")
+	}
+
+	// member project harus sudah menjadi member workspace project tersebut
+	if workspaceID, ok := utils.WorkspaceIDFromContext(ctx); ok {
+		workspaceMember, err := s.workspaceRepo.GetMember(ctx, workspaceID, req.AccountID)
+		if err != nil {
+			return nil, err
+		}
+		if workspaceMember == nil {
+			return nil, ErrNotWorkspaceMember
+		}
+	}
+
+	role := req.Role
+	if role == "" {
+		role = models.ProjectRoleMember
+	}
+	if req.AccountID == project.OwnerID && role != models.ProjectRoleOwner {
+		return nil, ErrProjectOwnerMember
+	}
+
+	member := &models.ProjectMember{
+		ProjectID: id,
+		AccountID: req.AccountID,
+		Role:      role,
+	}
+
+	if err := s.projectRepo.SaveMember(ctx, member); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (s *projectService) RemoveMember(ctx context.Context, id, accountID, userID uint) error {
+	project, err := s.getOwnedProject(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if accountID == project.OwnerID {
+		return ErrProjectOwnerMember
+	}
+
+	member, err := s.projectRepo.GetMember(ctx, id, accountID)
+	if err != nil {
+		return err
+	}
+	if member == nil {
+		return ErrNotProjectMember
+	}
+
+	return s.projectRepo.RemoveMember(ctx, id, accountID)
+}
+
+// getOwnedProject memastikan user adalah member dengan role owner
+func (s *projectService) getOwnedProject(ctx context.Context, id, userID uint) (*models.Project, error) {
+	project, err := s.GetProjectByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.projectRepo.GetMember(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil || member.Role != models.ProjectRoleOwner {
+		return nil, ErrProjectForbidden
+	}
+
+	return project, nil
+}
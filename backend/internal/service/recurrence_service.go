@@ -0,0 +1,386 @@
+package service
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"backend/internal/utils"
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidRRule            = utils.ErrInvalidRRule
+	ErrInvalidTimezone         = errors.New("invalid timezone")
+	ErrRecurrenceNotFound      = errors.New("task is not recurring")
+	ErrRecurrenceNeedsDeadline = errors.New("recurring task needs a deadline as its first occurrence")
+	ErrInvalidOccurrence       = errors.New("occurrence is not an upcoming occurrence of the series")
+)
+
+// upcomingOccurrences adalah jumlah occurrence yang ditampilkan di response
+const upcomingOccurrences = 5
+
+// dueRecurrenceBatch membatasi jumlah seri per putaran worker; sisanya
+// diproses di putaran berikutnya
+const dueRecurrenceBatch = 100
+
+type RecurrenceService interface {
+	GetRecurrence(ctx context.Context, taskID uint) (*dto.RecurrenceResponse, error)
+	SetRecurrence(ctx context.Context, taskID uint, req dto.SetRecurrenceRequest, userID uint) (*dto.RecurrenceResponse, error)
+	DeleteRecurrence(ctx context.Context, taskID uint) error
+	SkipOccurrence(ctx context.Context, taskID uint, req dto.SkipOccurrenceRequest) (*dto.RecurrenceResponse, error)
+	ApplyToFuture(ctx context.Context, task *models.Task, deadlineChanged bool) error
+	HandleCompleted(ctx context.Context, task *models.Task) error
+	GenerateDue(ctx context.Context, now time.Time) (int, error)
+}
+
+type recurrenceService struct {
+	taskRepo       repository.TaskRepository
+	recurrenceRepo repository.RecurrenceRepository
+}
+
+func NewRecurrenceService(taskRepo repository.TaskRepository, recurrenceRepo repository.RecurrenceRepository) RecurrenceService {
+	return &recurrenceService{
+		taskRepo:       taskRepo,
+		recurrenceRepo: recurrenceRepo,
+	}
+}
+
+func (s *recurrenceService) GetRecurrence(ctx context.Context, taskID uint) (*dto.RecurrenceResponse, error) {
+	_, recurrence, err := s.getSeries(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	return toRecurrenceResponse(recurrence)
+}
+
+// SetRecurrence menjadikan task occurrence pertama dari seri baru, atau
+// mengganti rule seri yang sudah ada mulai dari occurrence task ini
+func (s *recurrenceService) SetRecurrence(ctx context.Context, taskID uint, req dto.SetRecurrenceRequest, userID uint) (*dto.RecurrenceResponse, error) {
+	task, err := s.taskRepo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.Deadline.IsZero() {
+		return nil, ErrRecurrenceNeedsDeadline
+	}
+
+	rule, location, err := parseRecurrence(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.RecurrenceID != nil {
+		recurrence, err := s.recurrenceRepo.GetByID(ctx, *task.RecurrenceID)
+		if err != nil {
+			return nil, err
+		}
+		recurrence.RRule = rule
+		recurrence.Timezone = location.String()
+		recurrence.StartAt = *task.OccurrenceAt
+		if recurrence.NextOccurrenceAt, err = nextOccurrence(recurrence, recurrence.LastOccurrenceAt); err != nil {
+			return nil, err
+		}
+		if err := s.recurrenceRepo.Update(ctx, recurrence); err != nil {
+			return nil, err
+		}
+		return toRecurrenceResponse(recurrence)
+	}
+
+	recurrence := &models.TaskRecurrence{
+		WorkspaceID:      task.WorkspaceID,
+		CreateAccountID:  userID,
+		RRule:            rule,
+		Timezone:         location.String(),
+		StartAt:          task.Deadline,
+		LastOccurrenceAt: task.Deadline,
+	}
+	copyRecurrenceTemplate(recurrence, task)
+	if recurrence.NextOccurrenceAt, err = nextOccurrence(recurrence, recurrence.LastOccurrenceAt); err != nil {
+		return nil, err
+	}
+
+	if err := s.recurrenceRepo.Create(ctx, recurrence, task.ID); err != nil {
+		return nil, err
+	}
+
+	return toRecurrenceResponse(recurrence)
+}
+
+func (s *recurrenceService) DeleteRecurrence(ctx context.Context, taskID uint) error {
+	_, recurrence, err := s.getSeries(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	return s.recurrenceRepo.Delete(ctx, recurrence.ID)
+}
+
+// SkipOccurrence men-skip occurrence task ini (task dihapus dan occurrence
+// berikutnya langsung dibuat) atau occurrence mendatang yang belum dibuat.
+// Semua langkah berjalan dalam satu transaksi supaya seri tidak pernah punya
+// dua occurrence aktif.
+func (s *recurrenceService) SkipOccurrence(ctx context.Context, taskID uint, req dto.SkipOccurrenceRequest) (*dto.RecurrenceResponse, error) {
+	var recurrence *models.TaskRecurrence
+	err := s.taskRepo.Transaction(ctx, func(ctx context.Context) error {
+		task, series, err := s.getSeries(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		recurrence = series
+
+		occurrenceAt := *task.OccurrenceAt
+		skipCurrent := req.OccurrenceAt == nil
+		if !skipCurrent {
+			occurrenceAt = *req.OccurrenceAt
+			if err := ensureUpcomingOccurrence(recurrence, occurrenceAt); err != nil {
+				return err
+			}
+		}
+
+		skip := models.TaskRecurrenceSkip{RecurrenceID: recurrence.ID, OccurrenceAt: occurrenceAt}
+		if err := s.recurrenceRepo.AddSkip(ctx, &skip); err != nil {
+			return err
+		}
+		recurrence.Skips = append(recurrence.Skips, skip)
+
+		if skipCurrent {
+			if recurrence.LastOccurrenceAt.Equal(occurrenceAt) {
+				if _, err := s.generateNext(ctx, recurrence); err != nil {
+					return err
+				}
+			}
+			return s.taskRepo.Delete(ctx, task.ID)
+		}
+		if recurrence.NextOccurrenceAt != nil && recurrence.NextOccurrenceAt.Equal(occurrenceAt) {
+			if recurrence.NextOccurrenceAt, err = nextOccurrence(recurrence, recurrence.LastOccurrenceAt); err != nil {
+				return err
+			}
+			return s.recurrenceRepo.Update(ctx, recurrence)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toRecurrenceResponse(recurrence)
+}
+
+// ApplyToFuture menyalin perubahan task ke template seri ("all future
+// occurrences"). Jika deadline berubah, seri digeser mulai dari task ini.
+// Dipanggil sebelum task disimpan karena OccurrenceAt task bisa ikut berubah.
+func (s *recurrenceService) ApplyToFuture(ctx context.Context, task *models.Task, deadlineChanged bool) error {
+	if task.RecurrenceID == nil {
+		return ErrRecurrenceNotFound
+	}
+
+	recurrence, err := s.recurrenceRepo.GetByID(ctx, *task.RecurrenceID)
+	if err != nil {
+		return err
+	}
+	copyRecurrenceTemplate(recurrence, task)
+
+	if deadlineChanged {
+		if task.Deadline.IsZero() {
+			return ErrRecurrenceNeedsDeadline
+		}
+		if recurrence.LastOccurrenceAt.Equal(*task.OccurrenceAt) {
+			recurrence.LastOccurrenceAt = task.Deadline
+			task.OccurrenceAt = &task.Deadline
+		}
+		recurrence.StartAt = task.Deadline
+		if recurrence.NextOccurrenceAt, err = nextOccurrence(recurrence, recurrence.LastOccurrenceAt); err != nil {
+			return err
+		}
+	}
+
+	return s.recurrenceRepo.Update(ctx, recurrence)
+}
+
+// HandleCompleted membuat occurrence berikutnya saat occurrence terakhir
+// sebuah seri selesai, tanpa menunggu jadwalnya tiba
+func (s *recurrenceService) HandleCompleted(ctx context.Context, task *models.Task) error {
+	if task.RecurrenceID == nil || task.OccurrenceAt == nil {
+		return nil
+	}
+
+	recurrence, err := s.recurrenceRepo.GetByID(ctx, *task.RecurrenceID)
+	if err != nil {
+		return err
+	}
+	if !recurrence.LastOccurrenceAt.Equal(*task.OccurrenceAt) {
+		return nil
+	}
+
+	_, err = s.generateNext(ctx, recurrence)
+	return err
+}
+
+// GenerateDue membuat occurrence berikutnya untuk seri yang occurrence
+// terakhirnya sudah jatuh tempo. Dipanggil berkala oleh worker.
+func (s *recurrenceService) GenerateDue(ctx context.Context, now time.Time) (int, error) {
+	recurrences, err := s.recurrenceRepo.GetDue(ctx, now, dueRecurrenceBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for i := range recurrences {
+		task, err := s.generateNext(ctx, &recurrences[i])
+		if err != nil {
+			log.Printf("Error generating occurrence for recurrence %d: %v", recurrences[i].ID, err)
+			continue
+		}
+		if task != nil {
+			created++
+		}
+	}
+
+	return created, nil
+}
+
+// generateNext membuat task untuk NextOccurrenceAt. Mengembalikan nil tanpa
+// error jika seri sudah selesai atau occurrence sudah dibuat proses lain.
+func (s *recurrenceService) generateNext(ctx context.Context, recurrence *models.TaskRecurrence) (*models.Task, error) {
+	if recurrence.IsFinished() {
+		return nil, nil
+	}
+
+	next, err := nextOccurrence(recurrence, *recurrence.NextOccurrenceAt)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := s.recurrenceRepo.Materialize(ctx, recurrence, next)
+	if errors.Is(err, repository.ErrOccurrenceExists) {
+		return nil, nil
+	}
+	return task, err
+}
+
+// getSeries mengambil task beserta seri-nya, ErrRecurrenceNotFound jika
+// task bukan task berulang
+func (s *recurrenceService) getSeries(ctx context.Context, taskID uint) (*models.Task, *models.TaskRecurrence, error) {
+	task, err := s.taskRepo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if task.RecurrenceID == nil || task.OccurrenceAt == nil {
+		return nil, nil, ErrRecurrenceNotFound
+	}
+
+	recurrence, err := s.recurrenceRepo.GetByID(ctx, *task.RecurrenceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return task, recurrence, nil
+}
+
+// parseRecurrence memvalidasi rule dan timezone, mengembalikan rule yang
+// sudah dinormalisasi
+func parseRecurrence(req dto.SetRecurrenceRequest) (string, *time.Location, error) {
+	if _, err := utils.ParseRRule(req.RRule); err != nil {
+		return "", nil, err
+	}
+
+	timezone := req.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return "", nil, ErrInvalidTimezone
+	}
+
+	rule := strings.TrimPrefix(strings.TrimSpace(req.RRule), "RRULE:")
+	return strings.ToUpper(rule), location, nil
+}
+
+// copyRecurrenceTemplate menyalin field task yang dipakai occurrence berikutnya
+func copyRecurrenceTemplate(recurrence *models.TaskRecurrence, task *models.Task) {
+	recurrence.Title = task.Title
+	recurrence.Description = task.Description
+	recurrence.Priority = task.Priority
+	recurrence.AccountID = task.AccountID
+	recurrence.ProjectID = task.ProjectID
+}
+
+// seriesRule mengembalikan rule, dtstart di timezone seri, dan fungsi skip
+func seriesRule(recurrence *models.TaskRecurrence) (*utils.RRule, time.Time, func(time.Time) bool, error) {
+	rule, err := utils.ParseRRule(recurrence.RRule)
+	if err != nil {
+		return nil, time.Time{}, nil, err
+	}
+	location, err := time.LoadLocation(recurrence.Timezone)
+	if err != nil {
+		return nil, time.Time{}, nil, ErrInvalidTimezone
+	}
+
+	skipped := func(occurrence time.Time) bool {
+		for _, skip := range recurrence.Skips {
+			if skip.OccurrenceAt.Equal(occurrence) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return rule, recurrence.StartAt.In(location), skipped, nil
+}
+
+// nextOccurrence menghitung occurrence setelah `after`, nil jika seri selesai
+func nextOccurrence(recurrence *models.TaskRecurrence, after time.Time) (*time.Time, error) {
+	rule, dtstart, skipped, err := seriesRule(recurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	next, ok := rule.Next(dtstart, after, skipped)
+	if !ok {
+		return nil, nil
+	}
+	return &next, nil
+}
+
+func ensureUpcomingOccurrence(recurrence *models.TaskRecurrence, occurrenceAt time.Time) error {
+	if !occurrenceAt.After(recurrence.LastOccurrenceAt) {
+		return ErrInvalidOccurrence
+	}
+
+	rule, dtstart, _, err := seriesRule(recurrence)
+	if err != nil {
+		return err
+	}
+
+	next, ok := rule.Next(dtstart, occurrenceAt.Add(-time.Second), nil)
+	if !ok || !next.Equal(occurrenceAt) {
+		return ErrInvalidOccurrence
+	}
+	return nil
+}
+
+func toRecurrenceResponse(recurrence *models.TaskRecurrence) (*dto.RecurrenceResponse, error) {
+	rule, dtstart, skipped, err := seriesRule(recurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.RecurrenceResponse{
+		ID:               recurrence.ID,
+		RRule:            recurrence.RRule,
+		Timezone:         recurrence.Timezone,
+		StartAt:          recurrence.StartAt,
+		LastOccurrenceAt: recurrence.LastOccurrenceAt,
+		NextOccurrenceAt: recurrence.NextOccurrenceAt,
+		Skipped:          []time.Time{},
+		Upcoming:         rule.Upcoming(dtstart, recurrence.LastOccurrenceAt, upcomingOccurrences, skipped),
+	}
+	for _, skip := range recurrence.Skips {
+		response.Skipped = append(response.Skipped, skip.OccurrenceAt)
+	}
+
+	return response, nil
+}
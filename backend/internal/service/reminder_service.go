@@ -0,0 +1,154 @@
+package service
+
+import (
+	"backend/internal/models"
+	"backend/internal/notification"
+	"backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultReminderLeadTimes dipakai jika REMINDER_LEAD_TIMES tidak diisi
+var DefaultReminderLeadTimes = []time.Duration{24 * time.Hour, time.Hour}
+
+// reminderBatch membatasi jumlah task per jenis reminder per putaran worker
+const reminderBatch = 200
+
+type ReminderService interface {
+	ProcessDue(ctx context.Context, now time.Time) (int, error)
+}
+
+type reminderService struct {
+	reminderRepo repository.ReminderRepository
+	channel      notification.Channel
+	leadTimes    []time.Duration
+}
+
+func NewReminderService(reminderRepo repository.ReminderRepository, channel notification.Channel, leadTimes []time.Duration) ReminderService {
+	sorted := append([]time.Duration(nil), leadTimes...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	return &reminderService{
+		reminderRepo: reminderRepo,
+		channel:      channel,
+		leadTimes:    sorted,
+	}
+}
+
+// ParseLeadTimes mem-parse daftar durasi dipisah koma, misalnya "24h,1h"
+func ParseLeadTimes(value string) ([]time.Duration, error) {
+	if strings.TrimSpace(value) == "" {
+		return DefaultReminderLeadTimes, nil
+	}
+
+	var leadTimes []time.Duration
+	for _, item := range strings.Split(value, ",") {
+		lead, err := time.ParseDuration(strings.TrimSpace(item))
+		if err != nil || lead < time.Minute {
+			return nil, fmt.Errorf("invalid reminder lead time %q", item)
+		}
+		leadTimes = append(leadTimes, lead)
+	}
+	return leadTimes, nil
+}
+
+// ProcessDue memperbarui status overdue lalu mengirim reminder deadline dan
+// overdue yang belum terkirim. Mengembalikan jumlah reminder yang terkirim.
+func (s *reminderService) ProcessDue(ctx context.Context, now time.Time) (int, error) {
+	if _, err := s.reminderRepo.ClearOverdue(ctx, now); err != nil {
+		return 0, err
+	}
+	if _, err := s.reminderRepo.MarkOverdue(ctx, now); err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for i, lead := range s.leadTimes {
+		var floor time.Duration
+		if i > 0 {
+			floor = s.leadTimes[i-1]
+		}
+
+		tasks, err := s.reminderRepo.GetDueSoon(ctx, now, lead, floor, reminderBatch)
+		if err != nil {
+			return sent, err
+		}
+		for i := range tasks {
+			message := fmt.Sprintf("Task %q is due in %s", tasks[i].Title, formatLeadTime(lead))
+			sent += s.notify(ctx, &tasks[i], models.ReminderKindDueSoon, lead, message, now)
+		}
+	}
+
+	tasks, err := s.reminderRepo.GetOverdue(ctx, reminderBatch)
+	if err != nil {
+		return sent, err
+	}
+	for i := range tasks {
+		message := fmt.Sprintf("Task %q is overdue since %s", tasks[i].Title, tasks[i].Deadline.Format(time.RFC3339))
+		sent += s.notify(ctx, &tasks[i], models.ReminderKindOverdue, 0, message, now)
+	}
+
+	return sent, nil
+}
+
+// notify mengirim reminder ke penerima task dan mengembalikan jumlah yang terkirim
+func (s *reminderService) notify(ctx context.Context, task *models.Task, kind string, lead time.Duration, message string, now time.Time) int {
+	sent := 0
+	for _, recipient := range reminderRecipients(task) {
+		reminder := &models.TaskReminder{
+			TaskID:      task.ID,
+			AccountID:   recipient.ID,
+			Kind:        kind,
+			LeadMinutes: int(lead.Minutes()),
+			Deadline:    task.Deadline,
+			Channel:     s.channel.Name(),
+			SentAt:      now,
+		}
+
+		err := s.reminderRepo.Claim(ctx, reminder, func() error {
+			return s.channel.Send(ctx, notification.Notification{
+				Kind:      kind,
+				AccountID: recipient.ID,
+				Email:     recipient.Email,
+				TaskID:    task.ID,
+				TaskTitle: task.Title,
+				Deadline:  task.Deadline,
+				Message:   message,
+			})
+		})
+		if errors.Is(err, repository.ErrReminderSent) {
+			continue
+		}
+		if err != nil {
+			log.Printf("Error sending %s reminder for task %d: %v", kind, task.ID, err)
+			continue
+		}
+		sent++
+	}
+	return sent
+}
+
+// reminderRecipients mengembalikan assignee dan watcher task tanpa duplikat
+func reminderRecipients(task *models.Task) []models.Account {
+	seen := make(map[uint]bool)
+	var recipients []models.Account
+	for _, account := range append(append([]models.Account{}, task.Assignees...), task.Watchers...) {
+		if !seen[account.ID] {
+			seen[account.ID] = true
+			recipients = append(recipients, account)
+		}
+	}
+	return recipients
+}
+
+func formatLeadTime(lead time.Duration) string {
+	if lead%time.Hour == 0 {
+		return fmt.Sprintf("%dh", int(lead.Hours()))
+	}
+	return fmt.Sprintf("%dm", int(lead.Minutes()))
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"backend/internal/dto"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// exportFlushEvery adalah jumlah baris antar flush ke client, supaya
+// download berjalan bertahap dan tidak ditahan di buffer
+const exportFlushEvery = 200
+
+var exportCSVHeader = []string{
+	"id", "title", "description", "status", "priority", "deadline", "estimate_minutes",
+	"overdue_at", "project_id", "project", "parent_id", "assignees", "labels", "version",
+}
+
+// taskEncoder menulis baris export dalam satu format. begin dipanggil
+// sebelum baris pertama (atau saat tidak ada baris sama sekali).
+type taskEncoder interface {
+	begin() error
+	encode(row dto.TaskExportRow) error
+	end() error
+}
+
+func (s *taskService) ExportTasks(ctx context.Context, req dto.TaskExportRequest, w io.Writer) error {
+	var encoder taskEncoder
+	switch req.Format {
+	case dto.ExportFormatNDJSON:
+		encoder = &ndjsonTaskEncoder{w: w}
+	case dto.ExportFormatJSON:
+		encoder = &jsonTaskEncoder{w: w}
+	default:
+		encoder = &csvTaskEncoder{w: csv.NewWriter(w)}
+	}
+
+	count := 0
+	err := s.taskRepo.Export(ctx, &req.TaskListRequest, func(row dto.TaskExportRow) error {
+		if count == 0 {
+			if err := encoder.begin(); err != nil {
+				return err
+			}
+		}
+		if err := encoder.encode(row); err != nil {
+			return err
+		}
+		count++
+		if count%exportFlushEvery == 0 {
+			flush(w)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		if err := encoder.begin(); err != nil {
+			return err
+		}
+	}
+	if err := encoder.end(); err != nil {
+		return err
+	}
+	flush(w)
+	return nil
+}
+
+// flush meneruskan data yang sudah ditulis ke client jika w mendukungnya
+// (http.ResponseWriter)
+func flush(w io.Writer) {
+	if flusher, ok := w.(interface{ Flush() }); ok {
+		flusher.Flush()
+	}
+}
+
+type csvTaskEncoder struct {
+	w *csv.Writer
+}
+
+func (e *csvTaskEncoder) begin() error {
+	return e.w.Write(exportCSVHeader)
+}
+
+func (e *csvTaskEncoder) encode(row dto.TaskExportRow) error {
+	deadline := ""
+	if !row.Deadline.IsZero() {
+		deadline = row.Deadline.Format(time.RFC3339)
+	}
+	overdueAt := ""
+	if row.OverdueAt != nil {
+		overdueAt = row.OverdueAt.Format(time.RFC3339)
+	}
+	estimate := ""
+	if row.EstimateMinutes != nil {
+		estimate = strconv.Itoa(*row.EstimateMinutes)
+	}
+
+	return e.w.Write([]string{
+		strconv.FormatUint(uint64(row.ID), 10),
+		csvText(row.Title),
+		csvText(row.Description),
+		row.Status,
+		row.Priority.String(),
+		deadline,
+		estimate,
+		overdueAt,
+		optionalID(row.ProjectID),
+		csvText(optionalString(row.ProjectName)),
+		optionalID(row.ParentID),
+		optionalString(row.Assignees),
+		csvText(optionalString(row.Labels)),
+		strconv.FormatUint(uint64(row.Version), 10),
+	})
+}
+
+func (e *csvTaskEncoder) end() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+type ndjsonTaskEncoder struct {
+	w io.Writer
+}
+
+func (e *ndjsonTaskEncoder) begin() error {
+	return nil
+}
+
+func (e *ndjsonTaskEncoder) encode(row dto.TaskExportRow) error {
+	// Encoder menambahkan newline setelah setiap objek
+	return json.NewEncoder(e.w).Encode(row)
+}
+
+func (e *ndjsonTaskEncoder) end() error {
+	return nil
+}
+
+// jsonTaskEncoder menulis satu array JSON secara bertahap, elemen per elemen
+type jsonTaskEncoder struct {
+	w     io.Writer
+	first bool
+}
+
+func (e *jsonTaskEncoder) begin() error {
+	e.first = true
+	_, err := io.WriteString(e.w, "[")
+	return err
+}
+
+func (e *jsonTaskEncoder) encode(row dto.TaskExportRow) error {
+	data, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+	if !e.first {
+		if _, err := io.WriteString(e.w, ","); err != nil {
+			return err
+		}
+	}
+	e.first = false
+	_, err = e.w.Write(data)
+	return err
+}
+
+func (e *jsonTaskEncoder) end() error {
+	_, err := io.WriteString(e.w, "]\n")
+	return err
+}
+
+// csvText mencegah formula injection: teks yang diawali karakter formula
+// spreadsheet diberi awalan tanda kutip supaya dibaca sebagai teks
+func csvText(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func optionalID(id *uint) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.FormatUint(uint64(*id), 10)
+}
+
+func optionalString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
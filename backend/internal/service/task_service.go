@@ -4,27 +4,94 @@ import (
 	"backend/internal/dto"
 	"backend/internal/models"
 	"backend/internal/repository"
+	"backend/internal/utils"
 	"context"
 	"errors"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrTaskHasOpenChildren = errors.New("task still has open subtasks or checklist items")
+	ErrInvalidParent       = errors.New("task cannot be its own parent or a child of its subtasks")
+	ErrTaskBlocked         = errors.New("task is blocked by tasks that are not done yet")
+	ErrBulkTooLarge        = fmt.Errorf("bulk operation is limited to %d tasks", maxBulkTasks)
+	ErrBulkMissingParam    = errors.New("missing parameter for bulk operation")
 )
 
+// maxBulkTasks membatasi jumlah task dalam satu operasi bulk
+const maxBulkTasks = 500
+
+// errBulkRollback membatalkan transaksi bulk (dry run atau ada task gagal)
+var errBulkRollback = errors.New("bulk operation rolled back")
+
 type TaskService interface {
 	CreateTask(ctx context.Context, req dto.CreateTaskRequest, userID uint) (*dto.TaskResponse, error)
 	GetAllTasks(ctx context.Context, req dto.TaskListRequest) ([]dto.TaskResponse, int64, error)
+	// GetTaskPage adalah GetAllTasks dengan keyset pagination (mode cursor)
+	GetTaskPage(ctx context.Context, req dto.TaskListRequest) ([]dto.TaskResponse, *dto.TaskPageInfo, error)
 	GetTasksByStatus(ctx context.Context, status string) ([]dto.TaskResponse, error)
 	GetTaskByID(ctx context.Context, id uint) (*dto.TaskResponse, error)
+	GetSubtasks(ctx context.Context, id uint) ([]dto.TaskResponse, error)
 	UpdateTask(ctx context.Context, id uint, req dto.UpdateTaskRequest, userID uint) (*dto.TaskResponse, error)
 	DeleteTask(ctx context.Context, id uint) error
 	GetTasksByFilter(ctx context.Context, req dto.TaskFilterRequest) ([]dto.TaskResponse, error)
+	MoveTask(ctx context.Context, id uint, req dto.MoveTaskRequest, userID uint) (*dto.TaskResponse, error)
+	GetBoard(ctx context.Context, req dto.BoardRequest) (*dto.BoardResponse, error)
+	BulkTasks(ctx context.Context, req dto.BulkTaskRequest, userID uint) (*dto.BulkTaskResponse, error)
+	// ExportTasks menulis semua task yang cocok dengan filter ke w dalam format
+	// req.Format. Tidak ada yang ditulis jika query gagal sebelum baris pertama.
+	ExportTasks(ctx context.Context, req dto.TaskExportRequest, w io.Writer) error
+	// GetStats menghitung ringkasan dan time series task untuk dashboard
+	GetStats(ctx context.Context, req dto.TaskStatsRequest) (*dto.TaskStatsResponse, error)
 }
 
+// boardStatuses adalah kolom board secara berurutan
+var boardStatuses = []string{models.TaskStatusTodo, models.TaskStatusInProgress, models.TaskStatusDone}
+
+var ErrInvalidNeighbor = repository.ErrInvalidNeighbor
+
+// ErrInvalidOrder berarti TaskListRequest.Order memakai field di luar allowlist
+var ErrInvalidOrder = repository.ErrInvalidOrder
+
+// ErrInvalidCursor berarti cursor list rusak atau dibuat untuk urutan lain
+var ErrInvalidCursor = repository.ErrInvalidCursor
+
+// ErrVersionMismatch berarti If-Match tidak cocok dengan version task, atau
+// task diubah request lain di antara dibaca dan disimpan
+var ErrVersionMismatch = repository.ErrVersionConflict
+
 type taskService struct {
-	taskRepo repository.TaskRepository
+	taskRepo          repository.TaskRepository
+	dependencyRepo    repository.DependencyRepository
+	labelRepo         repository.LabelRepository
+	projectRepo       repository.ProjectRepository
+	workspaceRepo     repository.WorkspaceRepository
+	recurrenceService RecurrenceService
 }
 
-func NewTaskService(taskRepo repository.TaskRepository) TaskService {
+func NewTaskService(
+	taskRepo repository.TaskRepository,
+	dependencyRepo repository.DependencyRepository,
+	labelRepo repository.LabelRepository,
+	projectRepo repository.ProjectRepository,
+	workspaceRepo repository.WorkspaceRepository,
+	recurrenceService RecurrenceService,
+) TaskService {
 	return &taskService{
-		taskRepo: taskRepo,
+		taskRepo:          taskRepo,
+		dependencyRepo:    dependencyRepo,
+		labelRepo:         labelRepo,
+		projectRepo:       projectRepo,
+		workspaceRepo:     workspaceRepo,
+		recurrenceService: recurrenceService,
 	}
 }
 
@@ -33,25 +100,87 @@ func (s *taskService) CreateTask(ctx context.Context, req dto.CreateTaskRequest,
 		return nil, errors.New("title is required")
 	}
 
+	projectID := req.ProjectID
+	if req.ParentID != nil {
+		parent, err := s.taskRepo.GetByID(ctx, *req.ParentID)
+		if err != nil {
+			return nil, errors.New("parent task not found")
+		}
+		// subtask ikut project parent-nya jika tidak disebutkan
+		if projectID == nil {
+			projectID = parent.ProjectID
+		}
+	}
+
+	// assignee utama selalu menjadi assignee pertama
+	accountID := req.AccountID
+	if accountID == 0 && len(req.AssigneeIDs) > 0 {
+		accountID = req.AssigneeIDs[0]
+	}
+	assigneeIDs := uniqueIDs(append([]uint{accountID}, req.AssigneeIDs...))
+	watcherIDs := uniqueIDs(req.WatcherIDs)
+
+	if err := ensureWorkspaceMembers(ctx, s.workspaceRepo, append(assigneeIDs, watcherIDs...)...); err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureProjectAccess(ctx, projectID, userID, append(assigneeIDs, watcherIDs...)...); err != nil {
+		return nil, err
+	}
+
+	labels, err := s.findLabels(ctx, req.LabelIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Recurrence != nil {
+		if req.Deadline.IsZero() {
+			return nil, ErrRecurrenceNeedsDeadline
+		}
+		if _, _, err := parseRecurrence(*req.Recurrence); err != nil {
+			return nil, err
+		}
+	}
+
+	priority := models.DefaultPriority
+	if req.Priority != nil {
+		priority = *req.Priority
+	}
+
 	task := &models.Task{
 		CreateAccountID: userID,
-		AccountID:       req.AccountID,
+		AccountID:       accountID,
+		Assignees:       toAccounts(assigneeIDs),
+		Watchers:        toAccounts(watcherIDs),
+		ProjectID:       projectID,
+		ParentID:        req.ParentID,
 		Title:           req.Title,
 		Description:     req.Description,
 		Status:          req.Status,
+		Priority:        priority,
 		Deadline:        req.Deadline,
+		EstimateMinutes: positiveOrNil(req.EstimateMinutes),
+		Labels:          labels,
 	}
 
-	if err := s.taskRepo.Create(ctx, task); err != nil {
-		return nil, err
-	}
-
-	createdTask, err := s.taskRepo.GetByID(ctx, task.ID)
+	// task dan seri recurrence-nya disimpan bersama, jadi recurrence yang
+	// gagal tidak meninggalkan task biasa
+	err = s.taskRepo.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.taskRepo.Create(ctx, task); err != nil {
+			return err
+		}
+		if req.Recurrence != nil {
+			if _, err := s.recurrenceService.SetRecurrence(ctx, task.ID, *req.Recurrence, userID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return s.toTaskResponse(createdTask), nil
+	return s.GetTaskByID(ctx, task.ID)
 }
 
 func (s *taskService) GetAllTasks(ctx context.Context, req dto.TaskListRequest) ([]dto.TaskResponse, int64, error) {
@@ -60,21 +189,97 @@ func (s *taskService) GetAllTasks(ctx context.Context, req dto.TaskListRequest)
 		return nil, 0, err
 	}
 
-	responses := make([]dto.TaskResponse, len(tasks))
-	for i, task := range tasks {
-		responses[i] = *s.toTaskResponse(&task)
+	responses, err := s.toListResponses(ctx, tasks, &req)
+	if err != nil {
+		return nil, 0, err
 	}
-
 	return responses, count, nil
 }
 
+func (s *taskService) GetTaskPage(ctx context.Context, req dto.TaskListRequest) ([]dto.TaskResponse, *dto.TaskPageInfo, error) {
+	tasks, info, err := s.taskRepo.GetPage(ctx, &req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responses, err := s.toListResponses(ctx, tasks, &req)
+	if err != nil {
+		return nil, nil, err
+	}
+	return responses, info, nil
+}
+
+// toListResponses membuat response list, dengan highlight jika ada pencarian
+func (s *taskService) toListResponses(ctx context.Context, tasks []models.Task, req *dto.TaskListRequest) ([]dto.TaskResponse, error) {
+	responses, err := s.toTaskResponses(ctx, tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Search != nil {
+		if err := s.attachHighlights(ctx, responses, strings.TrimSpace(*req.Search)); err != nil {
+			return nil, err
+		}
+	}
+	return responses, nil
+}
+
+// searchHighlighter meng-escape teks task lalu mengganti penanda dari
+// repository dengan <mark>, supaya aman dirender sebagai HTML
+var searchHighlighter = strings.NewReplacer(
+	repository.SearchHighlightStart, "<mark>",
+	repository.SearchHighlightStop, "</mark>",
+)
+
+func (s *taskService) attachHighlights(ctx context.Context, responses []dto.TaskResponse, search string) error {
+	ids := make([]uint, len(responses))
+	for i, response := range responses {
+		ids[i] = response.ID
+	}
+
+	highlights, err := s.taskRepo.GetHighlights(ctx, ids, search)
+	if err != nil {
+		return err
+	}
+
+	for i := range responses {
+		highlight, ok := highlights[responses[i].ID]
+		if !ok {
+			continue
+		}
+		responses[i].Highlight = &dto.TaskHighlight{
+			Title:       searchHighlighter.Replace(html.EscapeString(highlight.Title)),
+			Description: searchHighlighter.Replace(html.EscapeString(highlight.Description)),
+		}
+	}
+	return nil
+}
+
 func (s *taskService) GetTaskByID(ctx context.Context, id uint) (*dto.TaskResponse, error) {
 	task, err := s.taskRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.toTaskResponse(task), nil
+	responses, err := s.toTaskResponses(ctx, []models.Task{*task})
+	if err != nil {
+		return nil, err
+	}
+
+	return &responses[0], nil
+}
+
+func (s *taskService) GetSubtasks(ctx context.Context, id uint) ([]dto.TaskResponse, error) {
+	if _, err := s.taskRepo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.taskRepo.GetSubtasks(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toTaskResponses(ctx, tasks)
 }
 
 func (s *taskService) UpdateTask(ctx context.Context, id uint, req dto.UpdateTaskRequest, userID uint) (*dto.TaskResponse, error) {
@@ -82,6 +287,10 @@ func (s *taskService) UpdateTask(ctx context.Context, id uint, req dto.UpdateTas
 	if err != nil {
 		return nil, err
 	}
+	if req.IfMatch != nil && !containsVersion(req.IfMatch, task.Version) {
+		return nil, ErrVersionMismatch
+	}
+	before := *task
 
 	if req.Title != nil {
 		task.Title = *req.Title
@@ -89,27 +298,106 @@ func (s *taskService) UpdateTask(ctx context.Context, id uint, req dto.UpdateTas
 	if req.Description != nil {
 		task.Description = *req.Description
 	}
-	if req.Status != nil {
+	if req.Scope == dto.RecurrenceScopeFuture && task.RecurrenceID == nil {
+		return nil, ErrRecurrenceNotFound
+	}
+
+	completed := false
+	if req.Status != nil && *req.Status != task.Status {
+		if err := s.ensureCanTransition(ctx, task, *req.Status); err != nil {
+			return nil, err
+		}
+		// pindah kolom: taruh di akhir kolom tujuan
+		rank, err := s.taskRepo.NextRank(ctx, task.WorkspaceID, *req.Status)
+		if err != nil {
+			return nil, err
+		}
 		task.Status = *req.Status
+		task.Rank = rank
+		completed = task.IsDone()
+		if completed {
+			task.OverdueAt = nil
+		}
+	}
+	if req.Priority != nil {
+		task.Priority = *req.Priority
 	}
+	deadlineChanged := req.Deadline != nil && !req.Deadline.Equal(task.Deadline)
 	if req.Deadline != nil {
 		task.Deadline = *req.Deadline
 	}
+	// status overdue dihitung ulang oleh worker reminder untuk deadline baru
+	if deadlineChanged {
+		task.OverdueAt = nil
+	}
+	if req.EstimateMinutes != nil {
+		task.EstimateMinutes = positiveOrNil(req.EstimateMinutes)
+	}
+	if req.ProjectID != nil {
+		// project_id = 0 mengeluarkan task dari project
+		if *req.ProjectID == 0 {
+			task.ProjectID = nil
+		} else {
+			if err := s.ensureProjectAccess(ctx, req.ProjectID, userID, participantIDs(task)...); err != nil {
+				return nil, err
+			}
+			task.ProjectID = req.ProjectID
+		}
+	}
+	if req.ParentID != nil {
+		// parent_id = 0 melepas task dari parent-nya
+		if *req.ParentID == 0 {
+			task.ParentID = nil
+		} else {
+			if err := s.ensureValidParent(ctx, task.ID, *req.ParentID); err != nil {
+				return nil, err
+			}
+			task.ParentID = req.ParentID
+		}
+	}
 	task.UpdateAccountID = &userID
 
-	if err := s.taskRepo.Update(ctx, task); err != nil {
+	if _, err := s.findLabels(ctx, req.AddLabelIDs); err != nil {
 		return nil, err
 	}
 
-	updatedTask, err := s.taskRepo.GetByID(ctx, id)
+	// perubahan seri, kolom task dan label disimpan bersama, jadi version
+	// yang bentrok tidak meninggalkan perubahan setengah jalan
+	err = s.taskRepo.Transaction(ctx, func(ctx context.Context) error {
+		if req.Scope == dto.RecurrenceScopeFuture {
+			if err := s.recurrenceService.ApplyToFuture(ctx, task, deadlineChanged); err != nil {
+				return err
+			}
+		}
+
+		if err := s.taskRepo.Update(ctx, task, taskChanges(&before, task)); err != nil {
+			return err
+		}
+
+		if err := s.taskRepo.AttachLabels(ctx, id, req.AddLabelIDs); err != nil {
+			return err
+		}
+		return s.taskRepo.DetachLabels(ctx, id, req.RemoveLabelIDs)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return s.toTaskResponse(updatedTask), nil
+	// task sudah tersimpan; jika gagal, worker akan membuat occurrence
+	// berikutnya saat jadwalnya tiba
+	if completed {
+		if err := s.recurrenceService.HandleCompleted(ctx, task); err != nil {
+			log.Printf("Error generating next occurrence for task %d: %v", id, err)
+		}
+	}
+
+	return s.GetTaskByID(ctx, id)
 }
 
 func (s *taskService) DeleteTask(ctx context.Context, id uint) error {
+	if _, err := s.taskRepo.GetByID(ctx, id); err != nil {
+		return err
+	}
 	return s.taskRepo.Delete(ctx, id)
 }
 
@@ -119,14 +407,607 @@ func (s *taskService) GetTasksByStatus(ctx context.Context, status string) ([]dt
 		return nil, err
 	}
 
+	return s.toTaskResponses(ctx, tasks)
+}
+
+func (s *taskService) GetTasksByFilter(ctx context.Context, req dto.TaskFilterRequest) ([]dto.TaskResponse, error) {
+	tasks, err := s.taskRepo.GetByFilter(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toTaskResponses(ctx, tasks)
+}
+
+// MoveTask memindahkan kartu di board ke kolom dan posisi tertentu
+func (s *taskService) MoveTask(ctx context.Context, id uint, req dto.MoveTaskRequest, userID uint) (*dto.TaskResponse, error) {
+	task, err := s.taskRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if (req.BeforeID != nil && *req.BeforeID == id) || (req.AfterID != nil && *req.AfterID == id) {
+		return nil, ErrInvalidNeighbor
+	}
+
+	completed := false
+	if req.Status != task.Status {
+		if err := s.ensureCanTransition(ctx, task, req.Status); err != nil {
+			return nil, err
+		}
+		completed = req.Status == models.TaskStatusDone
+	}
+
+	if err := s.taskRepo.Move(ctx, task, req.Status, req.BeforeID, req.AfterID, userID); err != nil {
+		return nil, err
+	}
+
+	if completed {
+		task.Status = req.Status
+		if err := s.recurrenceService.HandleCompleted(ctx, task); err != nil {
+			log.Printf("Error generating next occurrence for task %d: %v", id, err)
+		}
+	}
+
+	return s.GetTaskByID(ctx, id)
+}
+
+// GetBoard mengelompokkan task per kolom status dalam urutan rank
+func (s *taskService) GetBoard(ctx context.Context, req dto.BoardRequest) (*dto.BoardResponse, error) {
+	tasks, err := s.taskRepo.GetBoard(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	responses, err := s.toTaskResponses(ctx, tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	// status di luar kolom bawaan tetap ditampilkan sebagai kolom tambahan
+	statuses := append([]string{}, boardStatuses...)
+	for _, response := range responses {
+		if !containsStatus(statuses, response.Status) {
+			statuses = append(statuses, response.Status)
+		}
+	}
+
+	board := &dto.BoardResponse{Columns: make([]dto.BoardColumn, len(statuses))}
+	columns := make(map[string]*dto.BoardColumn, len(statuses))
+	for i, status := range statuses {
+		board.Columns[i] = dto.BoardColumn{Status: status, Tasks: []dto.TaskResponse{}}
+		columns[status] = &board.Columns[i]
+	}
+
+	for _, response := range responses {
+		column := columns[response.Status]
+		column.Tasks = append(column.Tasks, response)
+		column.Count++
+	}
+
+	return board, nil
+}
+
+// BulkTasks menjalankan satu operasi ke banyak task dalam satu transaksi.
+// Setiap task diproses di savepoint sendiri supaya kegagalan satu task tetap
+// tercatat per item; jika ada yang gagal, atau DryRun, seluruh perubahan
+// di-rollback sehingga operasi bulk selalu all-or-nothing.
+func (s *taskService) BulkTasks(ctx context.Context, req dto.BulkTaskRequest, userID uint) (*dto.BulkTaskResponse, error) {
+	if err := s.validateBulkRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	ids := uniqueIDs(req.IDs)
+	if len(ids) == 0 && req.Filter != nil {
+		var err error
+		if ids, err = s.taskRepo.GetIDsByFilter(ctx, *req.Filter, maxBulkTasks+1); err != nil {
+			return nil, err
+		}
+	}
+	if len(ids) > maxBulkTasks {
+		return nil, ErrBulkTooLarge
+	}
+
+	response := &dto.BulkTaskResponse{
+		Operation: req.Operation,
+		DryRun:    req.DryRun,
+		Total:     len(ids),
+		Results:   make([]dto.BulkTaskResult, len(ids)),
+	}
+
+	var completed []models.Task
+	err := s.taskRepo.Transaction(ctx, func(ctx context.Context) error {
+		tasks, err := s.taskRepo.LockByIDs(ctx, ids)
+		if err != nil {
+			return err
+		}
+		byID := make(map[uint]*models.Task, len(tasks))
+		for i := range tasks {
+			byID[tasks[i].ID] = &tasks[i]
+		}
+
+		for _, i := range bulkOrder(ids, byID) {
+			result := &response.Results[i]
+			result.ID = ids[i]
+
+			task, ok := byID[ids[i]]
+			if !ok {
+				result.Result = dto.BulkResultFailed
+				result.Error = gorm.ErrRecordNotFound.Error()
+				continue
+			}
+
+			err := s.taskRepo.Transaction(ctx, func(ctx context.Context) error {
+				var err error
+				result.Result, result.Changes, err = s.applyBulk(ctx, task, req, userID)
+				return err
+			})
+			if err != nil {
+				result.Result = dto.BulkResultFailed
+				result.Changes = nil
+				result.Error = err.Error()
+				continue
+			}
+			if req.Operation == dto.BulkOpSetStatus && result.Result == dto.BulkResultUpdated && task.IsDone() {
+				completed = append(completed, *task)
+			}
+		}
+
+		for _, result := range response.Results {
+			if result.Result == dto.BulkResultFailed {
+				response.Failed++
+			} else {
+				response.Succeeded++
+			}
+		}
+		if response.Failed > 0 || req.DryRun {
+			return errBulkRollback
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBulkRollback) {
+		return nil, err
+	}
+	response.Applied = err == nil
+
+	if response.Applied {
+		for i := range completed {
+			if err := s.recurrenceService.HandleCompleted(ctx, &completed[i]); err != nil {
+				log.Printf("Error generating next occurrence for task %d: %v", completed[i].ID, err)
+			}
+		}
+	}
+
+	return response, nil
+}
+
+// validateBulkRequest memastikan parameter operasi bulk lengkap dan valid
+// sebelum transaksi dimulai
+func (s *taskService) validateBulkRequest(ctx context.Context, req dto.BulkTaskRequest) error {
+	switch req.Operation {
+	case dto.BulkOpSetStatus:
+		if req.Status == nil {
+			return fmt.Errorf("%w: status", ErrBulkMissingParam)
+		}
+	case dto.BulkOpReassign:
+		if req.AccountID == nil || *req.AccountID == 0 {
+			return fmt.Errorf("%w: account_id", ErrBulkMissingParam)
+		}
+		return ensureWorkspaceMembers(ctx, s.workspaceRepo, *req.AccountID)
+	case dto.BulkOpSetDeadline:
+		if req.Deadline == nil {
+			return fmt.Errorf("%w: deadline", ErrBulkMissingParam)
+		}
+	case dto.BulkOpAddLabel:
+		if req.LabelID == nil {
+			return fmt.Errorf("%w: label_id", ErrBulkMissingParam)
+		}
+		_, err := s.findLabels(ctx, []uint{*req.LabelID})
+		return err
+	}
+	return nil
+}
+
+// applyBulk menerapkan operasi bulk ke satu task yang sudah dikunci dan
+// mengembalikan hasil beserta field yang berubah
+func (s *taskService) applyBulk(ctx context.Context, task *models.Task, req dto.BulkTaskRequest, userID uint) (string, []dto.BulkFieldChange, error) {
+	switch req.Operation {
+	case dto.BulkOpSetStatus:
+		status := *req.Status
+		if task.Status == status {
+			return dto.BulkResultUnchanged, nil, nil
+		}
+		if err := s.ensureCanTransition(ctx, task, status); err != nil {
+			return "", nil, err
+		}
+		// pindah kolom: taruh di akhir kolom tujuan
+		if err := s.taskRepo.Move(ctx, task, status, nil, nil, userID); err != nil {
+			return "", nil, err
+		}
+		change := dto.BulkFieldChange{Field: "status", From: task.Status, To: status}
+		task.Status = status
+		return dto.BulkResultUpdated, []dto.BulkFieldChange{change}, nil
+
+	case dto.BulkOpReassign:
+		accountID := *req.AccountID
+		current := make([]uint, 0, len(task.Assignees))
+		for _, account := range task.Assignees {
+			current = append(current, account.ID)
+		}
+		if task.AccountID == accountID && len(current) == 1 {
+			return dto.BulkResultUnchanged, nil, nil
+		}
+		if task.ProjectID != nil {
+			if err := ensureProjectMembers(ctx, s.projectRepo, *task.ProjectID, accountID); err != nil {
+				return "", nil, err
+			}
+		}
+		if err := s.taskRepo.Reassign(ctx, task.ID, accountID, userID); err != nil {
+			return "", nil, err
+		}
+		return dto.BulkResultUpdated, []dto.BulkFieldChange{
+			{Field: "assignee_ids", From: current, To: []uint{accountID}},
+		}, nil
+
+	case dto.BulkOpSetDeadline:
+		if task.Deadline.Equal(*req.Deadline) {
+			return dto.BulkResultUnchanged, nil, nil
+		}
+		fields := map[string]interface{}{
+			"deadline":           *req.Deadline,
+			"overdue_at":         nil,
+			"update_accounts_id": userID,
+		}
+		if err := s.taskRepo.Update(ctx, task, fields); err != nil {
+			return "", nil, err
+		}
+		return dto.BulkResultUpdated, []dto.BulkFieldChange{
+			{Field: "deadline", From: task.Deadline, To: *req.Deadline},
+		}, nil
+
+	case dto.BulkOpAddLabel:
+		current := make([]uint, 0, len(task.Labels)+1)
+		for _, label := range task.Labels {
+			if label.ID == *req.LabelID {
+				return dto.BulkResultUnchanged, nil, nil
+			}
+			current = append(current, label.ID)
+		}
+		if err := s.taskRepo.AttachLabels(ctx, task.ID, []uint{*req.LabelID}); err != nil {
+			return "", nil, err
+		}
+		return dto.BulkResultUpdated, []dto.BulkFieldChange{
+			{Field: "label_ids", From: current, To: append(append([]uint{}, current...), *req.LabelID)},
+		}, nil
+
+	case dto.BulkOpDelete:
+		if err := s.taskRepo.Delete(ctx, task.ID); err != nil {
+			return "", nil, err
+		}
+		return dto.BulkResultDeleted, nil, nil
+	}
+
+	return "", nil, fmt.Errorf("unknown bulk operation %q", req.Operation)
+}
+
+// bulkOrder mengembalikan urutan index ids untuk diproses: subtask lebih
+// dulu dari parent-nya, supaya parent dan subtask bisa di-set done dalam
+// satu operasi bulk
+func bulkOrder(ids []uint, tasks map[uint]*models.Task) []int {
+	depth := func(id uint) int {
+		d := 0
+		for task, ok := tasks[id]; ok && task.ParentID != nil && d < len(tasks); task, ok = tasks[*task.ParentID] {
+			d++
+		}
+		return d
+	}
+
+	order := make([]int, len(ids))
+	depths := make([]int, len(ids))
+	for i, id := range ids {
+		order[i] = i
+		depths[i] = depth(id)
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return depths[order[a]] > depths[order[b]]
+	})
+	return order
+}
+
+func containsStatus(statuses []string, status string) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// ensureCanTransition menjaga aturan workflow saat status task berubah:
+// task yang masih diblokir tidak boleh dimulai, dan parent tidak boleh
+// done selama masih ada subtask atau checklist item yang belum selesai
+func (s *taskService) ensureCanTransition(ctx context.Context, task *models.Task, status string) error {
+	if status == models.TaskStatusInProgress || status == models.TaskStatusDone {
+		openBlockers, err := s.dependencyRepo.CountOpenBlockers(ctx, task.ID)
+		if err != nil {
+			return err
+		}
+		if openBlockers > 0 {
+			return ErrTaskBlocked
+		}
+	}
+
+	if status == models.TaskStatusDone {
+		progress, err := s.taskRepo.GetProgress(ctx, []uint{task.ID})
+		if err != nil {
+			return err
+		}
+		if progress[task.ID].HasOpenChildren() {
+			return ErrTaskHasOpenChildren
+		}
+	}
+
+	return nil
+}
+
+func (s *taskService) ensureValidParent(ctx context.Context, id, parentID uint) error {
+	if _, err := s.taskRepo.GetByID(ctx, parentID); err != nil {
+		return errors.New("parent task not found")
+	}
+
+	cyclic, err := s.taskRepo.IsAncestor(ctx, id, parentID)
+	if err != nil {
+		return err
+	}
+	if cyclic {
+		return ErrInvalidParent
+	}
+	return nil
+}
+
+// ensureWorkspaceMembers memastikan semua akun adalah member workspace aktif
+func ensureWorkspaceMembers(ctx context.Context, workspaceRepo repository.WorkspaceRepository, accountIDs ...uint) error {
+	workspaceID, ok := utils.WorkspaceIDFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	for _, accountID := range accountIDs {
+		member, err := workspaceRepo.GetMember(ctx, workspaceID, accountID)
+		if err != nil {
+			return err
+		}
+		if member == nil {
+			return ErrNotWorkspaceMember
+		}
+	}
+
+	return nil
+}
+
+// ensureProjectMembers memastikan semua akun adalah member project
+func ensureProjectMembers(ctx context.Context, projectRepo repository.ProjectRepository, projectID uint, accountIDs ...uint) error {
+	for _, accountID := range accountIDs {
+		member, err := projectRepo.GetMember(ctx, projectID, accountID)
+		if err != nil {
+			return err
+		}
+		if member == nil {
+			return ErrNotProjectMember
+		}
+	}
+	return nil
+}
+
+// ensureProjectAccess memastikan project aktif, user adalah member-nya,
+// dan semua assignee juga member project tersebut
+func (s *taskService) ensureProjectAccess(ctx context.Context, projectID *uint, userID uint, assigneeIDs ...uint) error {
+	if projectID == nil {
+		return nil
+	}
+
+	project, err := s.projectRepo.GetByID(ctx, *projectID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrProjectNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	member, err := s.projectRepo.GetMember(ctx, project.ID, userID)
+	if err != nil {
+		return err
+	}
+	if member == nil {
+		return ErrProjectNotFound
+	}
+	if project.IsArchived {
+		return ErrProjectArchived
+	}
+
+	return ensureProjectMembers(ctx, s.projectRepo, project.ID, assigneeIDs...)
+}
+
+// taskChanges mengembalikan kolom task yang nilainya berbeda antara before
+// dan after, supaya update hanya menyentuh kolom yang benar-benar berubah
+func taskChanges(before, after *models.Task) map[string]interface{} {
+	changes := make(map[string]interface{})
+	set := func(column string, changed bool, value interface{}) {
+		if changed {
+			changes[column] = value
+		}
+	}
+
+	set("title", before.Title != after.Title, after.Title)
+	set("description", before.Description != after.Description, after.Description)
+	set("status", before.Status != after.Status, after.Status)
+	set("rank", before.Rank != after.Rank, after.Rank)
+	set("priority", before.Priority != after.Priority, after.Priority)
+	set("deadline", !before.Deadline.Equal(after.Deadline), after.Deadline)
+	set("estimate_minutes", !equalInt(before.EstimateMinutes, after.EstimateMinutes), after.EstimateMinutes)
+	set("overdue_at", !equalTime(before.OverdueAt, after.OverdueAt), after.OverdueAt)
+	set("occurrence_at", !equalTime(before.OccurrenceAt, after.OccurrenceAt), after.OccurrenceAt)
+	set("project_id", !equalID(before.ProjectID, after.ProjectID), after.ProjectID)
+	set("parent_id", !equalID(before.ParentID, after.ParentID), after.ParentID)
+	set("update_accounts_id", !equalID(before.UpdateAccountID, after.UpdateAccountID), after.UpdateAccountID)
+
+	return changes
+}
+
+func equalTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func equalID(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func equalInt(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// positiveOrNil mengubah nilai 0 menjadi nil (tidak diisi)
+func positiveOrNil(value *int) *int {
+	if value == nil || *value <= 0 {
+		return nil
+	}
+	return value
+}
+
+func containsVersion(versions []uint, version uint) bool {
+	for _, v := range versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// participantIDs mengembalikan id semua assignee dan watcher task
+func participantIDs(task *models.Task) []uint {
+	ids := []uint{task.AccountID}
+	for _, account := range task.Assignees {
+		ids = append(ids, account.ID)
+	}
+	for _, account := range task.Watchers {
+		ids = append(ids, account.ID)
+	}
+	return uniqueIDs(ids)
+}
+
+func toAccounts(ids []uint) []models.Account {
+	accounts := make([]models.Account, len(ids))
+	for i, id := range ids {
+		accounts[i] = models.Account{ID: id}
+	}
+	return accounts
+}
+
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if id != 0 && !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// findLabels memastikan semua label yang diminta ada
+func (s *taskService) findLabels(ctx context.Context, ids []uint) ([]models.Label, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	labels, err := s.labelRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[uint]bool, len(labels))
+	for _, label := range labels {
+		found[label.ID] = true
+	}
+	for _, id := range ids {
+		if !found[id] {
+			return nil, ErrLabelNotFound
+		}
+	}
+
+	return labels, nil
+}
+
+// toTaskResponses memetakan task ke response dan melengkapi data turunan
+// (progress, dll) secara batch supaya tidak terjadi N+1 query
+func (s *taskService) toTaskResponses(ctx context.Context, tasks []models.Task) ([]dto.TaskResponse, error) {
+	ids := make([]uint, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+
+	progress, err := s.taskRepo.GetProgress(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	dependencies, err := s.dependencyRepo.GetByTaskIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	timeSpent, err := s.taskRepo.GetTimeSpent(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	blockedBy := make(map[uint][]dto.TaskLink)
+	blocking := make(map[uint][]dto.TaskLink)
+	for _, dependency := range dependencies {
+		if dependency.BlockedBy != nil {
+			blockedBy[dependency.TaskID] = append(blockedBy[dependency.TaskID], toTaskLink(dependency.BlockedBy))
+		}
+		if dependency.Task != nil {
+			blocking[dependency.BlockedByID] = append(blocking[dependency.BlockedByID], toTaskLink(dependency.Task))
+		}
+	}
+
 	responses := make([]dto.TaskResponse, len(tasks))
 	for i, task := range tasks {
 		responses[i] = *s.toTaskResponse(&task)
+		responses[i].Progress = progress[task.ID]
+		responses[i].BlockedBy = blockedBy[task.ID]
+		responses[i].Blocking = blocking[task.ID]
+		responses[i].TimeSpentMinutes = secondsToMinutes(timeSpent[task.ID])
+		for _, blocker := range responses[i].BlockedBy {
+			if blocker.Status != models.TaskStatusDone {
+				responses[i].IsBlocked = true
+				break
+			}
+		}
 	}
 
 	return responses, nil
 }
 
+func toTaskLink(task *models.Task) dto.TaskLink {
+	return dto.TaskLink{
+		ID:     task.ID,
+		Title:  task.Title,
+		Status: task.Status,
+	}
+}
+
 func (s *taskService) toTaskResponse(task *models.Task) *dto.TaskResponse {
 	return &dto.TaskResponse{
 		ID:              task.ID,
@@ -136,23 +1017,26 @@ func (s *taskService) toTaskResponse(task *models.Task) *dto.TaskResponse {
 		UpdateUser:      task.UpdateUser,
 		AccountID:       task.AccountID,
 		Account:         task.Account,
+		Assignees:       task.Assignees,
+		Watchers:        task.Watchers,
+		ProjectID:       task.ProjectID,
+		Project:         task.Project,
+		ParentID:        task.ParentID,
+		RecurrenceID:    task.RecurrenceID,
+		OccurrenceAt:    task.OccurrenceAt,
 		Title:           task.Title,
 		Description:     task.Description,
 		Status:          task.Status,
+		Rank:            task.Rank,
+		Priority:        task.Priority,
 		Deadline:        task.Deadline,
+		OverdueAt:       task.OverdueAt,
+		CompletedAt:     task.CompletedAt,
+		EstimateMinutes: task.EstimateMinutes,
+		Version:         task.Version,
+		CreatedAt:       task.CreatedAt,
+		UpdatedAt:       task.UpdatedAt,
+		ChecklistItems:  task.ChecklistItems,
+		Labels:          task.Labels,
 	}
 }
-
-func (s *taskService) GetTasksByFilter(ctx context.Context, req dto.TaskFilterRequest) ([]dto.TaskResponse, error) {
-	tasks, err := s.taskRepo.GetByFilter(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-
-	responses := make([]dto.TaskResponse, len(tasks))
-	for i, task := range tasks {
-		responses[i] = *s.toTaskResponse(&task)
-	}
-
-	return responses, nil
-}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/utils"
+	"context"
+	"fmt"
+	"time"
+)
+
+// maxStatsBuckets membatasi jumlah titik time series dalam satu request
+const maxStatsBuckets = 366
+
+var ErrInvalidStatsRange = fmt.Errorf("stats range must have from before to and at most %d buckets", maxStatsBuckets)
+
+// GetStats merangkum task yang terlihat dan cocok dengan filter. Semua
+// hitungan dilakukan di SQL; service hanya menentukan batas waktu di
+// timezone request dan melengkapi status, priority dan bucket yang kosong.
+func (s *taskService) GetStats(ctx context.Context, req dto.TaskStatsRequest) (*dto.TaskStatsResponse, error) {
+	location, err := statsLocation(req.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	bucket := req.Bucket
+	if bucket == "" {
+		bucket = dto.StatsBucketDay
+	}
+	truncate := utils.BeginningOfDay
+	step := func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }
+	if bucket == dto.StatsBucketWeek {
+		truncate = beginningOfWeek
+		step = func(t time.Time) time.Time { return t.AddDate(0, 0, 7) }
+	}
+
+	now := time.Now().In(location)
+	today := utils.BeginningOfDay(now)
+	weekStart := beginningOfWeek(today)
+
+	last := today
+	if req.To != nil {
+		last = dateIn(*req.To, location)
+	}
+	last = truncate(last)
+	var first time.Time
+	if req.From != nil {
+		first = truncate(dateIn(*req.From, location))
+	} else if bucket == dto.StatsBucketWeek {
+		first = last.AddDate(0, 0, -7*11)
+	} else {
+		first = last.AddDate(0, 0, -29)
+	}
+	if first.After(last) {
+		return nil, ErrInvalidStatsRange
+	}
+
+	var buckets []time.Time
+	for t := first; !t.After(last); t = step(t) {
+		if len(buckets) == maxStatsBuckets {
+			return nil, ErrInvalidStatsRange
+		}
+		buckets = append(buckets, t)
+	}
+	end := step(last)
+
+	stats, err := s.taskRepo.GetStats(ctx, &req.TaskListRequest, now, weekStart, weekStart.AddDate(0, 0, 7))
+	if err != nil {
+		return nil, err
+	}
+	points, err := s.taskRepo.GetSeries(ctx, &req.TaskListRequest, bucket, location.String(), first, end)
+	if err != nil {
+		return nil, err
+	}
+
+	if stats.Total > 0 {
+		stats.CompletionRate = float64(stats.Done) / float64(stats.Total)
+	}
+
+	// seperti board, status di luar kolom bawaan ditaruh setelahnya
+	statuses := append([]string{}, boardStatuses...)
+	byStatus := make(map[string]int64, len(stats.ByStatus))
+	for _, row := range stats.ByStatus {
+		byStatus[row.Status] = row.Count
+		if !containsStatus(statuses, row.Status) {
+			statuses = append(statuses, row.Status)
+		}
+	}
+	stats.ByStatus = make([]dto.TaskStatusCount, len(statuses))
+	for i, status := range statuses {
+		stats.ByStatus[i] = dto.TaskStatusCount{Status: status, Count: byStatus[status]}
+	}
+
+	byPriority := make(map[models.Priority]int64, len(stats.ByPriority))
+	for _, row := range stats.ByPriority {
+		byPriority[row.Priority] = row.Count
+	}
+	stats.ByPriority = stats.ByPriority[:0]
+	for priority := models.PriorityP0; priority <= models.PriorityP4; priority++ {
+		stats.ByPriority = append(stats.ByPriority, dto.TaskPriorityCount{Priority: priority, Count: byPriority[priority]})
+	}
+
+	if stats.ByAssignee == nil {
+		stats.ByAssignee = []dto.TaskAssigneeCount{}
+	}
+
+	stats.Series = dto.TaskSeries{
+		Bucket:   bucket,
+		From:     first.Format(dateLayout),
+		To:       end.AddDate(0, 0, -1).Format(dateLayout),
+		Timezone: location.String(),
+		Points:   make([]dto.TaskSeriesPoint, len(buckets)),
+	}
+	for i, t := range buckets {
+		date := t.Format(dateLayout)
+		point := points[date]
+		point.Date = date
+		stats.Series.Points[i] = point
+	}
+
+	return stats, nil
+}
+
+// statsLocation memuat timezone request (default UTC) yang juga dikirim ke
+// postgres untuk AT TIME ZONE
+func statsLocation(timezone string) (*time.Location, error) {
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	location, err := time.LoadLocation(timezone)
+	// "Local" hanya dikenal Go, tidak oleh postgres
+	if err != nil || location.String() == "Local" {
+		return nil, ErrInvalidTimezone
+	}
+	return location, nil
+}
+
+// beginningOfWeek mengembalikan awal hari Senin di minggu t, sama seperti
+// date_trunc('week') di postgres
+func beginningOfWeek(t time.Time) time.Time {
+	day := utils.BeginningOfDay(t)
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+// dateIn mengambil tanggal kalender t (hasil binding tanpa jam) sebagai awal
+// hari di location
+func dateIn(t time.Time, location *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, location)
+}
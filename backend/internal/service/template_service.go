@@ -0,0 +1,311 @@
+package service
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"backend/internal/utils"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrTemplateExists          = errors.New("template name already exists")
+	ErrTemplateVariableMissing = errors.New("missing template variables")
+)
+
+type TemplateService interface {
+	CreateTemplate(ctx context.Context, req dto.CreateTemplateRequest, userID uint) (*dto.TemplateResponse, error)
+	GetAllTemplates(ctx context.Context, search *string) ([]dto.TemplateResponse, error)
+	GetTemplateByID(ctx context.Context, id uint) (*dto.TemplateResponse, error)
+	UpdateTemplate(ctx context.Context, id uint, req dto.UpdateTemplateRequest) (*dto.TemplateResponse, error)
+	DeleteTemplate(ctx context.Context, id uint) error
+	CreateTaskFromTemplate(ctx context.Context, id uint, req dto.CreateFromTemplateRequest, userID uint) (*dto.TaskResponse, error)
+}
+
+type templateService struct {
+	templateRepo repository.TemplateRepository
+	labelRepo    repository.LabelRepository
+	taskRepo     repository.TaskRepository
+	taskService  TaskService
+}
+
+func NewTemplateService(
+	templateRepo repository.TemplateRepository,
+	labelRepo repository.LabelRepository,
+	taskRepo repository.TaskRepository,
+	taskService TaskService,
+) TemplateService {
+	return &templateService{
+		templateRepo: templateRepo,
+		labelRepo:    labelRepo,
+		taskRepo:     taskRepo,
+		taskService:  taskService,
+	}
+}
+
+func (s *templateService) CreateTemplate(ctx context.Context, req dto.CreateTemplateRequest, userID uint) (*dto.TemplateResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+	if err := s.ensureUniqueName(ctx, name, 0); err != nil {
+		return nil, err
+	}
+
+	labels, err := s.findLabels(ctx, req.LabelIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	template := &models.TaskTemplate{
+		Name:                  name,
+		CreateAccountID:       userID,
+		Title:                 req.Title,
+		Description:           req.Description,
+		Status:                req.Status,
+		Priority:              models.DefaultPriority,
+		DeadlineOffsetMinutes: req.DeadlineOffsetMinutes,
+		Labels:                labels,
+	}
+	if template.Status == "" {
+		template.Status = models.TaskStatusTodo
+	}
+	if req.Priority != nil {
+		template.Priority = *req.Priority
+	}
+	template.Subtasks = toTemplateSubtasks(req.Subtasks, template.Priority)
+
+	if err := s.templateRepo.Create(ctx, template); err != nil {
+		return nil, err
+	}
+
+	return s.GetTemplateByID(ctx, template.ID)
+}
+
+func (s *templateService) GetAllTemplates(ctx context.Context, search *string) ([]dto.TemplateResponse, error) {
+	templates, err := s.templateRepo.GetAll(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.TemplateResponse, len(templates))
+	for i := range templates {
+		responses[i] = *toTemplateResponse(&templates[i])
+	}
+	return responses, nil
+}
+
+func (s *templateService) GetTemplateByID(ctx context.Context, id uint) (*dto.TemplateResponse, error) {
+	template, err := s.templateRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toTemplateResponse(template), nil
+}
+
+func (s *templateService) UpdateTemplate(ctx context.Context, id uint, req dto.UpdateTemplateRequest) (*dto.TemplateResponse, error) {
+	template, err := s.templateRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, errors.New("name is required")
+		}
+		if err := s.ensureUniqueName(ctx, name, template.ID); err != nil {
+			return nil, err
+		}
+		template.Name = name
+	}
+	if req.Title != nil {
+		if *req.Title == "" {
+			return nil, errors.New("title is required")
+		}
+		template.Title = *req.Title
+	}
+	if req.Description != nil {
+		template.Description = *req.Description
+	}
+	if req.Status != nil {
+		template.Status = *req.Status
+	}
+	if req.Priority != nil {
+		template.Priority = *req.Priority
+	}
+	if req.DeadlineOffsetMinutes != nil {
+		template.DeadlineOffsetMinutes = req.DeadlineOffsetMinutes
+	}
+	if req.ClearDeadline {
+		template.DeadlineOffsetMinutes = nil
+	}
+	if req.LabelIDs != nil {
+		if template.Labels, err = s.findLabels(ctx, *req.LabelIDs); err != nil {
+			return nil, err
+		}
+	}
+	if req.Subtasks != nil {
+		template.Subtasks = toTemplateSubtasks(*req.Subtasks, template.Priority)
+	}
+
+	if err := s.templateRepo.Update(ctx, template, req.LabelIDs != nil, req.Subtasks != nil); err != nil {
+		return nil, err
+	}
+
+	return s.GetTemplateByID(ctx, id)
+}
+
+func (s *templateService) DeleteTemplate(ctx context.Context, id uint) error {
+	if _, err := s.templateRepo.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return s.templateRepo.Delete(ctx, id)
+}
+
+// CreateTaskFromTemplate mengisi placeholder lalu membuat task beserta
+// subtask-nya dalam satu transaksi lewat TaskService, jadi validasi project,
+// assignee dan label sama dengan membuat task biasa
+func (s *templateService) CreateTaskFromTemplate(ctx context.Context, id uint, req dto.CreateFromTemplateRequest, userID uint) (*dto.TaskResponse, error) {
+	template, err := s.templateRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, name := range templateVariables(template) {
+		if _, ok := req.Variables[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrTemplateVariableMissing, strings.Join(missing, ", "))
+	}
+
+	start := time.Now()
+	if req.StartAt != nil {
+		start = *req.StartAt
+	}
+	accountID := req.AccountID
+	if accountID == 0 {
+		accountID = userID
+	}
+	labelIDs := make([]uint, len(template.Labels))
+	for i, label := range template.Labels {
+		labelIDs[i] = label.ID
+	}
+
+	var taskID uint
+	err = s.taskRepo.Transaction(ctx, func(ctx context.Context) error {
+		task, err := s.taskService.CreateTask(ctx, dto.CreateTaskRequest{
+			Title:       utils.FillPlaceholders(template.Title, req.Variables),
+			Description: utils.FillPlaceholders(template.Description, req.Variables),
+			Status:      template.Status,
+			Priority:    &template.Priority,
+			Deadline:    offsetDeadline(start, template.DeadlineOffsetMinutes),
+			AccountID:   accountID,
+			ProjectID:   req.ProjectID,
+			ParentID:    req.ParentID,
+			LabelIDs:    labelIDs,
+		}, userID)
+		if err != nil {
+			return err
+		}
+
+		for _, subtask := range template.Subtasks {
+			priority := subtask.Priority
+			_, err := s.taskService.CreateTask(ctx, dto.CreateTaskRequest{
+				Title:       utils.FillPlaceholders(subtask.Title, req.Variables),
+				Description: utils.FillPlaceholders(subtask.Description, req.Variables),
+				Status:      models.TaskStatusTodo,
+				Priority:    &priority,
+				Deadline:    offsetDeadline(start, subtask.DeadlineOffsetMinutes),
+				AccountID:   accountID,
+				ParentID:    &task.ID,
+			}, userID)
+			if err != nil {
+				return err
+			}
+		}
+
+		taskID = task.ID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.taskService.GetTaskByID(ctx, taskID)
+}
+
+func (s *templateService) ensureUniqueName(ctx context.Context, name string, exceptID uint) error {
+	existing, err := s.templateRepo.GetByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != exceptID {
+		return ErrTemplateExists
+	}
+	return nil
+}
+
+// findLabels memastikan semua label yang diminta ada
+func (s *templateService) findLabels(ctx context.Context, ids []uint) ([]models.Label, error) {
+	ids = uniqueIDs(ids)
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	labels, err := s.labelRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(labels) != len(ids) {
+		return nil, ErrLabelNotFound
+	}
+	return labels, nil
+}
+
+func toTemplateSubtasks(requests []dto.TemplateSubtaskRequest, defaultPriority models.Priority) []models.TaskTemplateSubtask {
+	subtasks := make([]models.TaskTemplateSubtask, len(requests))
+	for i, req := range requests {
+		subtasks[i] = models.TaskTemplateSubtask{
+			Title:                 req.Title,
+			Description:           req.Description,
+			Priority:              defaultPriority,
+			DeadlineOffsetMinutes: req.DeadlineOffsetMinutes,
+		}
+		if req.Priority != nil {
+			subtasks[i].Priority = *req.Priority
+		}
+	}
+	return subtasks
+}
+
+// templateVariables mengembalikan semua placeholder di template dan subtask-nya
+func templateVariables(template *models.TaskTemplate) []string {
+	texts := []string{template.Title, template.Description}
+	for _, subtask := range template.Subtasks {
+		texts = append(texts, subtask.Title, subtask.Description)
+	}
+	return utils.Placeholders(texts...)
+}
+
+// offsetDeadline menghitung deadline relatif; tanpa offset task tidak punya deadline
+func offsetDeadline(start time.Time, offsetMinutes *int) time.Time {
+	if offsetMinutes == nil {
+		return time.Time{}
+	}
+	return start.Add(time.Duration(*offsetMinutes) * time.Minute)
+}
+
+func toTemplateResponse(template *models.TaskTemplate) *dto.TemplateResponse {
+	return &dto.TemplateResponse{
+		TaskTemplate: *template,
+		Variables:    templateVariables(template),
+	}
+}
@@ -0,0 +1,280 @@
+package service
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrViewNotOwner      = errors.New("only the owner can change this view")
+	ErrViewNeedsProject  = errors.New("project visibility requires project_id")
+	ErrInvalidViewFilter = errors.New("invalid view filter")
+)
+
+type ViewService interface {
+	CreateView(ctx context.Context, req dto.CreateViewRequest, userID uint) (*dto.ViewResponse, error)
+	GetViews(ctx context.Context, userID uint, projectID *uint) ([]dto.ViewResponse, error)
+	GetViewByID(ctx context.Context, id, userID uint) (*dto.ViewResponse, error)
+	UpdateView(ctx context.Context, id uint, req dto.UpdateViewRequest, userID uint) (*dto.ViewResponse, error)
+	DeleteView(ctx context.Context, id, userID uint) error
+	// GetViewTasks menjalankan filter dan urutan view lewat list task biasa,
+	// jadi hasilnya tetap dibatasi task yang boleh dilihat akun
+	GetViewTasks(ctx context.Context, id, userID uint, page, limit string) ([]dto.TaskResponse, int64, error)
+}
+
+type viewService struct {
+	viewRepo    repository.ViewRepository
+	projectRepo repository.ProjectRepository
+	taskService TaskService
+}
+
+func NewViewService(
+	viewRepo repository.ViewRepository,
+	projectRepo repository.ProjectRepository,
+	taskService TaskService,
+) ViewService {
+	return &viewService{
+		viewRepo:    viewRepo,
+		projectRepo: projectRepo,
+		taskService: taskService,
+	}
+}
+
+func (s *viewService) CreateView(ctx context.Context, req dto.CreateViewRequest, userID uint) (*dto.ViewResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+	if err := validateViewFilter(ctx, req.Filters); err != nil {
+		return nil, err
+	}
+	if err := repository.ValidateTaskOrders(req.Order); err != nil {
+		return nil, err
+	}
+
+	view := &models.SavedView{
+		OwnerID:    userID,
+		Name:       name,
+		Visibility: req.Visibility,
+		ProjectID:  req.ProjectID,
+		Filters:    req.Filters,
+		Sort:       req.Sort,
+		Order:      toSavedViewOrders(req.Order),
+		Columns:    req.Columns,
+		GroupBy:    req.GroupBy,
+	}
+	if view.Visibility == "" {
+		view.Visibility = models.ViewVisibilityPrivate
+	}
+	if err := s.ensureViewProject(ctx, view, userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.viewRepo.Create(ctx, view); err != nil {
+		return nil, err
+	}
+
+	return s.GetViewByID(ctx, view.ID, userID)
+}
+
+func (s *viewService) GetViews(ctx context.Context, userID uint, projectID *uint) ([]dto.ViewResponse, error) {
+	views, err := s.viewRepo.GetAll(ctx, userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.ViewResponse, len(views))
+	for i := range views {
+		responses[i] = *toViewResponse(&views[i], userID)
+	}
+	return responses, nil
+}
+
+func (s *viewService) GetViewByID(ctx context.Context, id, userID uint) (*dto.ViewResponse, error) {
+	view, err := s.viewRepo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return toViewResponse(view, userID), nil
+}
+
+func (s *viewService) UpdateView(ctx context.Context, id uint, req dto.UpdateViewRequest, userID uint) (*dto.ViewResponse, error) {
+	view, err := s.getOwnedView(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, errors.New("name is required")
+		}
+		view.Name = name
+	}
+	if req.Visibility != nil && *req.Visibility != "" {
+		view.Visibility = *req.Visibility
+	}
+	if req.ProjectID != nil {
+		view.ProjectID = req.ProjectID
+	}
+	if req.Filters != nil {
+		if err := validateViewFilter(ctx, *req.Filters); err != nil {
+			return nil, err
+		}
+		view.Filters = *req.Filters
+	}
+	if req.Sort != nil {
+		view.Sort = *req.Sort
+	}
+	if req.Order != nil {
+		if err := repository.ValidateTaskOrders(*req.Order); err != nil {
+			return nil, err
+		}
+		view.Order = toSavedViewOrders(*req.Order)
+	}
+	if req.Columns != nil {
+		view.Columns = *req.Columns
+	}
+	if req.GroupBy != nil {
+		view.GroupBy = *req.GroupBy
+	}
+	if err := s.ensureViewProject(ctx, view, userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.viewRepo.Update(ctx, view); err != nil {
+		return nil, err
+	}
+
+	return s.GetViewByID(ctx, id, userID)
+}
+
+func (s *viewService) DeleteView(ctx context.Context, id, userID uint) error {
+	if _, err := s.getOwnedView(ctx, id, userID); err != nil {
+		return err
+	}
+	return s.viewRepo.Delete(ctx, id)
+}
+
+func (s *viewService) GetViewTasks(ctx context.Context, id, userID uint, page, limit string) ([]dto.TaskResponse, int64, error) {
+	view, err := s.viewRepo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return s.taskService.GetAllTasks(ctx, viewListRequest(view, page, limit))
+}
+
+// getOwnedView mencari view yang terlihat oleh akun dan memastikan akun
+// tersebut pemiliknya
+func (s *viewService) getOwnedView(ctx context.Context, id, userID uint) (*models.SavedView, error) {
+	view, err := s.viewRepo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if view.OwnerID != userID {
+		return nil, ErrViewNotOwner
+	}
+	return view, nil
+}
+
+// ensureViewProject memastikan view project dibagikan ke project yang
+// pemiliknya adalah member; visibility lain tidak menyimpan project
+func (s *viewService) ensureViewProject(ctx context.Context, view *models.SavedView, userID uint) error {
+	if view.Visibility != models.ViewVisibilityProject {
+		view.ProjectID = nil
+		return nil
+	}
+	if view.ProjectID == nil {
+		return ErrViewNeedsProject
+	}
+
+	project, err := s.projectRepo.GetByID(ctx, *view.ProjectID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrProjectNotFound
+	}
+	if err != nil {
+		return err
+	}
+	member, err := s.projectRepo.GetMember(ctx, project.ID, userID)
+	if err != nil {
+		return err
+	}
+	if member == nil {
+		return ErrProjectNotFound
+	}
+	return nil
+}
+
+// validateViewFilter menolak filter yang pasti gagal saat view dibuka,
+// termasuk query task yang tidak valid (dikembalikan sebagai *query.Error)
+func validateViewFilter(ctx context.Context, filter models.SavedViewFilter) error {
+	if filter.LabelMatch != "" && filter.LabelMatch != dto.LabelMatchAny && filter.LabelMatch != dto.LabelMatchAll {
+		return fmt.Errorf("%w: label_match must be any or all", ErrInvalidViewFilter)
+	}
+	for _, priority := range filter.Priorities {
+		if !priority.IsValid() {
+			return fmt.Errorf("%w: invalid priority %d", ErrInvalidViewFilter, priority)
+		}
+	}
+	return repository.ValidateTaskQuery(ctx, filter.Query)
+}
+
+// viewListRequest menerjemahkan view menjadi request list task. Tanpa sort
+// dan order, urutannya sama dengan default /task/list.
+func viewListRequest(view *models.SavedView, page, limit string) dto.TaskListRequest {
+	filter := view.Filters
+	req := dto.TaskListRequest{
+		Query:      filter.Query,
+		Search:     filter.Search,
+		Status:     filter.Status,
+		Priorities: filter.Priorities,
+		ProjectID:  filter.ProjectID,
+		ParentID:   filter.ParentID,
+		AssigneeID: filter.AssigneeID,
+		WatcherID:  filter.WatcherID,
+		RootOnly:   filter.RootOnly,
+		Ready:      filter.Ready,
+		Overdue:    filter.Overdue,
+		LabelIDs:   filter.LabelIDs,
+		LabelMatch: filter.LabelMatch,
+		StartDate:  filter.StartDate,
+		EndDate:    filter.EndDate,
+		Limit:      limit,
+		Page:       page,
+		Sort:       view.Sort,
+	}
+	for _, order := range view.Order {
+		req.Order = append(req.Order, dto.TaskOrder{Field: order.Field, Direction: order.Direction, Nulls: order.Nulls})
+	}
+	if req.Sort == "" && len(req.Order) == 0 {
+		if req.Search != nil && strings.TrimSpace(*req.Search) != "" {
+			req.Sort = dto.TaskSortRelevance
+		} else {
+			req.Sort = dto.TaskSortNewest
+		}
+	}
+	return req
+}
+
+func toSavedViewOrders(orders dto.TaskOrders) []models.SavedViewOrder {
+	saved := make([]models.SavedViewOrder, len(orders))
+	for i, order := range orders {
+		saved[i] = models.SavedViewOrder{Field: order.Field, Direction: order.Direction, Nulls: order.Nulls}
+	}
+	return saved
+}
+
+func toViewResponse(view *models.SavedView, userID uint) *dto.ViewResponse {
+	return &dto.ViewResponse{
+		SavedView: *view,
+		Editable:  view.OwnerID == userID,
+	}
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"backend/internal/utils"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// timesheetMaxDays membatasi rentang timesheet dalam satu request
+const timesheetMaxDays = 366
+
+var (
+	ErrTimerRunning      = repository.ErrTimerRunning
+	ErrTimerNotRunning   = repository.ErrTimerNotRunning
+	ErrWorkLogForbidden  = errors.New("only the author can delete a work log")
+	ErrTimesheetTooLarge = errors.New("timesheet range is limited to one year")
+)
+
+type WorkLogService interface {
+	StartTimer(ctx context.Context, taskID, userID uint) (*models.WorkLog, error)
+	StopTimer(ctx context.Context, taskID uint, req dto.StopTimerRequest, userID uint) (*models.WorkLog, error)
+	GetRunningTimer(ctx context.Context, userID uint) (*models.WorkLog, error)
+	AddWorkLog(ctx context.Context, taskID uint, req dto.CreateWorkLogRequest, userID uint) (*models.WorkLog, error)
+	GetWorkLogs(ctx context.Context, taskID uint) ([]models.WorkLog, error)
+	DeleteWorkLog(ctx context.Context, taskID, id, userID uint) error
+	GetTimesheet(ctx context.Context, req dto.TimesheetRequest, userID uint) (*dto.TimesheetResponse, error)
+}
+
+type workLogService struct {
+	workLogRepo   repository.WorkLogRepository
+	taskRepo      repository.TaskRepository
+	workspaceRepo repository.WorkspaceRepository
+}
+
+func NewWorkLogService(
+	workLogRepo repository.WorkLogRepository,
+	taskRepo repository.TaskRepository,
+	workspaceRepo repository.WorkspaceRepository,
+) WorkLogService {
+	return &workLogService{
+		workLogRepo:   workLogRepo,
+		taskRepo:      taskRepo,
+		workspaceRepo: workspaceRepo,
+	}
+}
+
+// StartTimer memulai timer di task; gagal jika user masih punya timer lain
+func (s *workLogService) StartTimer(ctx context.Context, taskID, userID uint) (*models.WorkLog, error) {
+	if _, err := s.taskRepo.GetByID(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	log := &models.WorkLog{
+		TaskID:    taskID,
+		AccountID: userID,
+		StartedAt: time.Now(),
+	}
+	if err := s.workLogRepo.Start(ctx, log); err != nil {
+		return nil, err
+	}
+	return log, nil
+}
+
+// StopTimer menghentikan timer user yang berjalan di task ini
+func (s *workLogService) StopTimer(ctx context.Context, taskID uint, req dto.StopTimerRequest, userID uint) (*models.WorkLog, error) {
+	log, err := s.workLogRepo.GetRunning(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if log == nil || log.TaskID != taskID {
+		return nil, ErrTimerNotRunning
+	}
+
+	if req.Note != "" {
+		log.Note = req.Note
+	}
+	if err := s.workLogRepo.Stop(ctx, log, time.Now()); err != nil {
+		return nil, err
+	}
+	return log, nil
+}
+
+func (s *workLogService) GetRunningTimer(ctx context.Context, userID uint) (*models.WorkLog, error) {
+	return s.workLogRepo.GetRunning(ctx, userID)
+}
+
+func (s *workLogService) AddWorkLog(ctx context.Context, taskID uint, req dto.CreateWorkLogRequest, userID uint) (*models.WorkLog, error) {
+	if _, err := s.taskRepo.GetByID(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	duration := time.Duration(req.DurationMinutes) * time.Minute
+	startedAt := time.Now().Add(-duration)
+	if req.StartedAt != nil {
+		startedAt = *req.StartedAt
+	}
+	endedAt := startedAt.Add(duration)
+
+	log := &models.WorkLog{
+		TaskID:          taskID,
+		AccountID:       userID,
+		StartedAt:       startedAt,
+		EndedAt:         &endedAt,
+		DurationSeconds: int64(duration.Seconds()),
+		Note:            req.Note,
+	}
+	if err := s.workLogRepo.Create(ctx, log); err != nil {
+		return nil, err
+	}
+	return log, nil
+}
+
+func (s *workLogService) GetWorkLogs(ctx context.Context, taskID uint) ([]models.WorkLog, error) {
+	if _, err := s.taskRepo.GetByID(ctx, taskID); err != nil {
+		return nil, err
+	}
+	return s.workLogRepo.GetByTaskID(ctx, taskID)
+}
+
+func (s *workLogService) DeleteWorkLog(ctx context.Context, taskID, id, userID uint) error {
+	log, err := s.workLogRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if log.TaskID != taskID {
+		return gorm.ErrRecordNotFound
+	}
+	if log.AccountID != userID {
+		return ErrWorkLogForbidden
+	}
+	return s.workLogRepo.Delete(ctx, id)
+}
+
+// GetTimesheet merangkum waktu kerja satu akun per hari dan per task.
+// Melihat timesheet akun lain hanya boleh untuk owner/admin workspace.
+func (s *workLogService) GetTimesheet(ctx context.Context, req dto.TimesheetRequest, userID uint) (*dto.TimesheetResponse, error) {
+	accountID := userID
+	if req.AccountID != nil && *req.AccountID != userID {
+		if err := s.ensureCanManageWorkspace(ctx, userID); err != nil {
+			return nil, err
+		}
+		accountID = *req.AccountID
+	}
+
+	from := utils.BeginningOfDay(req.From.UTC())
+	to := utils.BeginningOfDay(req.To.UTC()).AddDate(0, 0, 1)
+	if to.Sub(from) > timesheetMaxDays*24*time.Hour {
+		return nil, ErrTimesheetTooLarge
+	}
+
+	rows, err := s.workLogRepo.GetTimesheet(ctx, accountID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.TimesheetResponse{
+		AccountID: accountID,
+		From:      from.Format(dateLayout),
+		To:        to.AddDate(0, 0, -1).Format(dateLayout),
+		Days:      []dto.TimesheetDay{},
+		Tasks:     []dto.TimesheetTask{},
+	}
+
+	var total int64
+	taskTotals := make(map[uint]int64)
+	taskIndex := make(map[uint]int)
+	for _, row := range rows {
+		date := row.Day.Format(dateLayout)
+		if len(response.Days) == 0 || response.Days[len(response.Days)-1].Date != date {
+			response.Days = append(response.Days, dto.TimesheetDay{Date: date})
+		}
+		day := &response.Days[len(response.Days)-1]
+		day.Tasks = append(day.Tasks, dto.TimesheetTask{
+			TaskID:    row.TaskID,
+			Title:     row.Title,
+			ProjectID: row.ProjectID,
+			Minutes:   secondsToMinutes(row.Seconds),
+		})
+		day.Minutes += secondsToMinutes(row.Seconds)
+
+		if _, ok := taskIndex[row.TaskID]; !ok {
+			taskIndex[row.TaskID] = len(response.Tasks)
+			response.Tasks = append(response.Tasks, dto.TimesheetTask{
+				TaskID:    row.TaskID,
+				Title:     row.Title,
+				ProjectID: row.ProjectID,
+			})
+		}
+		taskTotals[row.TaskID] += row.Seconds
+		total += row.Seconds
+	}
+
+	for taskID, i := range taskIndex {
+		response.Tasks[i].Minutes = secondsToMinutes(taskTotals[taskID])
+	}
+	response.Minutes = secondsToMinutes(total)
+
+	return response, nil
+}
+
+func (s *workLogService) ensureCanManageWorkspace(ctx context.Context, userID uint) error {
+	workspaceID, ok := utils.WorkspaceIDFromContext(ctx)
+	if !ok {
+		return ErrWorkspaceForbidden
+	}
+	member, err := s.workspaceRepo.GetMember(ctx, workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	if member == nil || !member.CanManage() {
+		return ErrWorkspaceForbidden
+	}
+	return nil
+}
+
+const dateLayout = "2006-01-02"
+
+// secondsToMinutes membulatkan detik ke menit terdekat
+func secondsToMinutes(seconds int64) int64 {
+	return (seconds + 30) / 60
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"backend/internal/utils"
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrWorkspaceNotFound    = errors.New("workspace not found")
+	ErrWorkspaceForbidden   = errors.New("only workspace owners and admins can do this")
+	ErrWorkspaceSlugExists  = errors.New("workspace slug already exists")
+	ErrWorkspaceOwnerMember = errors.New("the workspace owner cannot be removed or demoted")
+	ErrNotWorkspaceMember   = errors.New("account is not a member of the workspace")
+)
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+type WorkspaceService interface {
+	CreateWorkspace(ctx context.Context, req dto.CreateWorkspaceRequest, userID uint) (*models.Workspace, error)
+	GetWorkspaces(ctx context.Context, userID uint) ([]models.Workspace, error)
+	GetWorkspaceByID(ctx context.Context, id, userID uint) (*models.Workspace, error)
+	UpdateWorkspace(ctx context.Context, id uint, req dto.UpdateWorkspaceRequest, userID uint) (*models.Workspace, error)
+	GetMembers(ctx context.Context, id, userID uint) ([]models.WorkspaceMember, error)
+	AddMember(ctx context.Context, id uint, req dto.AddWorkspaceMemberRequest, userID uint) (*models.WorkspaceMember, error)
+	RemoveMember(ctx context.Context, id, accountID, userID uint) error
+	// IsMember dipakai middleware untuk memastikan kredensial (token, app
+	// password) hanya berlaku selama akun masih member workspace-nya
+	IsMember(ctx context.Context, workspaceID, accountID uint) (bool, error)
+}
+
+type workspaceService struct {
+	workspaceRepo repository.WorkspaceRepository
+	accountRepo   repository.AccountRepository
+}
+
+func NewWorkspaceService(workspaceRepo repository.WorkspaceRepository, accountRepo repository.AccountRepository) WorkspaceService {
+	return &workspaceService{
+		workspaceRepo: workspaceRepo,
+		accountRepo:   accountRepo,
+	}
+}
+
+func (s *workspaceService) CreateWorkspace(ctx context.Context, req dto.CreateWorkspaceRequest, userID uint) (*models.Workspace, error) {
+	slug := generateWorkspaceSlug(req.Name)
+	if req.Slug != "" {
+		slug = slugify(req.Slug)
+
+		existing, err := s.workspaceRepo.GetBySlug(ctx, slug)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return nil, ErrWorkspaceSlugExists
+		}
+	}
+
+	workspace := &models.Workspace{
+		Name:    strings.TrimSpace(req.Name),
+		Slug:    slug,
+		OwnerID: userID,
+	}
+
+	if err := s.workspaceRepo.Create(ctx, workspace); err != nil {
+		return nil, err
+	}
+
+	return s.workspaceRepo.GetByID(ctx, workspace.ID)
+}
+
+func (s *workspaceService) IsMember(ctx context.Context, workspaceID, accountID uint) (bool, error) {
+	member, err := s.workspaceRepo.GetMember(ctx, workspaceID, accountID)
+	if err != nil {
+		return false, err
+	}
+	return member != nil, nil
+}
+
+func (s *workspaceService) GetWorkspaces(ctx context.Context, userID uint) ([]models.Workspace, error) {
+	return s.workspaceRepo.GetAllForAccount(ctx, userID)
+}
+
+// GetWorkspaceByID hanya mengembalikan workspace jika user adalah member-nya
+func (s *workspaceService) GetWorkspaceByID(ctx context.Context, id, userID uint) (*models.Workspace, error) {
+	member, err := s.workspaceRepo.GetMember(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, ErrWorkspaceNotFound
+	}
+
+	return s.workspaceRepo.GetByID(ctx, id)
+}
+
+func (s *workspaceService) UpdateWorkspace(ctx context.Context, id uint, req dto.UpdateWorkspaceRequest, userID uint) (*models.Workspace, error) {
+	workspace, err := s.getManagedWorkspace(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, errors.New("name is required")
+		}
+		workspace.Name = name
+	}
+
+	if err := s.workspaceRepo.Update(ctx, workspace); err != nil {
+		return nil, err
+	}
+
+	return s.workspaceRepo.GetByID(ctx, id)
+}
+
+func (s *workspaceService) GetMembers(ctx context.Context, id, userID uint) ([]models.WorkspaceMember, error) {
+	if _, err := s.GetWorkspaceByID(ctx, id, userID); err != nil {
+		return nil, err
+	}
+	return s.workspaceRepo.GetMembers(ctx, id)
+}
+
+func (s *workspaceService) AddMember(ctx context.Context, id uint, req dto.AddWorkspaceMemberRequest, userID uint) (*models.WorkspaceMember, error) {
+	workspace, err := s.getManagedWorkspace(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.accountRepo.GetByEmail(ctx, req.Email)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, errors.New("account not found")
+	}
+	if account.ID == workspace.OwnerID {
+		return nil, ErrWorkspaceOwnerMember
+	}
+
+	role := req.Role
+	if role == "" {
+		role = models.WorkspaceRoleMember
+	}
+
+	member := &models.WorkspaceMember{
+		WorkspaceID: id,
+		AccountID:   account.ID,
+		Role:        role,
+	}
+
+	if err := s.workspaceRepo.SaveMember(ctx, member); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (s *workspaceService) RemoveMember(ctx context.Context, id, accountID, userID uint) error {
+	workspace, err := s.getManagedWorkspace(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if accountID == workspace.OwnerID {
+		return ErrWorkspaceOwnerMember
+	}
+
+	member, err := s.workspaceRepo.GetMember(ctx, id, accountID)
+	if err != nil {
+		return err
+	}
+	if member == nil {
+		return ErrNotWorkspaceMember
+	}
+
+	return s.workspaceRepo.RemoveMember(ctx, id, accountID)
+}
+
+// getManagedWorkspace memastikan user adalah owner atau admin workspace
+func (s *workspaceService) getManagedWorkspace(ctx context.Context, id, userID uint) (*models.Workspace, error) {
+	member, err := s.workspaceRepo.GetMember(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, ErrWorkspaceNotFound
+	}
+	if !member.CanManage() {
+		return nil, ErrWorkspaceForbidden
+	}
+
+	return s.workspaceRepo.GetByID(ctx, id)
+}
+
+func slugify(value string) string {
+	slug := nonSlugChars.ReplaceAllString(strings.ToLower(value), "-")
+	return strings.Trim(slug, "-")
+}
+
+// generateWorkspaceSlug membuat slug unik dari nama dengan akhiran acak
+func generateWorkspaceSlug(name string) string {
+	slug := slugify(name)
+	if slug == "" {
+		slug = "workspace"
+	}
+	if len(slug) > 40 {
+		slug = strings.Trim(slug[:40], "-")
+	}
+	return slug + "-" + utils.GenerateUUID()[:6]
+}
@@ -0,0 +1,34 @@
+package utils
+
+import "context"
+
+type contextKey string
+
+const accountIDKey contextKey = "account_id"
+
+// WithAccountID menyimpan id akun yang sedang login ke context request,
+// supaya layer repository bisa membatasi data sesuai hak akses akun tersebut
+func WithAccountID(ctx context.Context, accountID uint) context.Context {
+	return context.WithValue(ctx, accountIDKey, accountID)
+}
+
+// AccountIDFromContext mengembalikan id akun dari context, false jika tidak ada
+// (misalnya proses internal yang tidak berjalan atas nama user)
+func AccountIDFromContext(ctx context.Context) (uint, bool) {
+	accountID, ok := ctx.Value(accountIDKey).(uint)
+	return accountID, ok
+}
+
+const workspaceIDKey contextKey = "workspace_id"
+
+// WithWorkspaceID menyimpan workspace aktif ke context request. Repository
+// memakai nilai ini untuk membatasi semua query ke workspace tersebut.
+func WithWorkspaceID(ctx context.Context, workspaceID uint) context.Context {
+	return context.WithValue(ctx, workspaceIDKey, workspaceID)
+}
+
+// WorkspaceIDFromContext mengembalikan workspace aktif, false jika tidak ada
+func WorkspaceIDFromContext(ctx context.Context) (uint, bool) {
+	workspaceID, ok := ctx.Value(workspaceIDKey).(uint)
+	return workspaceID, ok
+}
@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"regexp"
+	"sort"
+)
+
+// placeholderPattern mencocokkan placeholder {{nama}}, spasi di dalam kurung diabaikan
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// Placeholders mengembalikan nama placeholder unik di texts, terurut
+func Placeholders(texts ...string) []string {
+	seen := make(map[string]bool)
+	names := []string{}
+	for _, text := range texts {
+		for _, match := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+			if !seen[match[1]] {
+				seen[match[1]] = true
+				names = append(names, match[1])
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FillPlaceholders mengganti placeholder di text dengan nilai dari vars;
+// placeholder tanpa nilai dibiarkan apa adanya
+func FillPlaceholders(text string, vars map[string]string) string {
+	return placeholderPattern.ReplaceAllStringFunc(text, func(match string) string {
+		if value, ok := vars[placeholderPattern.FindStringSubmatch(match)[1]]; ok {
+			return value
+		}
+		return match
+	})
+}
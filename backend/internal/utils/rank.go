@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"strings"
+)
+
+// rankDigits adalah digit base-62 yang urutannya sama dengan urutan byte
+// (collation "C"), jadi rank bisa dibandingkan sebagai string biasa
+const rankDigits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+const rankBase = len(rankDigits)
+
+// RankBetween mengembalikan rank (fractional index) di antara a dan b.
+// a kosong berarti awal kolom dan b kosong berarti akhir kolom. Rank yang
+// dihasilkan tidak pernah diakhiri digit "0", sehingga selalu ada ruang di
+// antara dua rank; rank hanya bertambah panjang jika ruangnya habis.
+// Pemanggil harus memastikan a < b jika keduanya diisi.
+func RankBetween(a, b string) string {
+	if b != "" {
+		// salin prefix yang sama, a dianggap diisi "0" di belakang
+		n := 0
+		for n < len(b) && rankDigitAt(a, n) == rankDigit(b[n]) {
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(a) {
+				rest = a[n:]
+			}
+			return b[:n] + RankBetween(rest, b[n:])
+		}
+	}
+
+	digitA := rankDigitAt(a, 0)
+	digitB := rankBase
+	if b != "" {
+		digitB = rankDigit(b[0])
+	}
+
+	// menambah di awal/akhir kolom (kasus paling umum) cukup geser satu
+	// digit supaya rank tidak cepat memanjang
+	if b == "" && a != "" && digitA < rankBase-1 {
+		return string(rankDigits[digitA+1])
+	}
+	if a == "" && b != "" && digitB > 1 {
+		return string(rankDigits[digitB-1])
+	}
+	if digitB-digitA > 1 {
+		return string(rankDigits[(digitA+digitB+1)/2])
+	}
+
+	// digit berurutan: pakai digit pertama b jika b masih punya ekor,
+	// selain itu tambah satu digit di belakang digit a
+	if b != "" && len(b) > 1 {
+		return b[:1]
+	}
+	rest := ""
+	if len(a) > 1 {
+		rest = a[1:]
+	}
+	return string(rankDigits[digitA]) + RankBetween(rest, "")
+}
+
+// EvenRanks menghasilkan n rank berurutan dengan jarak merata, dipakai
+// saat rebalancing kolom yang rank-nya sudah terlalu rapat
+func EvenRanks(n int) []string {
+	width := 1
+	for capacity := rankBase; capacity <= (n+1)*rankBase; capacity *= rankBase {
+		width++
+	}
+
+	space := 1
+	for i := 0; i < width; i++ {
+		space *= rankBase
+	}
+	step := space / (n + 1)
+
+	ranks := make([]string, n)
+	for i := range ranks {
+		value := (i + 1) * step
+		if value%rankBase == 0 {
+			value++
+		}
+		ranks[i] = encodeRank(value, width)
+	}
+	return ranks
+}
+
+func encodeRank(value, width int) string {
+	digits := make([]byte, width)
+	for i := width - 1; i >= 0; i-- {
+		digits[i] = rankDigits[value%rankBase]
+		value /= rankBase
+	}
+	return string(digits)
+}
+
+func rankDigit(c byte) int {
+	return strings.IndexByte(rankDigits, c)
+}
+
+func rankDigitAt(s string, i int) int {
+	if i >= len(s) {
+		return 0
+	}
+	return rankDigit(s[i])
+}
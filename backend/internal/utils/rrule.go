@@ -0,0 +1,352 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RRule adalah subset RFC 5545 RRULE yang didukung untuk task berulang:
+// FREQ (DAILY, WEEKLY, MONTHLY, YEARLY), INTERVAL, BYDAY, BYMONTHDAY,
+// BYMONTH, COUNT dan UNTIL. Minggu selalu dimulai hari Senin (WKST=MO) dan
+// BYDAY pada FREQ=YEARLY hanya dihitung di bulan dtstart atau BYMONTH.
+type RRule struct {
+	Freq       string
+	Interval   int
+	ByDay      []RRuleWeekday
+	ByMonthDay []int
+	ByMonth    []time.Month
+	Count      int
+	Until      *time.Time
+}
+
+// RRuleWeekday adalah satu entry BYDAY, misalnya "MO" atau "-1FR".
+// Ordinal hanya berlaku untuk FREQ=MONTHLY dan YEARLY, 0 berarti semua.
+type RRuleWeekday struct {
+	Ordinal int
+	Weekday time.Weekday
+}
+
+const (
+	RRuleDaily   = "DAILY"
+	RRuleWeekly  = "WEEKLY"
+	RRuleMonthly = "MONTHLY"
+	RRuleYearly  = "YEARLY"
+)
+
+// maxRRulePeriods membatasi iterasi supaya rule yang tidak pernah cocok
+// (misalnya BYMONTHDAY=31 dengan BYMONTH=2) tidak berputar selamanya
+const maxRRulePeriods = 10000
+
+var rruleWeekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+var ErrInvalidRRule = errors.New("invalid recurrence rule")
+
+// ParseRRule mem-parse string RRULE, boleh diawali "RRULE:"
+func ParseRRule(value string) (*RRule, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return nil, fmt.Errorf("%w: rule is empty", ErrInvalidRRule)
+	}
+
+	rule := &RRule{Interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" {
+			return nil, fmt.Errorf("%w: malformed part %q", ErrInvalidRRule, part)
+		}
+
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Freq = strings.ToUpper(val)
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(val)
+			if err == nil && rule.Interval < 1 {
+				err = errors.New("must be positive")
+			}
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(val)
+			if err == nil && rule.Count < 1 {
+				err = errors.New("must be positive")
+			}
+		case "UNTIL":
+			var until time.Time
+			until, err = parseRRuleTime(val)
+			rule.Until = &until
+		case "BYDAY":
+			rule.ByDay, err = parseRRuleWeekdays(val)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseRRuleInts(val, -31, 31)
+		case "BYMONTH":
+			var months []int
+			months, err = parseRRuleInts(val, 1, 12)
+			for _, month := range months {
+				rule.ByMonth = append(rule.ByMonth, time.Month(month))
+			}
+		case "WKST":
+			if strings.ToUpper(val) != "MO" {
+				err = errors.New("only MO is supported")
+			}
+		default:
+			return nil, fmt.Errorf("%w: unsupported part %s", ErrInvalidRRule, key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidRRule, key, err)
+		}
+	}
+
+	switch rule.Freq {
+	case RRuleDaily, RRuleWeekly, RRuleMonthly, RRuleYearly:
+	case "":
+		return nil, fmt.Errorf("%w: FREQ is required", ErrInvalidRRule)
+	default:
+		return nil, fmt.Errorf("%w: unsupported FREQ %s", ErrInvalidRRule, rule.Freq)
+	}
+	if rule.Count > 0 && rule.Until != nil {
+		return nil, fmt.Errorf("%w: COUNT and UNTIL cannot be combined", ErrInvalidRRule)
+	}
+	for _, day := range rule.ByDay {
+		if day.Ordinal != 0 && rule.Freq != RRuleMonthly && rule.Freq != RRuleYearly {
+			return nil, fmt.Errorf("%w: BYDAY ordinals need FREQ=MONTHLY or YEARLY", ErrInvalidRRule)
+		}
+	}
+
+	return rule, nil
+}
+
+func parseRRuleTime(value string) (time.Time, error) {
+	if strings.HasSuffix(value, "Z") {
+		return time.Parse("20060102T150405Z", value)
+	}
+	if len(value) == 8 {
+		// UNTIL berupa tanggal saja berlaku sampai akhir hari tersebut (UTC)
+		date, err := time.Parse("20060102", value)
+		return date.Add(24*time.Hour - time.Second), err
+	}
+	return time.Parse("20060102T150405", value)
+}
+
+func parseRRuleWeekdays(value string) ([]RRuleWeekday, error) {
+	var days []RRuleWeekday
+	for _, item := range strings.Split(strings.ToUpper(value), ",") {
+		if len(item) < 2 {
+			return nil, fmt.Errorf("invalid weekday %q", item)
+		}
+		weekday, ok := rruleWeekdays[item[len(item)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("invalid weekday %q", item)
+		}
+
+		day := RRuleWeekday{Weekday: weekday}
+		if prefix := item[:len(item)-2]; prefix != "" {
+			ordinal, err := strconv.Atoi(prefix)
+			if err != nil || ordinal == 0 || ordinal < -5 || ordinal > 5 {
+				return nil, fmt.Errorf("invalid weekday %q", item)
+			}
+			day.Ordinal = ordinal
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+func parseRRuleInts(value string, min, max int) ([]int, error) {
+	var values []int
+	for _, item := range strings.Split(value, ",") {
+		number, err := strconv.Atoi(item)
+		if err != nil || number == 0 || number < min || number > max {
+			return nil, fmt.Errorf("invalid value %q", item)
+		}
+		values = append(values, number)
+	}
+	return values, nil
+}
+
+// Next mengembalikan occurrence pertama setelah `after` untuk seri yang
+// dimulai pada dtstart. Jam occurrence mengikuti jam lokal dtstart di
+// location-nya, jadi "setiap Senin 09:00" tetap 09:00 saat pergantian DST.
+// skip dipanggil untuk occurrence yang di-skip; occurrence tersebut tetap
+// dihitung untuk COUNT sesuai RFC 5545 (EXDATE diterapkan setelah COUNT).
+func (r *RRule) Next(dtstart, after time.Time, skip func(time.Time) bool) (time.Time, bool) {
+	var next time.Time
+	found := false
+	r.iterate(dtstart, func(occurrence time.Time) bool {
+		if !occurrence.After(after) || (skip != nil && skip(occurrence)) {
+			return true
+		}
+		next, found = occurrence, true
+		return false
+	})
+	return next, found
+}
+
+// Upcoming mengembalikan maksimal limit occurrence setelah `after`
+func (r *RRule) Upcoming(dtstart, after time.Time, limit int, skip func(time.Time) bool) []time.Time {
+	var occurrences []time.Time
+	r.iterate(dtstart, func(occurrence time.Time) bool {
+		if occurrence.After(after) && (skip == nil || !skip(occurrence)) {
+			occurrences = append(occurrences, occurrence)
+		}
+		return len(occurrences) < limit
+	})
+	return occurrences
+}
+
+// iterate memanggil yield untuk setiap occurrence secara berurutan sampai
+// yield mengembalikan false, COUNT/UNTIL tercapai, atau batas iterasi habis
+func (r *RRule) iterate(dtstart time.Time, yield func(time.Time) bool) {
+	count := 0
+	for period := 0; period < maxRRulePeriods; period++ {
+		for _, occurrence := range r.candidates(dtstart, period) {
+			if occurrence.Before(dtstart) {
+				continue
+			}
+			if r.Until != nil && occurrence.After(*r.Until) {
+				return
+			}
+			count++
+			if !yield(occurrence) {
+				return
+			}
+			if r.Count > 0 && count >= r.Count {
+				return
+			}
+		}
+	}
+}
+
+// candidates menghasilkan occurrence (terurut) pada periode ke-n sejak dtstart
+func (r *RRule) candidates(dtstart time.Time, n int) []time.Time {
+	step := n * r.Interval
+	var days []time.Time
+
+	switch r.Freq {
+	case RRuleDaily:
+		days = append(days, dateOf(dtstart).AddDate(0, 0, step))
+	case RRuleWeekly:
+		offset := (int(dtstart.Weekday()) + 6) % 7
+		monday := dateOf(dtstart).AddDate(0, 0, step*7-offset)
+		for i := 0; i < 7; i++ {
+			days = append(days, monday.AddDate(0, 0, i))
+		}
+		if len(r.ByDay) == 0 {
+			days = filterDays(days, func(day time.Time) bool { return day.Weekday() == dtstart.Weekday() })
+		}
+	case RRuleMonthly:
+		first := time.Date(dtstart.Year(), dtstart.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, step, 0)
+		days = r.daysInMonth(dtstart, first.Year(), first.Month())
+	case RRuleYearly:
+		year := dtstart.Year() + step
+		months := r.ByMonth
+		if len(months) == 0 {
+			months = []time.Month{dtstart.Month()}
+		}
+		for _, month := range months {
+			days = append(days, r.daysInMonth(dtstart, year, month)...)
+		}
+	}
+
+	if len(r.ByMonth) > 0 {
+		days = filterDays(days, func(day time.Time) bool { return containsMonth(r.ByMonth, day.Month()) })
+	}
+	if len(r.ByDay) > 0 && r.Freq != RRuleMonthly && r.Freq != RRuleYearly {
+		days = filterDays(days, func(day time.Time) bool { return r.matchesWeekday(day) })
+	}
+
+	occurrences := make([]time.Time, 0, len(days))
+	for _, day := range days {
+		occurrences = append(occurrences, time.Date(day.Year(), day.Month(), day.Day(),
+			dtstart.Hour(), dtstart.Minute(), dtstart.Second(), 0, dtstart.Location()))
+	}
+	sort.Slice(occurrences, func(i, j int) bool { return occurrences[i].Before(occurrences[j]) })
+	return occurrences
+}
+
+// daysInMonth menerapkan BYMONTHDAY/BYDAY dalam satu bulan. Tanpa keduanya
+// dipakai tanggal dtstart; bulan yang tidak punya tanggal itu dilewati.
+func (r *RRule) daysInMonth(dtstart time.Time, year int, month time.Month) []time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1).Day()
+	var days []time.Time
+
+	switch {
+	case len(r.ByMonthDay) > 0:
+		for _, monthDay := range r.ByMonthDay {
+			if monthDay < 0 {
+				monthDay = last + monthDay + 1
+			}
+			if monthDay >= 1 && monthDay <= last {
+				days = append(days, first.AddDate(0, 0, monthDay-1))
+			}
+		}
+		if len(r.ByDay) > 0 {
+			days = filterDays(days, func(day time.Time) bool { return r.matchesWeekday(day) })
+		}
+	case len(r.ByDay) > 0:
+		for _, byDay := range r.ByDay {
+			var matches []time.Time
+			for day := 1; day <= last; day++ {
+				date := first.AddDate(0, 0, day-1)
+				if date.Weekday() == byDay.Weekday {
+					matches = append(matches, date)
+				}
+			}
+			switch {
+			case byDay.Ordinal == 0:
+				days = append(days, matches...)
+			case byDay.Ordinal > 0 && byDay.Ordinal <= len(matches):
+				days = append(days, matches[byDay.Ordinal-1])
+			case byDay.Ordinal < 0 && -byDay.Ordinal <= len(matches):
+				days = append(days, matches[len(matches)+byDay.Ordinal])
+			}
+		}
+	case dtstart.Day() <= last:
+		days = append(days, first.AddDate(0, 0, dtstart.Day()-1))
+	}
+
+	return days
+}
+
+func (r *RRule) matchesWeekday(day time.Time) bool {
+	for _, byDay := range r.ByDay {
+		if byDay.Weekday == day.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func filterDays(days []time.Time, keep func(time.Time) bool) []time.Time {
+	filtered := days[:0]
+	for _, day := range days {
+		if keep(day) {
+			filtered = append(filtered, day)
+		}
+	}
+	return filtered
+}
+
+func containsMonth(months []time.Month, month time.Month) bool {
+	for _, m := range months {
+		if m == month {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package worker
+
+import (
+	"backend/internal/service"
+	"context"
+	"log"
+	"time"
+)
+
+// ImportWorker menjalankan job import yang pending satu per satu
+type ImportWorker struct {
+	importService service.ImportService
+	interval      time.Duration
+}
+
+func NewImportWorker(importService service.ImportService, interval time.Duration) *ImportWorker {
+	return &ImportWorker{
+		importService: importService,
+		interval:      interval,
+	}
+}
+
+// Start menjalankan worker di goroutine sendiri sampai ctx dibatalkan
+func (w *ImportWorker) Start(ctx context.Context) {
+	runEvery(ctx, w.interval, w.run)
+}
+
+// run memproses semua job yang menunggu sebelum menunggu putaran berikutnya
+func (w *ImportWorker) run(ctx context.Context) {
+	for ctx.Err() == nil {
+		processed, err := w.importService.ProcessNext(ctx)
+		if err != nil {
+			log.Printf("Import worker error: %v", err)
+			return
+		}
+		if !processed {
+			return
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package worker
+
+import (
+	"backend/internal/service"
+	"context"
+	"log"
+	"time"
+)
+
+// RecurrenceWorker secara berkala membuat occurrence berikutnya untuk task
+// berulang yang jadwalnya sudah tiba
+type RecurrenceWorker struct {
+	recurrenceService service.RecurrenceService
+	interval          time.Duration
+}
+
+func NewRecurrenceWorker(recurrenceService service.RecurrenceService, interval time.Duration) *RecurrenceWorker {
+	return &RecurrenceWorker{
+		recurrenceService: recurrenceService,
+		interval:          interval,
+	}
+}
+
+// Start menjalankan worker di goroutine sendiri sampai ctx dibatalkan
+func (w *RecurrenceWorker) Start(ctx context.Context) {
+	runEvery(ctx, w.interval, w.run)
+}
+
+func (w *RecurrenceWorker) run(ctx context.Context) {
+	created, err := w.recurrenceService.GenerateDue(ctx, time.Now())
+	if err != nil {
+		log.Printf("Recurrence worker error: %v", err)
+		return
+	}
+	if created > 0 {
+		log.Printf("Recurrence worker created %d task(s)", created)
+	}
+}
@@ -0,0 +1,38 @@
+package worker
+
+import (
+	"backend/internal/service"
+	"context"
+	"log"
+	"time"
+)
+
+// ReminderWorker secara berkala menandai task overdue dan mengirim reminder
+// deadline yang jatuh tempo
+type ReminderWorker struct {
+	reminderService service.ReminderService
+	interval        time.Duration
+}
+
+func NewReminderWorker(reminderService service.ReminderService, interval time.Duration) *ReminderWorker {
+	return &ReminderWorker{
+		reminderService: reminderService,
+		interval:        interval,
+	}
+}
+
+// Start menjalankan worker di goroutine sendiri sampai ctx dibatalkan
+func (w *ReminderWorker) Start(ctx context.Context) {
+	runEvery(ctx, w.interval, w.run)
+}
+
+func (w *ReminderWorker) run(ctx context.Context) {
+	sent, err := w.reminderService.ProcessDue(ctx, time.Now())
+	if err != nil {
+		log.Printf("Reminder worker error: %v", err)
+		return
+	}
+	if sent > 0 {
+		log.Printf("Reminder worker sent %d reminder(s)", sent)
+	}
+}
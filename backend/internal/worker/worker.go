@@ -0,0 +1,25 @@
+package worker
+
+import (
+	"context"
+	"time"
+)
+
+// runEvery menjalankan fn segera lalu setiap interval di goroutine sendiri
+// sampai ctx dibatalkan
+func runEvery(ctx context.Context, interval time.Duration, fn func(ctx context.Context)) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			fn(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}